@@ -25,6 +25,7 @@ type Option interface {
 type Options struct {
 	FgColor Color
 	BgColor Color
+	Blink   bool
 }
 
 // Set allows existing options to be passed as an option.
@@ -62,3 +63,12 @@ func BgColor(color Color) Option {
 		co.BgColor = color
 	})
 }
+
+// Blink makes the content of the cell blink. Terminals that don't support
+// blinking either ignore this or emulate it in software, see the
+// documentation of the terminal implementation.
+func Blink() Option {
+	return option(func(co *Options) {
+		co.Blink = true
+	})
+}
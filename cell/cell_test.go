@@ -72,6 +72,17 @@ func TestNewOptions(t *testing.T) {
 				BgColor: ColorMagenta,
 			},
 		},
+		{
+			desc: "setting blink",
+			opts: []Option{
+				FgColor(ColorCyan),
+				Blink(),
+			},
+			want: &Options{
+				FgColor: ColorCyan,
+				Blink:   true,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -16,6 +16,7 @@ package cell
 
 import (
 	"fmt"
+	"strings"
 )
 
 // color.go defines constants for cell colors.
@@ -59,6 +60,45 @@ const (
 	ColorWhite
 )
 
+// byName maps names accepted by ColorByName to colors.
+var byName = map[string]Color{
+	"default": ColorDefault,
+	"black":   ColorBlack,
+	"red":     ColorRed,
+	"green":   ColorGreen,
+	"yellow":  ColorYellow,
+	"blue":    ColorBlue,
+	"magenta": ColorMagenta,
+	"cyan":    ColorCyan,
+	"white":   ColorWhite,
+
+	// The "bright" variants of the 8 system colors.
+	"bright-black":   ColorNumber(8),
+	"bright-red":     ColorNumber(9),
+	"bright-green":   ColorNumber(10),
+	"bright-yellow":  ColorNumber(11),
+	"bright-blue":    ColorNumber(12),
+	"bright-magenta": ColorNumber(13),
+	"bright-cyan":    ColorNumber(14),
+	"bright-white":   ColorNumber(15),
+}
+
+// ColorByName returns the color with the provided name. Useful when colors
+// are loaded from configuration.
+// The supported names are "default", the 8 system colors "black", "red",
+// "green", "yellow", "blue", "magenta", "cyan", "white" and their bright
+// variants prefixed with "bright-", e.g. "bright-blue".
+// Names are case insensitive. The bright variants are terminal colors 8-15,
+// make sure your terminal is set to a terminalapi.ColorMode that supports
+// them.
+func ColorByName(name string) (Color, error) {
+	c, ok := byName[strings.ToLower(name)]
+	if !ok {
+		return ColorDefault, fmt.Errorf("unknown color name %q", name)
+	}
+	return c, nil
+}
+
 // ColorNumber sets a color using its number.
 // Make sure your terminal is set to a terminalapi.ColorMode that supports the
 // target color. The provided value must be in the range 0-255.
@@ -205,3 +205,50 @@ func TestColorRGB24(t *testing.T) {
 		})
 	}
 }
+
+func TestColorByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Color
+		wantErr bool
+	}{
+		{name: "default", want: ColorDefault},
+		{name: "black", want: ColorBlack},
+		{name: "red", want: ColorRed},
+		{name: "green", want: ColorGreen},
+		{name: "yellow", want: ColorYellow},
+		{name: "blue", want: ColorBlue},
+		{name: "magenta", want: ColorMagenta},
+		{name: "cyan", want: ColorCyan},
+		{name: "white", want: ColorWhite},
+		{name: "bright-black", want: Color(9)},
+		{name: "bright-red", want: Color(10)},
+		{name: "bright-green", want: Color(11)},
+		{name: "bright-yellow", want: Color(12)},
+		{name: "bright-blue", want: Color(13)},
+		{name: "bright-magenta", want: Color(14)},
+		{name: "bright-cyan", want: Color(15)},
+		{name: "bright-white", want: Color(16)},
+		{name: "Bright-Blue", want: Color(13)},
+		{name: "RED", want: ColorRed},
+		{name: "", wantErr: true},
+		{name: "purple", wantErr: true},
+		{name: "bright red", wantErr: true},
+		{name: " red", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ColorByName(tc.name)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ColorByName(%q) => unexpected error: %v, wantErr: %v", tc.name, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ColorByName(%q) => %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
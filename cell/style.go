@@ -0,0 +1,76 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+// style.go defines a reusable combination of cell options.
+
+// Style is a reusable combination of cell options, e.g. one of the named
+// styles of a palette built when the dashboard starts.
+// A style is applied with the FromStyle option or by expanding its Options
+// wherever cell options are accepted. It only sets the attributes that its
+// options set, so it can be combined with other options, options provided
+// after it override it.
+// The zero value is an empty style that doesn't set any attributes. Styles
+// are immutable, the With methods return modified copies that don't share
+// state with the original, so they are safe for concurrent use.
+type Style struct {
+	// opts are the options of the style in the order they are applied.
+	opts []Option
+}
+
+// NewStyle returns a new style that applies the provided options.
+func NewStyle(opts ...Option) Style {
+	return Style{}.With(opts...)
+}
+
+// With returns a copy of the style that additionally applies the provided
+// options after the options of this style.
+func (s Style) With(opts ...Option) Style {
+	res := make([]Option, 0, len(s.opts)+len(opts))
+	res = append(res, s.opts...)
+	res = append(res, opts...)
+	return Style{opts: res}
+}
+
+// WithFg returns a copy of the style that uses the provided foreground color.
+func (s Style) WithFg(color Color) Style {
+	return s.With(FgColor(color))
+}
+
+// WithBg returns a copy of the style that uses the provided background color.
+func (s Style) WithBg(color Color) Style {
+	return s.With(BgColor(color))
+}
+
+// WithBlink returns a copy of the style that makes the content of the cell
+// blink, see the Blink option.
+func (s Style) WithBlink() Style {
+	return s.With(Blink())
+}
+
+// Options returns the options of the style. The returned slice can be
+// modified without affecting the style.
+func (s Style) Options() []Option {
+	return append([]Option(nil), s.opts...)
+}
+
+// FromStyle applies all the options of the provided style.
+func FromStyle(s Style) Option {
+	return option(func(co *Options) {
+		for _, o := range s.opts {
+			o.Set(co)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestStyle(t *testing.T) {
+	base := NewStyle(FgColor(ColorRed), BgColor(ColorBlack))
+
+	tests := []struct {
+		desc string
+		opts []Option
+		want *Options
+	}{
+		{
+			desc: "zero value style sets nothing",
+			opts: []Option{
+				FromStyle(Style{}),
+			},
+			want: &Options{},
+		},
+		{
+			desc: "applies the options of the style",
+			opts: []Option{
+				FromStyle(base),
+			},
+			want: &Options{
+				FgColor: ColorRed,
+				BgColor: ColorBlack,
+			},
+		},
+		{
+			desc: "options of the style can be expanded",
+			opts: base.Options(),
+			want: &Options{
+				FgColor: ColorRed,
+				BgColor: ColorBlack,
+			},
+		},
+		{
+			desc: "only sets the attributes the style sets",
+			opts: []Option{
+				Blink(),
+				FromStyle(NewStyle(FgColor(ColorRed))),
+			},
+			want: &Options{
+				FgColor: ColorRed,
+				Blink:   true,
+			},
+		},
+		{
+			desc: "options provided after the style override it",
+			opts: []Option{
+				FromStyle(base),
+				FgColor(ColorBlue),
+			},
+			want: &Options{
+				FgColor: ColorBlue,
+				BgColor: ColorBlack,
+			},
+		},
+		{
+			desc: "modified copies override the original options",
+			opts: []Option{
+				FromStyle(base.WithFg(ColorGreen).WithBg(ColorWhite).WithBlink()),
+			},
+			want: &Options{
+				FgColor: ColorGreen,
+				BgColor: ColorWhite,
+				Blink:   true,
+			},
+		},
+		{
+			desc: "with appends options",
+			opts: []Option{
+				FromStyle(base.With(Blink())),
+			},
+			want: &Options{
+				FgColor: ColorRed,
+				BgColor: ColorBlack,
+				Blink:   true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := NewOptions(tc.opts...)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewOptions => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStyleCopiesDontShareState(t *testing.T) {
+	opts := []Option{FgColor(ColorRed)}
+	base := NewStyle(opts...)
+	opts[0] = FgColor(ColorBlue)
+
+	// Derive two styles from the same base.
+	base = base.With(BgColor(ColorBlack))
+	green := base.WithFg(ColorGreen)
+	yellow := base.WithFg(ColorYellow)
+	base.Options()[0] = FgColor(ColorBlue)
+
+	for _, tc := range []struct {
+		desc  string
+		style Style
+		want  *Options
+	}{
+		{"base", base, &Options{FgColor: ColorRed, BgColor: ColorBlack}},
+		{"green", green, &Options{FgColor: ColorGreen, BgColor: ColorBlack}},
+		{"yellow", yellow, &Options{FgColor: ColorYellow, BgColor: ColorBlack}},
+	} {
+		got := NewOptions(FromStyle(tc.style))
+		if diff := pretty.Compare(tc.want, got); diff != "" {
+			t.Errorf("%s => unexpected diff (-want, +got):\n%s", tc.desc, diff)
+		}
+	}
+}
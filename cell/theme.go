@@ -0,0 +1,67 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+// theme.go defines a theme that assigns cell options to roles.
+
+// Theme assigns cell options to the roles elements of a dashboard have, so
+// that containers and widgets can be styled consistently.
+// Containers and widgets that support themes accept it via their WithTheme
+// option and use it as defaults for their own options.
+type Theme struct {
+	// Border is used for borders around containers and widgets.
+	Border Options
+	// Text is used for text, e.g. labels.
+	Text Options
+	// Accent is used for highlighted elements, e.g. focused borders or the
+	// bars of a gauge.
+	Accent Options
+	// Warning is used for elements that display a warning.
+	Warning Options
+	// Error is used for elements that display an error.
+	Error Options
+}
+
+// BorderOpts returns the cell options for the border role.
+func (t *Theme) BorderOpts() []Option {
+	return roleOpts(t.Border)
+}
+
+// TextOpts returns the cell options for the text role.
+func (t *Theme) TextOpts() []Option {
+	return roleOpts(t.Text)
+}
+
+// AccentOpts returns the cell options for the accent role.
+func (t *Theme) AccentOpts() []Option {
+	return roleOpts(t.Accent)
+}
+
+// WarningOpts returns the cell options for the warning role.
+func (t *Theme) WarningOpts() []Option {
+	return roleOpts(t.Warning)
+}
+
+// ErrorOpts returns the cell options for the error role.
+func (t *Theme) ErrorOpts() []Option {
+	return roleOpts(t.Error)
+}
+
+// roleOpts returns the options of a role as a slice of cell options.
+// The options are copied so that the returned slice doesn't share state with
+// the theme.
+func roleOpts(o Options) []Option {
+	return []Option{&o}
+}
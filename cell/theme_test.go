@@ -0,0 +1,85 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestTheme(t *testing.T) {
+	theme := &Theme{
+		Border:  Options{FgColor: ColorBlue},
+		Text:    Options{FgColor: ColorWhite, BgColor: ColorBlack},
+		Accent:  Options{FgColor: ColorCyan},
+		Warning: Options{FgColor: ColorYellow},
+		Error:   Options{FgColor: ColorRed, Blink: true},
+	}
+
+	tests := []struct {
+		desc string
+		opts func() []Option
+		want *Options
+	}{
+		{
+			desc: "border",
+			opts: theme.BorderOpts,
+			want: &Options{FgColor: ColorBlue},
+		},
+		{
+			desc: "text",
+			opts: theme.TextOpts,
+			want: &Options{FgColor: ColorWhite, BgColor: ColorBlack},
+		},
+		{
+			desc: "accent",
+			opts: theme.AccentOpts,
+			want: &Options{FgColor: ColorCyan},
+		},
+		{
+			desc: "warning",
+			opts: theme.WarningOpts,
+			want: &Options{FgColor: ColorYellow},
+		},
+		{
+			desc: "error",
+			opts: theme.ErrorOpts,
+			want: &Options{FgColor: ColorRed, Blink: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := NewOptions(tc.opts()...)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewOptions => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestThemeOptsDontShareState(t *testing.T) {
+	theme := &Theme{
+		Text: Options{FgColor: ColorWhite},
+	}
+	opts := theme.TextOpts()
+	theme.Text.FgColor = ColorRed
+
+	want := &Options{FgColor: ColorWhite}
+	if diff := pretty.Compare(want, NewOptions(opts...)); diff != "" {
+		t.Errorf("NewOptions => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
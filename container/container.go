@@ -18,6 +18,19 @@ Package container defines a type that wraps other containers or widgets.
 The container supports splitting container into sub containers, defining
 container styles and placing widgets. The container also creates and manages
 canvases assigned to the placed widgets.
+
+When splitting, the container tries to satisfy the minimum sizes requested by
+the widgets placed in the sub containers. If the requested split would give a
+sub container less space than the minimum size of its widgets (including
+borders and any nested splits), the space is reallocated from the sibling.
+When the sum of both minimum sizes exceeds the available space, the first (left
+or top) sub container is satisfied first and the second (right or bottom) sub
+container gets the remainder.
+
+Widgets can also report a preferred size via widgetapi.Options.PreferredSize.
+The preferred size is advisory and all the currently available splits have an
+explicit ratio (SplitPercent defaults to DefaultSplitPercent), so it doesn't
+affect the split sizes.
 */
 package container
 
@@ -61,6 +74,36 @@ type Container struct {
 	// mu protects the container tree.
 	// All containers in the tree share the same lock.
 	mu *sync.Mutex
+
+	// notify is the function provided to widgets that implement
+	// widgetapi.Notifier. Only set on the root container, nil until Notify is
+	// called.
+	notify func()
+
+	// eds is the event distribution system the container tree is subscribed
+	// to. Only set on the root container, nil until Subscribe is called.
+	eds *event.DistributionSystem
+	// tabsSubscribed indicates that the tabs are subscribed to keyboard
+	// events. Only set on the root container.
+	tabsSubscribed bool
+	// widgetSubs are the event subscriptions of the widgets keyed by the
+	// containers they are placed in. Only set on the root container.
+	widgetSubs map[*Container]*widgetSubs
+}
+
+// widgetSubs are the event subscriptions of a widget.
+type widgetSubs struct {
+	// widget is the subscribed widget.
+	widget widgetapi.Widget
+	// stops are the functions that cancel the subscriptions.
+	stops []event.StopFunc
+}
+
+// stop cancels the subscriptions.
+func (ws *widgetSubs) stop() {
+	for _, stop := range ws.stops {
+		stop()
+	}
 }
 
 // String represents the container metadata in a human readable format.
@@ -111,13 +154,53 @@ func (c *Container) hasWidget() bool {
 	return c.opts.widget != nil
 }
 
+// isHidden determines if this container or any of its parents are hidden.
+func (c *Container) isHidden() bool {
+	for ; c != nil; c = c.parent {
+		if c.opts.hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// inset returns the area shrunk on each side by the cells and the percentage
+// of its size. The inset is reduced so that the returned area is at least
+// of the needed size, if possible.
+func inset(ar image.Rectangle, cells, percent int, need image.Point) image.Rectangle {
+	insetFor := func(size, need int) int {
+		if need < 0 {
+			need = 0
+		}
+		in := cells + size*percent/100
+		if max := (size - need) / 2; in > max {
+			in = max
+		}
+		if in < 0 {
+			in = 0
+		}
+		return in
+	}
+	dx := insetFor(ar.Dx(), need.X)
+	dy := insetFor(ar.Dy(), need.Y)
+	return image.Rect(ar.Min.X+dx, ar.Min.Y+dy, ar.Max.X-dx, ar.Max.Y-dy)
+}
+
+// outer returns the area of this container inside of its margin, i.e. the
+// area the border is drawn on.
+func (c *Container) outer() image.Rectangle {
+	need := c.minSize().Sub(image.Point{2 * c.opts.margin, 2 * c.opts.margin})
+	return inset(c.area, c.opts.margin, c.opts.marginPercent, need)
+}
+
 // usable returns the usable area in this container.
-// This depends on whether the container has a border, etc.
+// This depends on whether the container has a border, margin, padding, etc.
 func (c *Container) usable() image.Rectangle {
+	ar := c.outer()
 	if c.hasBorder() {
-		return area.ExcludeBorder(c.area)
+		ar = area.ExcludeBorder(ar)
 	}
-	return c.area
+	return inset(ar, c.opts.padding, c.opts.paddingPercent, c.contentMinSize())
 }
 
 // widgetArea returns the area in the container that is available for the
@@ -152,11 +235,149 @@ func (c *Container) widgetArea() (image.Rectangle, error) {
 // split splits the container's usable area into child areas.
 // Panics if the container isn't configured for a split.
 func (c *Container) split() (image.Rectangle, image.Rectangle, error) {
+	first, _, second, err := c.splitDivider()
+	return first, second, err
+}
+
+// hasDivider determines if a divider is drawn between the sub containers.
+func (c *Container) hasDivider() bool {
+	if c.opts.divider == linestyle.None {
+		return false
+	}
+	return !(c.first != nil && c.first.opts.hidden) && !(c.second != nil && c.second.opts.hidden)
+}
+
+// splitDivider is like split, but also returns the area of the divider
+// between the child areas. The divider area is empty if the container doesn't
+// have a divider, see SplitDivider.
+func (c *Container) splitDivider() (first, divider, second image.Rectangle, err error) {
 	ar := c.usable()
+	if c.opts.tabs != nil && ar.Dy() > 0 {
+		// The top row is reserved for the tab bar.
+		ar.Min.Y++
+	}
+	total := ar.Dy()
 	if c.opts.split == splitTypeVertical {
-		return area.VSplit(ar, c.opts.splitPercent)
+		total = ar.Dx()
+	}
+	var div int
+	if c.hasDivider() && total > 0 {
+		div = 1
+		total -= div
+	}
+
+	var cells int
+	if parts := c.opts.splitParts; parts > 0 {
+		cells = total / parts
+	} else {
+		cells = total * c.opts.splitPercent / 100
+	}
+	cells = c.fitMinSize(cells, total)
+	switch {
+	case c.first != nil && c.first.opts.hidden:
+		cells = 0
+	case c.second != nil && c.second.opts.hidden:
+		cells = total
+	}
+
+	if c.opts.split == splitTypeVertical {
+		first, second, err = area.VSplitCells(ar, cells)
+		if err != nil {
+			return image.ZR, image.ZR, image.ZR, err
+		}
+		divider = image.Rect(second.Min.X, second.Min.Y, second.Min.X+div, second.Max.Y)
+		second.Min.X += div
+		return first, divider, second, nil
+	}
+
+	first, second, err = area.HSplitCells(ar, cells)
+	if err != nil {
+		return image.ZR, image.ZR, image.ZR, err
+	}
+	divider = image.Rect(second.Min.X, second.Min.Y, second.Max.X, second.Min.Y+div)
+	second.Min.Y += div
+	return first, divider, second, nil
+}
+
+// fitMinSize adjusts the number of cells given to the first sub container out
+// of the total cells available, so that both sub containers get at least their
+// minimum size along the split axis. See the package documentation for the
+// resolution order when that isn't possible.
+func (c *Container) fitMinSize(cells, total int) int {
+	firstMin, secondMin := c.first.minSize(), c.second.minSize()
+	fMin, sMin := firstMin.Y, secondMin.Y
+	if c.opts.split == splitTypeVertical {
+		fMin, sMin = firstMin.X, secondMin.X
+	}
+
+	if fMin > total {
+		fMin = total
+	}
+	if cells < fMin {
+		return fMin
+	}
+	if rest := total - cells; rest < sMin {
+		if cells = total - sMin; cells < fMin {
+			return fMin
+		}
+	}
+	return cells
+}
+
+// minSize returns the minimum size this container needs in order to satisfy
+// the minimum sizes of all the widgets placed in it or its sub containers.
+// Returns a zero size for a nil container.
+func (c *Container) minSize() image.Point {
+	if c == nil || c.opts.hidden {
+		return image.ZP
+	}
+
+	size := c.contentMinSize()
+	size = size.Add(image.Point{2 * c.opts.padding, 2 * c.opts.padding})
+	if c.hasBorder() {
+		size = size.Add(image.Point{2, 2})
+	}
+	return size.Add(image.Point{2 * c.opts.margin, 2 * c.opts.margin})
+}
+
+// contentMinSize returns the minimum size of the content of this container,
+// i.e. of its widget or sub containers and its tab bar.
+// Returns a zero size for a nil container.
+func (c *Container) contentMinSize() image.Point {
+	if c == nil || c.opts.hidden {
+		return image.ZP
+	}
+
+	var size image.Point
+	switch {
+	case c.hasWidget():
+		size = c.opts.widget.Options().MinimumSize
+	case c.first != nil || c.second != nil:
+		f, s := c.first.minSize(), c.second.minSize()
+		if c.hasDivider() {
+			if c.opts.split == splitTypeVertical {
+				f.X++
+			} else {
+				f.Y++
+			}
+		}
+		if c.opts.split == splitTypeVertical {
+			size = image.Point{f.X + s.X, f.Y}
+			if s.Y > size.Y {
+				size.Y = s.Y
+			}
+		} else {
+			size = image.Point{f.X, f.Y + s.Y}
+			if s.X > size.X {
+				size.X = s.X
+			}
+		}
+	}
+
+	if c.opts.tabs != nil {
+		size.Y++
 	}
-	return area.HSplit(ar, c.opts.splitPercent)
+	return size
 }
 
 // createFirst creates and returns the first sub container of this container.
@@ -186,6 +407,30 @@ func (c *Container) Draw() error {
 	return drawTree(c)
 }
 
+// Update updates the container with the specified ID by applying the
+// provided options. This can be used to perform dynamic layout changes, e.g.
+// hiding and showing parts of the layout or changing the borders.
+// The ID must match a container that was created with the ID option.
+// Widgets placed into containers by Update receive keyboard and mouse events
+// according to their options the same way as widgets placed by New.
+func (c *Container) Update(id string, opts ...Option) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	root := rootCont(c)
+	target, err := findID(root, id)
+	if err != nil {
+		return err
+	}
+	if err := applyOptions(target, opts...); err != nil {
+		return err
+	}
+	if root.eds != nil {
+		root.subscribeWidgets()
+	}
+	return nil
+}
+
 // updateFocus processes the mouse event and determines if it changes the
 // focused container.
 func (c *Container) updateFocus(m *terminalapi.Mouse) {
@@ -196,17 +441,27 @@ func (c *Container) updateFocus(m *terminalapi.Mouse) {
 	if target == nil { // Ignore mouse clicks where no containers are.
 		return
 	}
+	if ts := target.opts.tabs; ts != nil {
+		ts.mouse(m, tabBarArea(target))
+	}
 	c.focusTracker.mouse(target, m)
 }
 
-// keyboardToWidget forwards the keyboard event to the widget unconditionally.
+// keyboardToWidget forwards the keyboard event to the widget if it is in
+// scope and accepted by the widget's key filter.
 func (c *Container) keyboardToWidget(k *terminalapi.Keyboard, scope widgetapi.KeyScope) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.isHidden() {
+		return nil
+	}
 	if scope == widgetapi.KeyScopeFocused && !c.focusTracker.isActive(c) {
 		return nil
 	}
+	if f := c.opts.widget.Options().KeyFilter; f != nil && !f(k.Key) {
+		return nil
+	}
 	return c.opts.widget.Keyboard(k)
 }
 
@@ -215,6 +470,9 @@ func (c *Container) mouseToWidget(m *terminalapi.Mouse, scope widgetapi.MouseSco
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.isHidden() {
+		return nil
+	}
 	target := pointCont(c, m.Position)
 	if target == nil { // Ignore mouse clicks where no containers are.
 		return nil
@@ -241,18 +499,50 @@ func (c *Container) mouseToWidget(m *terminalapi.Mouse, scope widgetapi.MouseSco
 	var wm *terminalapi.Mouse
 	if m.Position.In(wa) {
 		wm = &terminalapi.Mouse{
-			Position: m.Position.Sub(offset),
-			Button:   m.Button,
+			Position:         m.Position.Sub(offset),
+			Button:           m.Button,
+			Motion:           m.Motion,
+			TerminalPosition: m.Position,
 		}
 	} else {
 		wm = &terminalapi.Mouse{
-			Position: image.Point{-1, -1},
-			Button:   m.Button,
+			Position:         image.Point{-1, -1},
+			Button:           m.Button,
+			Motion:           m.Motion,
+			TerminalPosition: m.Position,
 		}
 	}
 	return c.opts.widget.Mouse(wm)
 }
 
+// Notify provides the function that widgets implementing widgetapi.Notifier
+// call when their content changes. The function is provided to all the
+// widgets in the container tree and to any widgets placed later by Update.
+// This method is private to termdash, stability isn't guaranteed and changes
+// won't be backward compatible.
+func (c *Container) Notify(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	root := rootCont(c)
+	root.notify = fn
+	var errStr string
+	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+		if c.hasWidget() {
+			setNotify(c.opts.widget, fn)
+		}
+		return nil
+	}))
+}
+
+// setNotify provides the function to the widget if it implements
+// widgetapi.Notifier.
+func setNotify(w widgetapi.Widget, fn func()) {
+	if n, ok := w.(widgetapi.Notifier); ok && fn != nil {
+		n.SetNotify(fn)
+	}
+}
+
 // Subscribe tells the container to subscribe itself and widgets to the
 // provided event distribution system.
 // This method is private to termdash, stability isn't guaranteed and changes
@@ -261,45 +551,83 @@ func (c *Container) Subscribe(eds *event.DistributionSystem) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// maxReps is the maximum number of repetitive events towards widgets
-	// before we throttle them.
-	const maxReps = 10
-
 	root := rootCont(c)
+	root.eds = eds
 	// Subscriber the container itself in order to track keyboard focus.
 	eds.Subscribe([]terminalapi.Event{&terminalapi.Mouse{}}, func(ev terminalapi.Event) {
 		root.updateFocus(ev.(*terminalapi.Mouse))
 	}, event.MaxRepetitive(0)) // One event is enough to change the focus.
+	root.subscribeWidgets()
+}
+
+// maxReps is the maximum number of repetitive events towards widgets before
+// we throttle them.
+const maxReps = 10
+
+// subscribeWidgets subscribes the tabs and the widgets in the container tree
+// to the event distribution system. Widgets that are already subscribed keep
+// their subscriptions, subscriptions of widgets that were removed from the
+// tree are canceled.
+// Must only be called on the root container after Subscribe.
+// Caller must hold c.mu.
+func (c *Container) subscribeWidgets() {
+	root := c
+	eds := root.eds
+
+	// Subscribe the container to keyboard events if any of the containers
+	// has tabs that can be switched using the keyboard.
+	if !root.tabsSubscribed && hasTabs(root) {
+		eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(ev terminalapi.Event) {
+			root.keyboardToTabs(ev.(*terminalapi.Keyboard))
+		}, event.MaxRepetitive(maxReps))
+		root.tabsSubscribed = true
+	}
 
 	// Subscribe any widgets that specify Keyboard or Mouse in their options.
+	subs := map[*Container]*widgetSubs{}
 	var errStr string
 	preOrder(root, &errStr, visitFunc(func(c *Container) error {
-		if c.hasWidget() {
-			wOpt := c.opts.widget.Options()
-			switch scope := wOpt.WantKeyboard; scope {
-			case widgetapi.KeyScopeNone:
-				// Widget doesn't want any keyboard events.
-
-			default:
-				eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(ev terminalapi.Event) {
-					if err := c.keyboardToWidget(ev.(*terminalapi.Keyboard), scope); err != nil {
-						eds.Event(terminalapi.NewErrorf("failed to send global keyboard event %v to widget %T: %v", ev, c.opts.widget, err))
-					}
-				}, event.MaxRepetitive(maxReps))
-			}
+		if !c.hasWidget() {
+			return nil
+		}
+		if ws, ok := root.widgetSubs[c]; ok && ws.widget == c.opts.widget {
+			subs[c] = ws
+			return nil
+		}
+
+		ws := &widgetSubs{widget: c.opts.widget}
+		wOpt := c.opts.widget.Options()
+		switch scope := wOpt.WantKeyboard; scope {
+		case widgetapi.KeyScopeNone:
+			// Widget doesn't want any keyboard events.
+
+		default:
+			ws.stops = append(ws.stops, eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(ev terminalapi.Event) {
+				if err := c.keyboardToWidget(ev.(*terminalapi.Keyboard), scope); err != nil {
+					eds.Event(terminalapi.NewErrorf("failed to send global keyboard event %v to widget %T: %v", ev, c.opts.widget, err))
+				}
+			}, event.MaxRepetitive(maxReps)))
+		}
 
-			switch scope := wOpt.WantMouse; scope {
-			case widgetapi.MouseScopeNone:
-				// Widget doesn't want any mouse events.
+		switch scope := wOpt.WantMouse; scope {
+		case widgetapi.MouseScopeNone:
+			// Widget doesn't want any mouse events.
 
-			default:
-				eds.Subscribe([]terminalapi.Event{&terminalapi.Mouse{}}, func(ev terminalapi.Event) {
-					if err := c.mouseToWidget(ev.(*terminalapi.Mouse), scope); err != nil {
-						eds.Event(terminalapi.NewErrorf("failed to send mouse event %v to widget %T: %v", ev, c.opts.widget, err))
-					}
-				}, event.MaxRepetitive(maxReps))
-			}
+		default:
+			ws.stops = append(ws.stops, eds.Subscribe([]terminalapi.Event{&terminalapi.Mouse{}}, func(ev terminalapi.Event) {
+				if err := c.mouseToWidget(ev.(*terminalapi.Mouse), scope); err != nil {
+					eds.Event(terminalapi.NewErrorf("failed to send mouse event %v to widget %T: %v", ev, c.opts.widget, err))
+				}
+			}, event.MaxRepetitive(maxReps)))
 		}
+		subs[c] = ws
 		return nil
 	}))
+
+	for cont, ws := range root.widgetSubs {
+		if subs[cont] != ws {
+			ws.stop()
+		}
+	}
+	root.widgetSubs = subs
 }
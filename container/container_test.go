@@ -21,8 +21,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/draw/testdraw"
@@ -265,6 +267,242 @@ func TestNew(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "vertical split with a divider",
+			termSize: image.Point{11, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+						SplitDivider(linestyle.Light),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 4))
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 3}},
+				})
+				testdraw.MustBorder(cvs, image.Rect(6, 0, 11, 4))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "horizontal split with a divider, percentage and cell options",
+			termSize: image.Point{10, 13},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							Border(linestyle.Light),
+						),
+						Bottom(
+							Border(linestyle.Light),
+						),
+						SplitDivider(linestyle.Double, cell.FgColor(cell.ColorRed)),
+						SplitPercent(25),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 3))
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{0, 3}, End: image.Point{9, 3}},
+				},
+					draw.HVLineStyle(linestyle.Double),
+					draw.HVLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(cvs, image.Rect(0, 4, 10, 13))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "divider uses the border color",
+			termSize: image.Point{3, 2},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					BorderColor(cell.ColorBlue),
+					SplitVertical(
+						Left(),
+						Right(),
+						SplitDivider(linestyle.Light),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{1, 0}, End: image.Point{1, 1}},
+				}, draw.HVLineCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "divider isn't drawn when one of the sub containers is hidden",
+			termSize: image.Point{10, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+							Hidden(true),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+						SplitDivider(linestyle.Light),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 4))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "margin and padding in cells",
+			termSize: image.Point{10, 8},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					Margin(1),
+					Padding(1),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 9, 7),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(3, 3, 7, 5)), widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "percentage margin and padding are rounded down",
+			termSize: image.Point{15, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					MarginPercent(10),
+					PaddingPercent(20),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 14, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(4, 3, 11, 7)), widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "fixed and percentage margins add up",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Margin(1),
+					MarginPercent(20),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(5, 3, 15, 7)), widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "margin shrinks to fit the minimum size of the widget",
+			termSize: image.Point{12, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Margin(3),
+					PlaceWidget(fakewidget.New(widgetapi.Options{
+						MinimumSize: image.Point{8, 2},
+					})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(2, 3, 10, 7)),
+					widgetapi.Options{MinimumSize: image.Point{8, 2}},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "fails on negative padding",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Padding(-1))
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "fails on unsupported focus mouse mode",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, FocusFromMouse(FocusMouseMode(-1)))
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "fails on margin percentage too large",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, MarginPercent(50))
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
 		{
 			desc:     "fails on vertical split too small",
 			termSize: image.Point{20, 10},
@@ -448,6 +686,50 @@ func TestNew(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "applies border cell options",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light, cell.BgColor(cell.ColorGreen)),
+					BorderColor(cell.ColorRed),
+					FocusedColor(cell.ColorBlue),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light, cell.FgColor(cell.ColorCyan)),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(
+						cell.FgColor(cell.ColorBlue),
+						cell.BgColor(cell.ColorGreen),
+					),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorCyan)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "splitting a container removes the widget",
 			termSize: image.Point{10, 10},
@@ -506,50 +788,325 @@ func TestNew(t *testing.T) {
 				return ft
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			got, err := faketerm.New(tc.termSize)
-			if err != nil {
-				t.Fatalf("faketerm.New => unexpected error: %v", err)
-			}
-
-			cont, err := tc.container(got)
-			if (err != nil) != tc.wantContainerErr {
-				t.Errorf("tc.container => unexpected error:%v, wantErr:%v", err, tc.wantContainerErr)
-			}
-			if err != nil {
-				return
-			}
-			if err := cont.Draw(); err != nil {
-				t.Fatalf("Draw => unexpected error: %v", err)
-			}
-
-			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
-				t.Errorf("Draw => %v", diff)
-			}
-		})
-	}
-
-}
-
-// eventGroup is a group of events to be delivered with synchronization.
-// I.e. the test execution waits until the specified number is processed before
-// proceeding with test execution.
-type eventGroup struct {
-	events        []terminalapi.Event
-	wantProcessed int
-}
-
-// errorHandler just stores the last error received.
-type errorHandler struct {
-	err error
-	mu  sync.Mutex
-}
-
-func (eh *errorHandler) get() error {
-	eh.mu.Lock()
+		{
+			desc:     "fails on a nil theme",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					WithTheme(nil),
+				)
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "inherits border and focused color from the theme",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					WithTheme(&cell.Theme{
+						Border: cell.Options{FgColor: cell.ColorRed},
+						Accent: cell.Options{FgColor: cell.ColorBlue},
+					}),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+							BorderColor(cell.ColorGreen),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "fails on TooSmallText with control characters",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					TooSmallText("too\nsmall"),
+				)
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on SplitVerticalN with less than two children",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVerticalN(
+						Child(Border(linestyle.Light)),
+					),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "fails on SplitHorizontalN with no children",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontalN(),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "vertical split into three on width that doesn't divide evenly",
+			termSize: image.Point{10, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVerticalN(
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 3, 4))
+				testdraw.MustBorder(cvs, image.Rect(3, 0, 6, 4))
+				testdraw.MustBorder(cvs, image.Rect(6, 0, 10, 4))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "vertical split into three on width that divides evenly",
+			termSize: image.Point{12, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVerticalN(
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 4))
+				testdraw.MustBorder(cvs, image.Rect(4, 0, 8, 4))
+				testdraw.MustBorder(cvs, image.Rect(8, 0, 12, 4))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "vertical split into five on width that doesn't divide evenly",
+			termSize: image.Point{23, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVerticalN(
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 4))
+				testdraw.MustBorder(cvs, image.Rect(4, 0, 8, 4))
+				testdraw.MustBorder(cvs, image.Rect(8, 0, 13, 4))
+				testdraw.MustBorder(cvs, image.Rect(13, 0, 18, 4))
+				testdraw.MustBorder(cvs, image.Rect(18, 0, 23, 4))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "horizontal split into three on height that doesn't divide evenly",
+			termSize: image.Point{4, 11},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontalN(
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 3))
+				testdraw.MustBorder(cvs, image.Rect(0, 3, 4, 7))
+				testdraw.MustBorder(cvs, image.Rect(0, 7, 4, 11))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "horizontal split into five on height that doesn't divide evenly",
+			termSize: image.Point{4, 23},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontalN(
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+						Child(Border(linestyle.Light)),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 4))
+				testdraw.MustBorder(cvs, image.Rect(0, 4, 4, 8))
+				testdraw.MustBorder(cvs, image.Rect(0, 8, 4, 13))
+				testdraw.MustBorder(cvs, image.Rect(0, 13, 4, 18))
+				testdraw.MustBorder(cvs, image.Rect(0, 18, 4, 23))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "fails on empty ID",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ID(""),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "fails on duplicate ID",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(ID("pane")),
+						Right(ID("pane")),
+					),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "hidden container gives its space to the sibling",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+							Hidden(true),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			cont, err := tc.container(got)
+			if (err != nil) != tc.wantContainerErr {
+				t.Errorf("tc.container => unexpected error:%v, wantErr:%v", err, tc.wantContainerErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+
+}
+
+// eventGroup is a group of events to be delivered with synchronization.
+// I.e. the test execution waits until the specified number is processed before
+// proceeding with test execution.
+type eventGroup struct {
+	events        []terminalapi.Event
+	wantProcessed int
+}
+
+// errorHandler just stores the last error received.
+type errorHandler struct {
+	err error
+	mu  sync.Mutex
+}
+
+func (eh *errorHandler) get() error {
+	eh.mu.Lock()
 	defer eh.mu.Unlock()
 	return eh.err
 }
@@ -752,18 +1309,24 @@ func TestKeyboard(t *testing.T) {
 			},
 		},
 		{
-			desc:     "widget returns an error when processing the event",
+			desc:     "event not forwarded if rejected by the widget's key filter",
 			termSize: image.Point{40, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+					PlaceWidget(fakewidget.New(widgetapi.Options{
+						WantKeyboard: widgetapi.KeyScopeGlobal,
+						KeyFilter: func(k keyboard.Key) bool {
+							return k == keyboard.KeyEnter
+						},
+					})),
 				)
 			},
 			eventGroups: []*eventGroup{
 				{
 					events: []terminalapi.Event{
-						&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+						&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						&terminalapi.Keyboard{Key: 'a'},
 					},
 					wantProcessed: 2,
 				},
@@ -771,20 +1334,50 @@ func TestKeyboard(t *testing.T) {
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
+				// Only the accepted key reaches the widget.
 				fakewidget.MustDraw(
 					ft,
 					testcanvas.MustNew(ft.Area()),
-					widgetapi.Options{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
 				)
 				return ft
 			},
-			wantErr: true,
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			got, err := faketerm.New(tc.termSize)
+		{
+			desc:     "widget returns an error when processing the event",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+				)
+			},
+			eventGroups: []*eventGroup{
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+					},
+					wantProcessed: 2,
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					widgetapi.Options{},
+				)
+				return ft
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
 			if err != nil {
 				t.Fatalf("faketerm.New => unexpected error: %v", err)
 			}
@@ -1406,3 +1999,452 @@ func TestMouse(t *testing.T) {
 		})
 	}
 }
+
+// mouseRecorder is a widget that records the received mouse events.
+type mouseRecorder struct {
+	events []*terminalapi.Mouse
+	mu     sync.Mutex
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (mr *mouseRecorder) Draw(*canvas.Canvas) error { return nil }
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (mr *mouseRecorder) Keyboard(*terminalapi.Keyboard) error { return nil }
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (mr *mouseRecorder) Mouse(m *terminalapi.Mouse) error {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	mr.events = append(mr.events, m)
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (mr *mouseRecorder) Options() widgetapi.Options {
+	return widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal}
+}
+
+func TestMouseTerminalPosition(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{10, 10})
+	mr := &mouseRecorder{}
+	cont, err := New(
+		ft,
+		Border(linestyle.Light),
+		PlaceWidget(mr),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	for _, m := range []*terminalapi.Mouse{
+		{Position: image.Point{3, 2}, Button: mouse.ButtonLeft},
+		{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+		{Position: image.Point{4, 3}, Button: mouse.ButtonRelease, Motion: true},
+	} {
+		if err := cont.mouseToWidget(m, widgetapi.MouseScopeGlobal); err != nil {
+			t.Fatalf("mouseToWidget => unexpected error: %v", err)
+		}
+	}
+
+	want := []*terminalapi.Mouse{
+		{Position: image.Point{2, 1}, Button: mouse.ButtonLeft, TerminalPosition: image.Point{3, 2}},
+		{Position: image.Point{-1, -1}, Button: mouse.ButtonRelease, TerminalPosition: image.Point{0, 0}},
+		{Position: image.Point{3, 2}, Button: mouse.ButtonRelease, Motion: true, TerminalPosition: image.Point{4, 3}},
+	}
+	if diff := pretty.Compare(want, mr.events); diff != "" {
+		t.Errorf("Mouse => unexpected events, diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		desc          string
+		termSize      image.Point
+		container     func(ft *faketerm.Terminal) (*Container, error)
+		events        []terminalapi.Event
+		wantProcessed int
+		update        func(*Container) error
+		wantUpdateErr bool
+		// afterEvents are sent after the update.
+		afterEvents        []terminalapi.Event
+		wantAfterProcessed int
+		want               func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "fails on unknown ID",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ID("root"),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("unknown", Border(linestyle.Light))
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:     "fails on invalid option",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ID("root"),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("root", SplitVerticalN())
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:     "updates the options of the container",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(ID("right")),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("right", Border(linestyle.Light))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "hides a container",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							Border(linestyle.Light),
+						),
+						Bottom(
+							ID("bottom"),
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("bottom", Hidden(true))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "shows a hidden container again",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							Border(linestyle.Light),
+							Hidden(true),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("left", Hidden(false))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "hides sub containers of the hidden container",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							SplitHorizontal(
+								Top(Border(linestyle.Light)),
+								Bottom(Border(linestyle.Light)),
+							),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("left", Hidden(true))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "hidden container doesn't receive events",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							Hidden(true),
+							PlaceWidget(fakewidget.New(widgetapi.Options{
+								WantKeyboard: widgetapi.KeyScopeGlobal,
+								WantMouse:    widgetapi.MouseScopeGlobal,
+							})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			},
+			wantProcessed: 4,
+			update: func(c *Container) error {
+				return c.Update("left", Hidden(false))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					widgetapi.Options{
+						WantKeyboard: widgetapi.KeyScopeGlobal,
+						WantMouse:    widgetapi.MouseScopeGlobal,
+					},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "widget placed by update receives events",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(ID("right")),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("right", PlaceWidget(fakewidget.New(widgetapi.Options{
+					WantKeyboard: widgetapi.KeyScopeGlobal,
+					WantMouse:    widgetapi.MouseScopeWidget,
+				})))
+			},
+			afterEvents: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{25, 5}, Button: mouse.ButtonLeft},
+			},
+			wantAfterProcessed: 3,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					widgetapi.Options{
+						WantKeyboard: widgetapi.KeyScopeGlobal,
+						WantMouse:    widgetapi.MouseScopeWidget,
+					},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+					&terminalapi.Mouse{Position: image.Point{5, 5}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "widget replaced by update no longer receives events",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(
+							ID("right"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("right", PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})))
+			},
+			afterEvents: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			wantAfterProcessed: 1,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), tc.wantProcessed; got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			err = tc.update(c)
+			if (err != nil) != tc.wantUpdateErr {
+				t.Errorf("Update => unexpected error: %v, wantErr: %v", err, tc.wantUpdateErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, ev := range tc.afterEvents {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), tc.wantProcessed+tc.wantAfterProcessed; got != want {
+					return fmt.Errorf("the event distribution system processed %d events after the update, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+// notifyingWidget is a fake widget that implements widgetapi.Notifier.
+type notifyingWidget struct {
+	*fakewidget.Mirror
+	notify func()
+}
+
+// SetNotify implements widgetapi.Notifier.SetNotify.
+func (nw *notifyingWidget) SetNotify(fn func()) {
+	nw.notify = fn
+}
+
+func TestNotify(t *testing.T) {
+	ft, err := faketerm.New(image.Point{10, 10})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	placed := &notifyingWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	cont, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				PlaceWidget(placed),
+			),
+			Right(
+				ID("right"),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if placed.notify != nil {
+		t.Errorf("SetNotify called before Notify")
+	}
+
+	var got int
+	cont.Notify(func() { got++ })
+	if placed.notify == nil {
+		t.Fatalf("Notify didn't call SetNotify on the widget placed by New")
+	}
+	placed.notify()
+
+	updated := &notifyingWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	if err := cont.Update("right", PlaceWidget(updated)); err != nil {
+		t.Fatalf("Update => unexpected error: %v", err)
+	}
+	if updated.notify == nil {
+		t.Fatalf("Update didn't call SetNotify on the newly placed widget")
+	}
+	updated.notify()
+
+	if want := 2; got != want {
+		t.Errorf("the notify function was called %d times, want %d", got, want)
+	}
+}
@@ -47,6 +47,9 @@ func drawTree(c *Container) error {
 		if c.second != nil {
 			c.second.area = second
 		}
+		if c.isHidden() {
+			return nil
+		}
 		return drawCont(c)
 	}))
 	if errStr != "" {
@@ -61,7 +64,7 @@ func drawBorder(c *Container) error {
 		return nil
 	}
 
-	cvs, err := canvas.New(c.area)
+	cvs, err := canvas.New(c.outer())
 	if err != nil {
 		return err
 	}
@@ -73,9 +76,11 @@ func drawBorder(c *Container) error {
 
 	var cOpts []cell.Option
 	if c.focusTracker.isActive(c) {
+		cOpts = append(cOpts, c.opts.borderCellOpts...)
 		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.focusedColor))
 	} else {
 		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.borderColor))
+		cOpts = append(cOpts, c.opts.borderCellOpts...)
 	}
 
 	if err := draw.Border(cvs, ar,
@@ -89,6 +94,35 @@ func drawBorder(c *Container) error {
 	return cvs.Apply(c.term)
 }
 
+// drawDivider draws the line between the sub containers if requested.
+func drawDivider(c *Container) error {
+	if c.first == nil || c.second == nil || !c.hasDivider() {
+		return nil
+	}
+
+	_, ar, _, err := c.splitDivider()
+	if err != nil {
+		return err
+	}
+	if ar.Dx() < 2 && ar.Dy() < 2 {
+		return nil // A line must be at least two cells long.
+	}
+
+	cvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+	end := image.Point{ar.Dx() - 1, ar.Dy() - 1}
+	cOpts := append([]cell.Option{cell.FgColor(c.opts.inherited.borderColor)}, c.opts.dividerCellOpts...)
+	if err := draw.HVLines(cvs, []draw.HVLine{{Start: image.ZP, End: end}},
+		draw.HVLineStyle(c.opts.divider),
+		draw.HVLineCellOpts(cOpts...),
+	); err != nil {
+		return err
+	}
+	return cvs.Apply(c.term)
+}
+
 // drawWidget requests the widget to draw on the canvas.
 func drawWidget(c *Container) error {
 	widgetArea, err := c.widgetArea()
@@ -124,7 +158,9 @@ func drawWidget(c *Container) error {
 	return cvs.Apply(c.term)
 }
 
-// drawResize draws an unicode character indicating that the size is too small to draw this container.
+// drawResize draws an unicode character or the text provided via the
+// TooSmallText option indicating that the size is too small to draw this
+// container.
 // Does nothing if the size is smaller than one cell, leaving no space for the character.
 func drawResize(c *Container, area image.Rectangle) error {
 	if area.Dx() < 1 || area.Dy() < 1 {
@@ -135,7 +171,15 @@ func drawResize(c *Container, area image.Rectangle) error {
 	if err != nil {
 		return err
 	}
-	if err := draw.ResizeNeeded(cvs); err != nil {
+
+	if text := c.opts.inherited.tooSmallText; text != "" {
+		if _, err := draw.Text(cvs, text, image.Point{0, 0},
+			draw.TextOverrunMode(draw.OverrunModeTrim),
+			draw.TextCellOpts(c.opts.inherited.tooSmallCellOpts...),
+		); err != nil {
+			return err
+		}
+	} else if err := draw.ResizeNeeded(cvs); err != nil {
 		return err
 	}
 	return cvs.Apply(c.term)
@@ -151,6 +195,14 @@ func drawCont(c *Container) error {
 		return fmt.Errorf("unable to draw container border: %v", err)
 	}
 
+	if err := drawTabBar(c); err != nil {
+		return fmt.Errorf("unable to draw the tab bar: %v", err)
+	}
+
+	if err := drawDivider(c); err != nil {
+		return fmt.Errorf("unable to draw the split divider: %v", err)
+	}
+
 	if err := drawWidget(c); err != nil {
 		return fmt.Errorf("unable to draw widget %T: %v", c.opts.widget, err)
 	}
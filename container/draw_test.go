@@ -18,6 +18,7 @@ import (
 	"image"
 	"testing"
 
+	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
@@ -620,6 +621,49 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "draws the configured text when the widget doesn't fit",
+			termSize: image.Point{6, 2},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					TooSmallText("too small", cell.FgColor(cell.ColorRed)),
+					PlaceWidget(fakewidget.New(widgetapi.Options{
+						MinimumSize: image.Point{10, 10}},
+					)),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "too sm", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "sub containers inherit the text drawn when they don't fit",
+			termSize: image.Point{4, 1},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					TooSmallText("small"),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "sm", image.Point{0, 0})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -644,6 +688,147 @@ func TestDrawWidget(t *testing.T) {
 	}
 }
 
+func TestSplitSatisfiesMinimumSize(t *testing.T) {
+	minWidget := func(size image.Point) Option {
+		return PlaceWidget(fakewidget.New(widgetapi.Options{
+			MinimumSize: size,
+		}))
+	}
+
+	tests := []struct {
+		desc       string
+		termSize   image.Point
+		opts       []Option
+		wantFirst  image.Rectangle
+		wantSecond image.Rectangle
+	}{
+		{
+			desc:     "split unchanged when minimum sizes fit",
+			termSize: image.Point{20, 10},
+			opts: []Option{
+				SplitVertical(
+					Left(minWidget(image.Point{5, 1})),
+					Right(minWidget(image.Point{5, 1})),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 10, 10),
+			wantSecond: image.Rect(10, 0, 20, 10),
+		},
+		{
+			desc:     "left container grows to its minimum width",
+			termSize: image.Point{24, 10},
+			opts: []Option{
+				SplitVertical(
+					Left(minWidget(image.Point{15, 1})),
+					Right(minWidget(image.Point{9, 1})),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 15, 10),
+			wantSecond: image.Rect(15, 0, 24, 10),
+		},
+		{
+			desc:     "right container grows to its minimum width",
+			termSize: image.Point{20, 10},
+			opts: []Option{
+				SplitVertical(
+					Left(),
+					Right(minWidget(image.Point{14, 1})),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 6, 10),
+			wantSecond: image.Rect(6, 0, 20, 10),
+		},
+		{
+			desc:     "bottom container grows to its minimum height",
+			termSize: image.Point{20, 10},
+			opts: []Option{
+				SplitHorizontal(
+					Top(),
+					Bottom(minWidget(image.Point{1, 4})),
+					SplitPercent(90),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 20, 6),
+			wantSecond: image.Rect(0, 6, 20, 10),
+		},
+		{
+			desc:     "minimum size accounts for borders and nested splits",
+			termSize: image.Point{30, 10},
+			opts: []Option{
+				SplitVertical(
+					Left(),
+					Right(
+						Border(linestyle.Light),
+						SplitVertical(
+							Left(minWidget(image.Point{7, 1})),
+							Right(minWidget(image.Point{7, 1})),
+						),
+					),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 14, 10),
+			wantSecond: image.Rect(14, 0, 30, 10),
+		},
+		{
+			desc:     "first container is satisfied first when minimums exceed the space",
+			termSize: image.Point{20, 10},
+			opts: []Option{
+				SplitVertical(
+					Left(minWidget(image.Point{15, 1})),
+					Right(minWidget(image.Point{15, 1})),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 15, 10),
+			wantSecond: image.Rect(15, 0, 20, 10),
+		},
+		{
+			desc:     "first container gets all the space when its minimum exceeds it",
+			termSize: image.Point{20, 10},
+			opts: []Option{
+				SplitVertical(
+					Left(minWidget(image.Point{30, 1})),
+					Right(),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 20, 10),
+			wantSecond: image.ZR,
+		},
+		{
+			desc:     "equal split reallocates space for a minimum size",
+			termSize: image.Point{21, 10},
+			opts: []Option{
+				SplitVerticalN(
+					Child(minWidget(image.Point{10, 1})),
+					Child(),
+					Child(),
+				),
+			},
+			wantFirst:  image.Rect(0, 0, 10, 10),
+			wantSecond: image.Rect(10, 0, 21, 10),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(tc.termSize)
+			c, err := New(ft, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if diff := pretty.Compare(tc.wantFirst, c.first.area); diff != "" {
+				t.Errorf("first container area => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantSecond, c.second.area); diff != "" {
+				t.Errorf("second container area => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDrawHandlesTerminalResize(t *testing.T) {
 	termSize := image.Point{60, 10}
 	got, err := faketerm.New(termSize)
@@ -33,7 +33,7 @@ func pointCont(c *Container, p image.Point) *Container {
 		cont   *Container
 	)
 	postOrder(rootCont(c), &errStr, visitFunc(func(c *Container) error {
-		if p.In(c.area) && cont == nil {
+		if p.In(c.area) && cont == nil && !c.isHidden() {
 			cont = c
 		}
 		return nil
@@ -55,6 +55,9 @@ type focusTracker struct {
 	// buttonFSM is a state machine tracking mouse clicks in containers and
 	// moving focus from one container to the next.
 	buttonFSM *button.FSM
+
+	// mode determines how the mouse moves the focus.
+	mode FocusMouseMode
 }
 
 // newFocusTracker returns a new focus tracker with focus set at the provided
@@ -82,10 +85,22 @@ func (ft *focusTracker) active() *Container {
 // the focused container in the tree.
 // The argument c is the container onto which the mouse event landed.
 func (ft *focusTracker) mouse(target *Container, m *terminalapi.Mouse) {
+	// Movement of the mouse without any held buttons.
+	if m.Motion && m.Button == mouse.ButtonRelease {
+		if ft.mode == FocusOnHover {
+			ft.container = target
+		}
+		return
+	}
+
 	clicked, bs := ft.buttonFSM.Event(m)
 	switch {
 	case bs == button.Down:
-		ft.candidate = target
+		// In the hover mode, a drag started in another container doesn't
+		// focus the container where it ends.
+		if ft.mode != FocusOnHover || !m.Motion {
+			ft.candidate = target
+		}
 	case bs == button.Up && clicked:
 		if target == ft.candidate {
 			ft.container = target
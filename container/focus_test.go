@@ -293,6 +293,7 @@ func TestFocusTrackerMouse(t *testing.T) {
 
 	tests := []struct {
 		desc string
+		opts []Option
 		// Can be either the mouse event or a time.Duration to pause for.
 		events        []*terminalapi.Mouse
 		wantFocused   contLoc
@@ -393,17 +394,110 @@ func TestFocusTrackerMouse(t *testing.T) {
 			wantFocused:   contLocRoot,
 			wantProcessed: 3,
 		},
+		{
+			desc: "click mode ignores mouse motion",
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonRelease, Motion: true},
+			},
+			wantFocused:   contLocRoot,
+			wantProcessed: 1,
+		},
+		{
+			desc: "hover moves focus without a click",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonRelease, Motion: true},
+			},
+			wantFocused:   contLocLeft,
+			wantProcessed: 1,
+		},
+		{
+			desc: "hover focus follows the mouse across containers",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonRelease, Motion: true},
+				{Position: insideRight, Button: mouse.ButtonRelease, Motion: true},
+			},
+			wantFocused:   contLocRight,
+			wantProcessed: 2,
+		},
+		{
+			desc: "hover focus ignores mouse wheel events",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonRelease, Motion: true},
+				{Position: insideRight, Button: mouse.ButtonWheelUp},
+				{Position: insideRight, Button: mouse.ButtonWheelDown},
+			},
+			wantFocused:   contLocLeft,
+			wantProcessed: 3,
+		},
+		{
+			desc: "hover focus ignores a release without motion",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonRelease, Motion: true},
+				{Position: insideRight, Button: mouse.ButtonRelease},
+			},
+			wantFocused:   contLocLeft,
+			wantProcessed: 2,
+		},
+		{
+			desc: "hover focus moves on a click",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideRight, Button: mouse.ButtonLeft},
+				{Position: insideRight, Button: mouse.ButtonRelease},
+			},
+			wantFocused:   contLocRight,
+			wantProcessed: 2,
+		},
+		{
+			desc: "hover focus doesn't move while dragging",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonRelease, Motion: true},
+				{Position: insideLeft, Button: mouse.ButtonLeft},
+				{Position: insideRight, Button: mouse.ButtonLeft, Motion: true},
+			},
+			wantFocused:   contLocLeft,
+			wantProcessed: 3,
+		},
+		{
+			desc: "hover focus doesn't move on release after a drag from another container",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonRelease, Motion: true},
+				{Position: insideLeft, Button: mouse.ButtonLeft},
+				{Position: insideRight, Button: mouse.ButtonLeft, Motion: true},
+				{Position: insideRight, Button: mouse.ButtonRelease},
+			},
+			wantFocused:   contLocLeft,
+			wantProcessed: 4,
+		},
+		{
+			desc: "hover focus moves on motion after a drag",
+			opts: []Option{FocusFromMouse(FocusOnHover)},
+			events: []*terminalapi.Mouse{
+				{Position: insideLeft, Button: mouse.ButtonLeft},
+				{Position: insideRight, Button: mouse.ButtonLeft, Motion: true},
+				{Position: insideRight, Button: mouse.ButtonRelease},
+				{Position: insideRight, Button: mouse.ButtonRelease, Motion: true},
+			},
+			wantFocused:   contLocRight,
+			wantProcessed: 4,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			root, err := New(
-				ft,
+			opts := append([]Option{
 				SplitVertical(
 					Left(),
 					Right(),
 				),
-			)
+			}, tc.opts...)
+			root, err := New(ft, opts...)
 			if err != nil {
 				t.Fatalf("New => unexpected error: %v", err)
 			}
@@ -17,7 +17,9 @@ package container
 // options.go defines container options.
 
 import (
+	"errors"
 	"fmt"
+	"unicode"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
@@ -46,9 +48,31 @@ type options struct {
 	// inherited are options that are inherited by child containers.
 	inherited inherited
 
+	// id is the identifier provided by the user.
+	id string
+
+	// hidden indicates that this container and its sub containers are
+	// excluded from layout, drawing and event delivery.
+	hidden bool
+
+	// tabs are the tabs of this container, nil if it doesn't have tabs.
+	tabs *tabState
+	// tabKeys are the keys that switch tabs, nil if not configured.
+	tabKeys *tabKeys
+	// tabActiveCellOpts are the cell options for the title of the active tab.
+	tabActiveCellOpts []cell.Option
+
 	// split identifies how is this container split.
 	split        splitType
 	splitPercent int
+	// splitParts when positive indicates that the first sub container gets
+	// an equal share of this container's size divided into the specified
+	// number of parts. Takes precedence over splitPercent.
+	splitParts int
+	// divider is the style of the line drawn between the sub containers,
+	// linestyle.None if there is no divider.
+	divider         linestyle.LineStyle
+	dividerCellOpts []cell.Option
 
 	// widget is the widget in the container.
 	// A container can have either two sub containers (left and right) or a
@@ -61,8 +85,19 @@ type options struct {
 
 	// border is the border around the container.
 	border            linestyle.LineStyle
+	borderCellOpts    []cell.Option
 	borderTitle       string
 	borderTitleHAlign align.Horizontal
+
+	// margin is the space in cells around the border, marginPercent is
+	// additional space as percentage of the size of the container.
+	margin        int
+	marginPercent int
+	// padding is the space in cells between the border and the content,
+	// paddingPercent is additional space as percentage of the size inside
+	// the border.
+	padding        int
+	paddingPercent int
 }
 
 // inherited contains options that are inherited by child containers.
@@ -71,6 +106,12 @@ type inherited struct {
 	borderColor cell.Color
 	// focusedColor is the color used for the border when focused.
 	focusedColor cell.Color
+
+	// tooSmallText is drawn instead of the container or its widget when
+	// there isn't enough space to draw them.
+	// Empty text indicates the default rune.
+	tooSmallText     string
+	tooSmallCellOpts []cell.Option
 }
 
 // newOptions returns a new options instance with the default values.
@@ -118,9 +159,9 @@ const DefaultSplitPercent = 50
 
 // SplitPercent sets the relative size of the split as percentage of the available space.
 // When using SplitVertical, the provided size is applied to the new left
-// container, the new right container gets the reminder of the size.
+// container, the new right container gets the remainder of the size.
 // When using SplitHorizontal, the provided size is applied to the new top
-// container, the new bottom container gets the reminder of the size.
+// container, the new bottom container gets the remainder of the size.
 // The provided value must be a positive number in the range 0 < p < 100.
 // If not provided, defaults to DefaultSplitPercent.
 func SplitPercent(p int) SplitOption {
@@ -133,13 +174,32 @@ func SplitPercent(p int) SplitOption {
 	})
 }
 
+// SplitDivider draws a line of the provided style between the two sub
+// containers, which is more space efficient than borders around each of
+// them. The divider occupies one cell along the split axis, the rest of the
+// space is divided between the sub containers according to the other split
+// options. The divider is only drawn when both sub containers are visible and
+// when it is at least two cells long.
+// The divider uses the color set by BorderColor, the provided cell options are
+// applied after it.
+func SplitDivider(ls linestyle.LineStyle, cOpts ...cell.Option) SplitOption {
+	return splitOption(func(opts *options) error {
+		opts.divider = ls
+		opts.dividerCellOpts = cOpts
+		return nil
+	})
+}
+
 // SplitVertical splits the container along the vertical axis into two sub
 // containers. The use of this option removes any widget placed at this
 // container, containers with sub containers cannot contain widgets.
 func SplitVertical(l LeftOption, r RightOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeVertical
+		c.opts.splitParts = 0
+		c.opts.divider = linestyle.None
 		c.opts.widget = nil
+		c.opts.tabs = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -162,13 +222,74 @@ func SplitVertical(l LeftOption, r RightOption, opts ...SplitOption) Option {
 	})
 }
 
+// SplitVerticalN splits the container along the vertical axis into the
+// provided number of equally sized sub containers, ordered from left to right.
+// When the width doesn't divide evenly, the remainder is distributed so that no
+// sub container is more than one cell wider than another.
+// At least two children must be provided. The use of this option removes any
+// widget placed at this container, containers with sub containers cannot
+// contain widgets.
+func SplitVerticalN(children ...ChildOption) Option {
+	return option(func(c *Container) error {
+		return splitN(c, splitTypeVertical, children)
+	})
+}
+
+// SplitHorizontalN splits the container along the horizontal axis into the
+// provided number of equally sized sub containers, ordered from top to bottom.
+// When the height doesn't divide evenly, the remainder is distributed so that
+// no sub container is more than one cell taller than another.
+// At least two children must be provided. The use of this option removes any
+// widget placed at this container, containers with sub containers cannot
+// contain widgets.
+func SplitHorizontalN(children ...ChildOption) Option {
+	return option(func(c *Container) error {
+		return splitN(c, splitTypeHorizontal, children)
+	})
+}
+
+// splitN splits the container into len(children) equal parts.
+// The first child is placed into the first sub container and the remaining
+// children are recursively split into the second sub container.
+func splitN(c *Container, st splitType, children []ChildOption) error {
+	if min := 2; len(children) < min {
+		return fmt.Errorf("invalid number of children %d, must be at least %d", len(children), min)
+	}
+	c.opts.split = st
+	c.opts.splitParts = len(children)
+	c.opts.divider = linestyle.None
+	c.opts.widget = nil
+	c.opts.tabs = nil
+
+	f, err := c.createFirst()
+	if err != nil {
+		return err
+	}
+	if err := applyOptions(f, children[0].cOpts()...); err != nil {
+		return err
+	}
+
+	s, err := c.createSecond()
+	if err != nil {
+		return err
+	}
+	rest := children[1:]
+	if len(rest) == 1 {
+		return applyOptions(s, rest[0].cOpts()...)
+	}
+	return splitN(s, st, rest)
+}
+
 // SplitHorizontal splits the container along the horizontal axis into two sub
 // containers. The use of this option removes any widget placed at this
 // container, containers with sub containers cannot contain widgets.
 func SplitHorizontal(t TopOption, b BottomOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeHorizontal
+		c.opts.splitParts = 0
+		c.opts.divider = linestyle.None
 		c.opts.widget = nil
+		c.opts.tabs = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -191,6 +312,36 @@ func SplitHorizontal(t TopOption, b BottomOption, opts ...SplitOption) Option {
 	})
 }
 
+// ID sets an identifier for this container.
+// This ID can be later used to perform dynamic layout changes by passing new
+// options to this container via Container.Update.
+// The ID must be a non-empty string that is unique in the container tree.
+func ID(id string) Option {
+	return option(func(c *Container) error {
+		if id == "" {
+			return errors.New("the ID cannot be an empty string")
+		}
+		if found, err := findID(rootCont(c), id); err == nil && found != c {
+			return fmt.Errorf("duplicate container ID %q", id)
+		}
+		c.opts.id = id
+		return nil
+	})
+}
+
+// Hidden sets whether the container is hidden.
+// A hidden container and all its sub containers aren't drawn and don't
+// receive any events. The space of a hidden container is given to its sibling
+// created by the same split of the parent container.
+// This option is usually set via Container.Update in order to collapse and
+// later restore a part of the layout without rebuilding it.
+func Hidden(hidden bool) Option {
+	return option(func(c *Container) error {
+		c.opts.hidden = hidden
+		return nil
+	})
+}
+
 // PlaceWidget places the provided widget into the container.
 // The use of this option removes any sub containers. Containers with sub
 // containers cannot have widgets.
@@ -199,6 +350,8 @@ func PlaceWidget(w widgetapi.Widget) Option {
 		c.opts.widget = w
 		c.first = nil
 		c.second = nil
+		c.opts.tabs = nil
+		setNotify(w, rootCont(c).notify)
 		return nil
 	})
 }
@@ -224,9 +377,79 @@ func AlignVertical(v align.Vertical) Option {
 }
 
 // Border configures the container to have a border of the specified style.
-func Border(ls linestyle.LineStyle) Option {
+// The provided cell options are applied to the runes of the border and its
+// title, e.g. to make the border bold or to change its color. These override
+// the color set by BorderColor, but not the FocusedColor of a focused
+// container.
+func Border(ls linestyle.LineStyle, opts ...cell.Option) Option {
 	return option(func(c *Container) error {
 		c.opts.border = ls
+		c.opts.borderCellOpts = opts
+		return nil
+	})
+}
+
+// Margin sets the number of empty cells on each side of the container,
+// outside of its border. Combines with MarginPercent, the margins add up.
+// The margin shrinks when the container is too small to fit the minimum size
+// of its content. Must be zero or a positive number, defaults to zero.
+func Margin(cells int) Option {
+	return option(func(c *Container) error {
+		if cells < 0 {
+			return fmt.Errorf("invalid Margin(%d), must be zero or a positive number", cells)
+		}
+		c.opts.margin = cells
+		return nil
+	})
+}
+
+// MarginPercent sets the margin on each side of the container as percentage
+// of its size, i.e. of its width for the left and right margin and of its
+// height for the top and bottom margin. The number of cells is rounded down
+// and recomputed whenever the container resizes. Adds to the cells set by
+// Margin.
+// The margin shrinks when the container is too small to fit the minimum size
+// of its content. The provided value must be in the range 0 <= p < 50,
+// defaults to zero.
+func MarginPercent(p int) Option {
+	return option(func(c *Container) error {
+		if min, max := 0, 50; p < min || p >= max {
+			return fmt.Errorf("invalid MarginPercent(%d), must be in range %d <= p < %d", p, min, max)
+		}
+		c.opts.marginPercent = p
+		return nil
+	})
+}
+
+// Padding sets the number of empty cells on each side of the container
+// between its border and its content, i.e. the widget or the sub containers.
+// Combines with PaddingPercent, the paddings add up.
+// The padding shrinks when the container is too small to fit the minimum size
+// of its content. Must be zero or a positive number, defaults to zero.
+func Padding(cells int) Option {
+	return option(func(c *Container) error {
+		if cells < 0 {
+			return fmt.Errorf("invalid Padding(%d), must be zero or a positive number", cells)
+		}
+		c.opts.padding = cells
+		return nil
+	})
+}
+
+// PaddingPercent sets the padding on each side of the container as
+// percentage of the size inside its border, i.e. of its width for the left and
+// right padding and of its height for the top and bottom padding. The number
+// of cells is rounded down and recomputed whenever the container resizes.
+// Adds to the cells set by Padding.
+// The padding shrinks when the container is too small to fit the minimum size
+// of its content. The provided value must be in the range 0 <= p < 50,
+// defaults to zero.
+func PaddingPercent(p int) Option {
+	return option(func(c *Container) error {
+		if min, max := 0, 50; p < min || p >= max {
+			return fmt.Errorf("invalid PaddingPercent(%d), must be in range %d <= p < %d", p, min, max)
+		}
+		c.opts.paddingPercent = p
 		return nil
 	})
 }
@@ -282,6 +505,90 @@ func FocusedColor(color cell.Color) Option {
 	})
 }
 
+// TooSmallText sets the text that is drawn instead of the container or its
+// widget when the container is too small to draw them, e.g. when the widget's
+// canvas would be smaller than its widgetapi.Options.MinimumSize. The text is
+// drawn on the first line and trimmed if it doesn't fit. Defaults to a single
+// rune indicating that a resize is needed.
+// The text must not contain control characters like newlines.
+// This option is inherited to sub containers created by container splits.
+func TooSmallText(text string, opts ...cell.Option) Option {
+	return option(func(c *Container) error {
+		for _, r := range text {
+			if unicode.IsControl(r) {
+				return fmt.Errorf("TooSmallText(%q) must not contain control characters, found %q", text, r)
+			}
+		}
+		c.opts.inherited.tooSmallText = text
+		c.opts.inherited.tooSmallCellOpts = opts
+		return nil
+	})
+}
+
+// WithTheme sets the colors of the border around the container from the
+// theme. The border uses the foreground color of the theme's Border role and
+// the foreground color of the Accent role when the container is focused.
+// Options provided after this one override the colors from the theme.
+// This option is inherited to sub containers created by container splits.
+// The theme must not be nil.
+func WithTheme(t *cell.Theme) Option {
+	return option(func(c *Container) error {
+		if t == nil {
+			return fmt.Errorf("WithTheme requires a non-nil theme")
+		}
+		c.opts.inherited.borderColor = t.Border.FgColor
+		c.opts.inherited.focusedColor = t.Accent.FgColor
+		return nil
+	})
+}
+
+// FocusFromMouse sets how the mouse moves the focus between containers, see
+// FocusMouseMode. The mode applies to the entire tree of containers,
+// regardless of which container the option is provided to.
+// Defaults to FocusOnClick.
+func FocusFromMouse(fm FocusMouseMode) Option {
+	return option(func(c *Container) error {
+		if _, ok := focusMouseModeNames[fm]; !ok {
+			return fmt.Errorf("invalid FocusFromMouse(%v)", fm)
+		}
+		c.focusTracker.mode = fm
+		return nil
+	})
+}
+
+// FocusMouseMode determines how the mouse moves the focus between containers.
+type FocusMouseMode int
+
+// String implements fmt.Stringer()
+func (fm FocusMouseMode) String() string {
+	if n, ok := focusMouseModeNames[fm]; ok {
+		return n
+	}
+	return "FocusMouseModeUnknown"
+}
+
+// focusMouseModeNames maps FocusMouseMode values to human readable names.
+var focusMouseModeNames = map[FocusMouseMode]string{
+	FocusOnClick: "FocusOnClick",
+	FocusOnHover: "FocusOnHover",
+}
+
+const (
+	// FocusOnClick focuses a container when the user clicks into it with the
+	// left mouse button, i.e. presses and releases the button inside of it.
+	FocusOnClick FocusMouseMode = iota
+
+	// FocusOnHover focuses the container under the mouse cursor as the cursor
+	// crosses the container boundaries, no click is needed. Only movement of
+	// the mouse without any held buttons moves the focus, i.e. the focus
+	// doesn't change when dragging or on mouse wheel events. Clicks focus
+	// containers the same way as with FocusOnClick.
+	// Requires a terminal that reports the movement of the mouse without held
+	// buttons, i.e. mouse events with the Motion field set, e.g. the termbox
+	// terminal created with its MouseMotion option.
+	FocusOnHover
+)
+
 // splitType identifies how a container is split.
 type splitType int
 
@@ -403,3 +710,29 @@ func Bottom(opts ...Option) BottomOption {
 		return opts
 	})
 }
+
+// ChildOption is used to provide options to one of the sub containers created
+// by SplitVerticalN or SplitHorizontalN.
+type ChildOption interface {
+	// cOpts returns the options.
+	cOpts() []Option
+}
+
+// childOption implements ChildOption.
+type childOption func() []Option
+
+// cOpts implements ChildOption.cOpts.
+func (co childOption) cOpts() []Option {
+	if co == nil {
+		return nil
+	}
+	return co()
+}
+
+// Child applies options to one of the sub containers created by
+// SplitVerticalN or SplitHorizontalN.
+func Child(opts ...Option) ChildOption {
+	return childOption(func() []Option {
+		return opts
+	})
+}
@@ -0,0 +1,341 @@
+// Copyright 2026 The termdash Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// tabs.go contains code that implements the tabbed layout of containers.
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Tab is one of the tabs of a container created by the Tabs option.
+type Tab interface {
+	// tab returns the title and the options of the tab.
+	tab() (string, []Option)
+}
+
+// tab implements Tab.
+type tab struct {
+	title string
+	opts  []Option
+}
+
+// tab implements Tab.tab.
+func (t *tab) tab() (string, []Option) {
+	return t.title, t.opts
+}
+
+// NewTab returns a new tab with the provided title. The options are applied
+// to the sub container that is displayed when this tab is active.
+func NewTab(title string, opts ...Option) Tab {
+	return &tab{
+		title: title,
+		opts:  opts,
+	}
+}
+
+// Tabs turns the container into a tabbed layout. The container draws a tab
+// bar with the titles of the tabs on its top row and below it displays the sub
+// container of the active tab. The sub containers of the other tabs aren't
+// drawn and don't receive any events.
+//
+// The active tab can be changed by clicking on its title in the tab bar, by
+// pressing the left and right arrow keys while the container is focused, by
+// pressing the keys configured with the TabKeys option or by applying the
+// ActiveTab option via Container.Update. Initially the first tab is active.
+//
+// At least one tab must be provided. The use of this option removes any
+// widget or sub containers placed at this container.
+func Tabs(tabs ...Tab) Option {
+	return option(func(c *Container) error {
+		if len(tabs) == 0 {
+			return errors.New("at least one tab must be provided")
+		}
+		c.opts.widget = nil
+		c.opts.splitParts = 0
+
+		// Set before creating the sub containers, so their areas exclude the
+		// tab bar.
+		ts := &tabState{}
+		c.opts.tabs = ts
+		cur := c
+		for _, t := range tabs {
+			title, opts := t.tab()
+			cur.opts.split = splitTypeHorizontal
+			f, err := cur.createFirst()
+			if err != nil {
+				return err
+			}
+			if err := applyOptions(f, opts...); err != nil {
+				return err
+			}
+			s, err := cur.createSecond()
+			if err != nil {
+				return err
+			}
+
+			ts.titles = append(ts.titles, title)
+			ts.panes = append(ts.panes, f)
+			ts.chains = append(ts.chains, cur)
+			cur = s
+		}
+		// The last sub container only terminates the chain and is never shown.
+		cur.opts.hidden = true
+
+		ts.activate(0)
+		return nil
+	})
+}
+
+// ActiveTab sets the active tab of a container configured with the Tabs
+// option. The tabs are indexed from zero in the order they were provided.
+// Must be applied after the Tabs option.
+func ActiveTab(i int) Option {
+	return option(func(c *Container) error {
+		ts := c.opts.tabs
+		if ts == nil {
+			return errors.New("the ActiveTab option requires the container to have tabs")
+		}
+		if min, max := 0, len(ts.titles)-1; i < min || i > max {
+			return fmt.Errorf("invalid active tab %d, must be in range %d <= i <= %d", i, min, max)
+		}
+		ts.activate(i)
+		return nil
+	})
+}
+
+// TabKeys configures keys that switch to the previous and the next tab of a
+// container configured with the Tabs option. Unlike the arrow keys, these keys
+// switch tabs even when the container isn't focused.
+func TabKeys(prev, next keyboard.Key) Option {
+	return option(func(c *Container) error {
+		if prev == next {
+			return fmt.Errorf("the previous and next tab keys must differ, got %v for both", prev)
+		}
+		c.opts.tabKeys = &tabKeys{
+			prev: prev,
+			next: next,
+		}
+		return nil
+	})
+}
+
+// TabActiveCellOpts sets the cell options used to draw the title of the
+// active tab in the tab bar.
+// Defaults to DefaultTabActiveCellOpts.
+func TabActiveCellOpts(opts ...cell.Option) Option {
+	return option(func(c *Container) error {
+		c.opts.tabActiveCellOpts = opts
+		return nil
+	})
+}
+
+// DefaultTabActiveCellOpts are the default cell options used to draw the
+// title of the active tab.
+var DefaultTabActiveCellOpts = []cell.Option{
+	cell.FgColor(cell.ColorBlack),
+	cell.BgColor(cell.ColorWhite),
+}
+
+// tabKeys are the keys that switch tabs.
+type tabKeys struct {
+	prev keyboard.Key
+	next keyboard.Key
+}
+
+// tabState tracks the tabs of a container.
+type tabState struct {
+	// titles are the titles of the tabs.
+	titles []string
+
+	// panes are the sub containers with the content of each tab.
+	panes []*Container
+
+	// chains are the containers whose first sub container is the pane of the
+	// tab at the same index and whose second sub container holds the
+	// remaining tabs. The first chain is the container with the tabs.
+	chains []*Container
+
+	// active is the index of the active tab.
+	active int
+}
+
+// activate makes the tab at the specified index active.
+func (ts *tabState) activate(i int) {
+	ts.active = i
+	for j, p := range ts.panes {
+		p.opts.hidden = j != i
+	}
+	for j, c := range ts.chains {
+		if j == 0 {
+			continue
+		}
+		c.opts.hidden = i < j
+	}
+}
+
+// mouse switches tabs when the mouse event is a click on a tab title in the
+// provided tab bar.
+func (ts *tabState) mouse(m *terminalapi.Mouse, bar image.Rectangle) {
+	if m.Button != mouse.ButtonLeft {
+		return
+	}
+	for i, ar := range ts.titleAreas(bar) {
+		if m.Position.In(ar) {
+			ts.activate(i)
+			return
+		}
+	}
+}
+
+// titleAreas returns the areas of the titles of the tabs when drawn into the
+// provided tab bar. Titles are separated by a single cell. Titles that don't
+// fit are trimmed, titles that don't fit at all have zero areas.
+func (ts *tabState) titleAreas(bar image.Rectangle) []image.Rectangle {
+	areas := make([]image.Rectangle, len(ts.titles))
+	x := bar.Min.X
+	for i, title := range ts.titles {
+		if i > 0 {
+			x++ // The separator.
+		}
+		if x >= bar.Max.X {
+			break
+		}
+		end := x + runewidth.StringWidth(tabText(title))
+		if end > bar.Max.X {
+			end = bar.Max.X
+		}
+		areas[i] = image.Rect(x, bar.Min.Y, end, bar.Max.Y)
+		x = end
+	}
+	return areas
+}
+
+// tabText returns the text drawn for a tab with the provided title.
+func tabText(title string) string {
+	return fmt.Sprintf(" %s ", title)
+}
+
+// keyboard switches tabs when the keyboard event is one of the keys that
+// change tabs. The focused argument indicates if the container with the tabs
+// is focused.
+func (ts *tabState) keyboard(k *terminalapi.Keyboard, focused bool, keys *tabKeys) {
+	var prev, next bool
+	if focused {
+		prev = k.Key == keyboard.KeyArrowLeft
+		next = k.Key == keyboard.KeyArrowRight
+	}
+	if keys != nil {
+		prev = prev || k.Key == keys.prev
+		next = next || k.Key == keys.next
+	}
+
+	switch {
+	case prev && ts.active > 0:
+		ts.activate(ts.active - 1)
+	case next && ts.active < len(ts.titles)-1:
+		ts.activate(ts.active + 1)
+	}
+}
+
+// tabBarArea returns the area of the tab bar of the container.
+func tabBarArea(c *Container) image.Rectangle {
+	us := c.usable()
+	return image.Rect(us.Min.X, us.Min.Y, us.Max.X, us.Min.Y+1)
+}
+
+// drawTabBar draws the tab bar if the container has tabs.
+func drawTabBar(c *Container) error {
+	ts := c.opts.tabs
+	if ts == nil {
+		return nil
+	}
+
+	bar := tabBarArea(c)
+	cvs, err := canvas.New(bar)
+	if err != nil {
+		return err
+	}
+
+	activeOpts := DefaultTabActiveCellOpts
+	if c.opts.tabActiveCellOpts != nil {
+		activeOpts = c.opts.tabActiveCellOpts
+	}
+
+	for i, ar := range ts.titleAreas(bar) {
+		if ar.Empty() {
+			break
+		}
+		start := ar.Min.Sub(bar.Min)
+		if i > 0 {
+			if _, err := draw.Text(cvs, "│", start.Sub(image.Point{1, 0})); err != nil {
+				return err
+			}
+		}
+
+		var cOpts []cell.Option
+		if i == ts.active {
+			cOpts = activeOpts
+		}
+		if _, err := draw.Text(cvs, tabText(ts.titles[i]), start,
+			draw.TextCellOpts(cOpts...),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+	return cvs.Apply(c.term)
+}
+
+// keyboardToTabs forwards the keyboard event to all the visible containers
+// with tabs, allowing them to switch tabs.
+func (c *Container) keyboardToTabs(k *terminalapi.Keyboard) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errStr string
+	preOrder(rootCont(c), &errStr, visitFunc(func(cur *Container) error {
+		if cur.opts.tabs == nil || cur.isHidden() {
+			return nil
+		}
+		cur.opts.tabs.keyboard(k, c.focusTracker.isActive(cur), cur.opts.tabKeys)
+		return nil
+	}))
+}
+
+// hasTabs determines if any container in the tree has tabs.
+func hasTabs(c *Container) bool {
+	var (
+		errStr string
+		found  bool
+	)
+	preOrder(rootCont(c), &errStr, visitFunc(func(cur *Container) error {
+		if cur.opts.tabs != nil {
+			found = true
+		}
+		return nil
+	}))
+	return found
+}
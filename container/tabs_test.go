@@ -0,0 +1,441 @@
+// Copyright 2026 The termdash Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/event"
+	"github.com/mum4k/termdash/internal/event/testevent"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/internal/widgetapi"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/fakewidget"
+)
+
+// mustDrawTwoTabBar draws the tab bar of tabs titled "a" and "b" with the
+// specified active tab on the row that starts at the provided point.
+func mustDrawTwoTabBar(cvs *canvas.Canvas, start image.Point, active int) {
+	aOpts, bOpts := DefaultTabActiveCellOpts, DefaultTabActiveCellOpts
+	if active == 0 {
+		bOpts = nil
+	} else {
+		aOpts = nil
+	}
+	testdraw.MustText(cvs, " a ", start, draw.TextCellOpts(aOpts...))
+	testdraw.MustText(cvs, "│", start.Add(image.Point{3, 0}))
+	testdraw.MustText(cvs, " b ", start.Add(image.Point{4, 0}), draw.TextCellOpts(bOpts...))
+}
+
+// twoTabs returns two tabs titled "a" and "b" whose containers have borders
+// titled "A" and "B" respectively.
+func twoTabs() []Tab {
+	return []Tab{
+		NewTab("a", Border(linestyle.Light), BorderTitle("A")),
+		NewTab("b", Border(linestyle.Light), BorderTitle("B")),
+	}
+}
+
+func TestTabs(t *testing.T) {
+	tests := []struct {
+		desc             string
+		termSize         image.Point
+		container        func(ft *faketerm.Terminal) (*Container, error)
+		wantContainerErr bool
+		want             func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "fails without tabs",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs())
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on ActiveTab without tabs",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ActiveTab(0))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on ActiveTab out of range",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...), ActiveTab(2))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on TabKeys with identical keys",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...), TabKeys('n', 'n'))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "draws the tab bar and the first tab",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 0)
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6), draw.BorderTitle("A", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws the tab selected by ActiveTab",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...), ActiveTab(1))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 1)
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6), draw.BorderTitle("B", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws a single tab",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(NewTab("a", Border(linestyle.Light))))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, " a ", image.Point{0, 0}, draw.TextCellOpts(DefaultTabActiveCellOpts...))
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws the tab bar inside of the container border",
+			termSize: image.Point{20, 8},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Border(linestyle.Light), Tabs(twoTabs()...))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, ft.Area(), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				mustDrawTwoTabBar(cvs, image.Point{1, 1}, 0)
+				testdraw.MustBorder(cvs, image.Rect(1, 2, 19, 7), draw.BorderTitle("A", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "trims titles that don't fit",
+			termSize: image.Point{8, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(
+					NewTab("one"),
+					NewTab("two"),
+				), TabActiveCellOpts(cell.FgColor(cell.ColorRed)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, " one ", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(cvs, "│", image.Point{5, 0})
+				testdraw.MustText(cvs, " …", image.Point{6, 0})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "placing a widget removes the tabs",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...), PlaceWidget(fakewidget.New(widgetapi.Options{})))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), widgetapi.Options{})
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := faketerm.MustNew(tc.termSize)
+			c, err := tc.container(got)
+			if (err != nil) != tc.wantContainerErr {
+				t.Errorf("tc.container => unexpected error: %v, wantErr: %v", err, tc.wantContainerErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestTabsEvents(t *testing.T) {
+	tests := []struct {
+		desc        string
+		termSize    image.Point
+		container   func(ft *faketerm.Terminal) (*Container, error)
+		eventGroups []*eventGroup
+		want        func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "click on a title switches the tab",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...))
+			},
+			eventGroups: []*eventGroup{
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+						&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+					},
+					wantProcessed: 2,
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 1)
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6), draw.BorderTitle("B", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "click outside of titles doesn't switch the tab",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...))
+			},
+			eventGroups: []*eventGroup{
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Mouse{Position: image.Point{15, 0}, Button: mouse.ButtonLeft},
+						&terminalapi.Mouse{Position: image.Point{15, 0}, Button: mouse.ButtonRelease},
+					},
+					wantProcessed: 2,
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 0)
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6), draw.BorderTitle("A", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "arrow keys switch tabs when the container is focused",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(twoTabs()...))
+			},
+			eventGroups: []*eventGroup{
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+						&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+					},
+					wantProcessed: 2,
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 1)
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6), draw.BorderTitle("B", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "arrow keys don't switch tabs when the container isn't focused",
+			termSize: image.Point{40, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Tabs(twoTabs()...)),
+						Right(),
+					),
+				)
+			},
+			eventGroups: []*eventGroup{
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+					},
+					wantProcessed: 1,
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 0)
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6), draw.BorderTitle("A", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "configured keys switch tabs when the container isn't focused",
+			termSize: image.Point{40, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Tabs(twoTabs()...),
+							TabKeys('p', 'n'),
+						),
+						Right(),
+					),
+				)
+			},
+			eventGroups: []*eventGroup{
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Keyboard{Key: 'n'},
+						&terminalapi.Keyboard{Key: 'n'},
+						&terminalapi.Keyboard{Key: 'p'},
+					},
+					wantProcessed: 3,
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 0)
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 20, 6), draw.BorderTitle("A", draw.OverrunModeThreeDot))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "only the widget in the active tab receives events",
+			termSize: image.Point{30, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(
+					NewTab("a", PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal}))),
+					NewTab("b", PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal}))),
+				))
+			},
+			eventGroups: []*eventGroup{
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+					},
+					wantProcessed: 3,
+				},
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+						&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+					},
+					wantProcessed: 5,
+				},
+				{
+					events: []terminalapi.Event{
+						&terminalapi.Keyboard{Key: keyboard.KeyTab},
+					},
+					wantProcessed: 8,
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTwoTabBar(cvs, image.Point{0, 0}, 1)
+				testcanvas.MustApply(cvs, ft)
+
+				// The widget in the second tab only received the key sent
+				// after it became active.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 30, 10)),
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := faketerm.MustNew(tc.termSize)
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			for _, eg := range tc.eventGroups {
+				for _, ev := range eg.events {
+					eds.Event(ev)
+				}
+				if err := testevent.WaitFor(5*time.Second, func() error {
+					if got, want := eds.Processed(), eg.wantProcessed; got != want {
+						return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+					}
+					return nil
+				}); err != nil {
+					t.Fatalf("testevent.WaitFor => %v", err)
+				}
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ package container
 
 // traversal.go provides functions that navigate the container tree.
 
+import "fmt"
+
 // rootCont returns the root container.
 func rootCont(c *Container) *Container {
 	for p := c.parent; p != nil; p = c.parent {
@@ -56,3 +58,23 @@ func postOrder(c *Container, errStr *string, visit visitFunc) {
 		return
 	}
 }
+
+// findID finds the container with the specified ID in the tree rooted at the
+// provided container.
+// Returns an error if there is no container with this ID.
+func findID(root *Container, id string) (*Container, error) {
+	var (
+		errStr string
+		cont   *Container
+	)
+	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+		if c.opts.id == id && cont == nil {
+			cont = c
+		}
+		return nil
+	}))
+	if cont == nil {
+		return nil, fmt.Errorf("cannot find container with ID %q", id)
+	}
+	return cont, nil
+}
@@ -24,18 +24,26 @@ import (
 	"github.com/mum4k/termdash/internal/runewidth"
 )
 
-// hAlign aligns the given area in the rectangle horizontally.
-func hAlign(rect image.Rectangle, ar image.Rectangle, h align.Horizontal) (image.Rectangle, error) {
-	gap := rect.Dx() - ar.Dx()
+// hGap returns the number of cells to the left of an element aligned
+// horizontally, given the total number of free cells around it.
+func hGap(free int, h align.Horizontal) (int, error) {
 	switch h {
 	case align.HorizontalRight:
-		// Use gap from above.
+		return free, nil
 	case align.HorizontalCenter:
-		gap /= 2
+		return free / 2, nil
 	case align.HorizontalLeft:
-		gap = 0
+		return 0, nil
 	default:
-		return image.ZR, fmt.Errorf("unsupported horizontal alignment %v", h)
+		return 0, fmt.Errorf("unsupported horizontal alignment %v", h)
+	}
+}
+
+// hAlign aligns the given area in the rectangle horizontally.
+func hAlign(rect image.Rectangle, ar image.Rectangle, h align.Horizontal) (image.Rectangle, error) {
+	gap, err := hGap(rect.Dx()-ar.Dx(), h)
+	if err != nil {
+		return image.ZR, err
 	}
 
 	return image.Rect(
@@ -46,18 +54,26 @@ func hAlign(rect image.Rectangle, ar image.Rectangle, h align.Horizontal) (image
 	), nil
 }
 
-// vAlign aligns the given area in the rectangle vertically.
-func vAlign(rect image.Rectangle, ar image.Rectangle, v align.Vertical) (image.Rectangle, error) {
-	gap := rect.Dy() - ar.Dy()
+// vGap returns the number of cells above an element aligned vertically,
+// given the total number of free cells around it.
+func vGap(free int, v align.Vertical) (int, error) {
 	switch v {
 	case align.VerticalBottom:
-		// Use gap from above.
+		return free, nil
 	case align.VerticalMiddle:
-		gap /= 2
+		return free / 2, nil
 	case align.VerticalTop:
-		gap = 0
+		return 0, nil
 	default:
-		return image.ZR, fmt.Errorf("unsupported vertical alignment %v", v)
+		return 0, fmt.Errorf("unsupported vertical alignment %v", v)
+	}
+}
+
+// vAlign aligns the given area in the rectangle vertically.
+func vAlign(rect image.Rectangle, ar image.Rectangle, v align.Vertical) (image.Rectangle, error) {
+	gap, err := vGap(rect.Dy()-ar.Dy(), v)
+	if err != nil {
+		return image.ZR, err
 	}
 
 	return image.Rect(
@@ -118,3 +134,53 @@ func Text(rect image.Rectangle, text string, h align.Horizontal, v align.Vertica
 	}
 	return image.Point{aligned.Min.X, aligned.Min.Y}, nil
 }
+
+// HorizontalText aligns the text horizontally within the specified width,
+// returns the number of cells from the start of the width where the text
+// should start. Accounts for full-width runes in the text.
+// If the text is wider than the width, returns zero so that the text can be
+// trimmed at the end. When strict is true, returns an error instead.
+// This only supports a single line of text, the text must not contain newlines.
+func HorizontalText(width int, text string, h align.Horizontal, strict bool) (int, error) {
+	if width < 0 {
+		return 0, fmt.Errorf("the width cannot be negative, got %d", width)
+	}
+	if strings.ContainsRune(text, '\n') {
+		return 0, fmt.Errorf("the provided text contains a newline character: %q", text)
+	}
+
+	cells := runewidth.StringWidth(text)
+	if cells > width {
+		if strict {
+			return 0, fmt.Errorf("the text %q needs %d cells, which is more than the width %d", text, cells, width)
+		}
+		cells = width
+	}
+
+	return hGap(width-cells, h)
+}
+
+// VerticalText aligns a block of lines of text vertically within the specified
+// height, returns the number of rows from the start of the height where the
+// first line should start. Rounds the same way as Rectangle does.
+// If there are more lines than the height, returns zero and reports that the
+// lines will be clipped at the bottom.
+func VerticalText(height, lineCount int, v align.Vertical) (startY int, clipped bool, err error) {
+	if height < 0 {
+		return 0, false, fmt.Errorf("the height cannot be negative, got %d", height)
+	}
+	if lineCount < 0 {
+		return 0, false, fmt.Errorf("the line count cannot be negative, got %d", lineCount)
+	}
+
+	if lineCount > height {
+		lineCount = height
+		clipped = true
+	}
+
+	gap, err := vGap(height-lineCount, v)
+	if err != nil {
+		return 0, false, err
+	}
+	return gap, clipped, nil
+}
@@ -349,3 +349,232 @@ func TestText(t *testing.T) {
 		})
 	}
 }
+
+func TestHorizontalText(t *testing.T) {
+	tests := []struct {
+		desc    string
+		width   int
+		text    string
+		hAlign  align.Horizontal
+		strict  bool
+		want    int
+		wantErr bool
+	}{
+		{
+			desc:    "fails on negative width",
+			width:   -1,
+			text:    "a",
+			hAlign:  align.HorizontalLeft,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on a newline",
+			width:   3,
+			text:    "a\nb",
+			hAlign:  align.HorizontalLeft,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on unsupported horizontal alignment",
+			width:   3,
+			text:    "a",
+			hAlign:  align.Horizontal(-1),
+			wantErr: true,
+		},
+		{
+			desc:   "aligns empty text",
+			width:  3,
+			text:   "",
+			hAlign: align.HorizontalCenter,
+			want:   1,
+		},
+		{
+			desc:   "aligns text left",
+			width:  3,
+			text:   "a",
+			hAlign: align.HorizontalLeft,
+			want:   0,
+		},
+		{
+			desc:   "aligns text center",
+			width:  3,
+			text:   "a",
+			hAlign: align.HorizontalCenter,
+			want:   1,
+		},
+		{
+			desc:   "aligns text center, rounds down",
+			width:  4,
+			text:   "a",
+			hAlign: align.HorizontalCenter,
+			want:   1,
+		},
+		{
+			desc:   "aligns text right",
+			width:  3,
+			text:   "a",
+			hAlign: align.HorizontalRight,
+			want:   2,
+		},
+		{
+			desc:   "aligns full-width text center",
+			width:  4,
+			text:   "界",
+			hAlign: align.HorizontalCenter,
+			want:   1,
+		},
+		{
+			desc:   "aligns full-width text right",
+			width:  4,
+			text:   "界",
+			hAlign: align.HorizontalRight,
+			want:   2,
+		},
+		{
+			desc:   "text that exactly fits",
+			width:  3,
+			text:   "abc",
+			hAlign: align.HorizontalRight,
+			want:   0,
+		},
+		{
+			desc:   "text that is too long is clamped to zero",
+			width:  3,
+			text:   "abcd",
+			hAlign: align.HorizontalRight,
+			want:   0,
+		},
+		{
+			desc:    "text that is too long fails in strict mode",
+			width:   3,
+			text:    "abcd",
+			hAlign:  align.HorizontalRight,
+			strict:  true,
+			wantErr: true,
+		},
+		{
+			desc:    "full-width text that is too long fails in strict mode",
+			width:   3,
+			text:    "界界",
+			hAlign:  align.HorizontalLeft,
+			strict:  true,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := HorizontalText(tc.width, tc.text, tc.hAlign, tc.strict)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("HorizontalText => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got != tc.want {
+				t.Errorf("HorizontalText => %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerticalText(t *testing.T) {
+	tests := []struct {
+		desc        string
+		height      int
+		lineCount   int
+		vAlign      align.Vertical
+		want        int
+		wantClipped bool
+		wantErr     bool
+	}{
+		{
+			desc:      "fails on negative height",
+			height:    -1,
+			lineCount: 1,
+			vAlign:    align.VerticalTop,
+			wantErr:   true,
+		},
+		{
+			desc:      "fails on negative line count",
+			height:    3,
+			lineCount: -1,
+			vAlign:    align.VerticalTop,
+			wantErr:   true,
+		},
+		{
+			desc:      "fails on unsupported vertical alignment",
+			height:    3,
+			lineCount: 1,
+			vAlign:    align.Vertical(-1),
+			wantErr:   true,
+		},
+		{
+			desc:      "aligns no lines",
+			height:    3,
+			lineCount: 0,
+			vAlign:    align.VerticalMiddle,
+			want:      1,
+		},
+		{
+			desc:      "aligns lines top",
+			height:    5,
+			lineCount: 2,
+			vAlign:    align.VerticalTop,
+			want:      0,
+		},
+		{
+			desc:      "aligns lines middle",
+			height:    5,
+			lineCount: 3,
+			vAlign:    align.VerticalMiddle,
+			want:      1,
+		},
+		{
+			desc:      "aligns lines middle, rounds down",
+			height:    5,
+			lineCount: 2,
+			vAlign:    align.VerticalMiddle,
+			want:      1,
+		},
+		{
+			desc:      "aligns lines bottom",
+			height:    5,
+			lineCount: 2,
+			vAlign:    align.VerticalBottom,
+			want:      3,
+		},
+		{
+			desc:      "lines that exactly fit",
+			height:    2,
+			lineCount: 2,
+			vAlign:    align.VerticalBottom,
+			want:      0,
+		},
+		{
+			desc:        "too many lines are clamped to zero and clipped",
+			height:      2,
+			lineCount:   3,
+			vAlign:      align.VerticalBottom,
+			want:        0,
+			wantClipped: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotClipped, err := VerticalText(tc.height, tc.lineCount, tc.vAlign)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("VerticalText => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got != tc.want || gotClipped != tc.wantClipped {
+				t.Errorf("VerticalText => (%d, %v), want (%d, %v)", got, gotClipped, tc.want, tc.wantClipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package animation contains helpers for widgets that transition smoothly
+// between two states.
+package animation
+
+import (
+	"sync"
+	"time"
+)
+
+// FrameInterval is the time between redraws requested by widgets while a
+// transition is in progress.
+const FrameInterval = time.Second / 25
+
+// Progress returns the completed fraction of a transition that started at
+// the start time and lasts for the duration d, as of the time now.
+// The returned value is in range 0 <= progress <= 1. Transitions with zero or
+// negative duration are always complete.
+func Progress(start, now time.Time, d time.Duration) float64 {
+	elapsed := now.Sub(start)
+	switch {
+	case d <= 0 || elapsed >= d:
+		return 1
+	case elapsed <= 0:
+		return 0
+	default:
+		return float64(elapsed) / float64(d)
+	}
+}
+
+// Interpolate returns the value between from and to that corresponds to the
+// progress of the transition, see Progress. Returns exactly the value to once
+// the transition is complete.
+func Interpolate(from, to, progress float64) float64 {
+	if progress >= 1 {
+		return to
+	}
+	return from + (to-from)*progress
+}
+
+// Frames requests redraws on behalf of a widget while a transition is in
+// progress. At most one request is pending at any time.
+// The zero value is ready to use. This object is thread-safe.
+type Frames struct {
+	// mu protects pending.
+	mu sync.Mutex
+	// pending indicates that a request is scheduled and not yet executed.
+	pending bool
+}
+
+// Schedule calls fn once the FrameInterval elapses, unless a previously
+// scheduled call is still pending. A nil fn is ignored.
+// Widgets call this when drawing an incomplete transition and provide the
+// function received via widgetapi.Notifier.
+func (f *Frames) Schedule(fn func()) {
+	if fn == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.pending {
+		return
+	}
+	f.pending = true
+	time.AfterFunc(FrameInterval, func() {
+		f.mu.Lock()
+		f.pending = false
+		f.mu.Unlock()
+		fn()
+	})
+}
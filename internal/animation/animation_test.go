@@ -0,0 +1,98 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestProgress(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		elapsed time.Duration
+		d       time.Duration
+		want    float64
+	}{
+		{0, 0, 1},
+		{time.Second, 0, 1},
+		{time.Second, -time.Second, 1},
+		{-time.Second, time.Second, 0},
+		{0, time.Second, 0},
+		{250 * time.Millisecond, time.Second, 0.25},
+		{time.Second, time.Second, 1},
+		{2 * time.Second, time.Second, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("elapsed:%v duration:%v", tc.elapsed, tc.d), func(t *testing.T) {
+			if got := Progress(start, start.Add(tc.elapsed), tc.d); got != tc.want {
+				t.Errorf("Progress => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		from, to, progress float64
+		want               float64
+	}{
+		{0, 10, 0, 0},
+		{0, 10, 0.5, 5},
+		{0, 10, 1, 10},
+		{10, 0, 0.25, 7.5},
+		{-5, 5, 0.5, 0},
+		{0.3, 0.7, 1, 0.7},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("from:%v to:%v progress:%v", tc.from, tc.to, tc.progress), func(t *testing.T) {
+			if got := Interpolate(tc.from, tc.to, tc.progress); got != tc.want {
+				t.Errorf("Interpolate => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFrames(t *testing.T) {
+	var f Frames
+	f.Schedule(nil) // Doesn't panic.
+
+	called := make(chan struct{}, 10)
+	fn := func() { called <- struct{}{} }
+	f.Schedule(fn)
+	f.Schedule(fn) // Ignored, a call is pending.
+
+	select {
+	case <-called:
+	case <-time.After(10 * FrameInterval):
+		t.Fatalf("Schedule => the function wasn't called")
+	}
+	select {
+	case <-called:
+		t.Fatalf("Schedule => the function was called more than once")
+	case <-time.After(2 * FrameInterval):
+	}
+
+	// Can schedule again once the pending call executed.
+	f.Schedule(fn)
+	select {
+	case <-called:
+	case <-time.After(10 * FrameInterval):
+		t.Fatalf("Schedule => the function wasn't called after the previous call completed")
+	}
+}
@@ -78,6 +78,44 @@ func VSplit(area image.Rectangle, widthPerc int) (left image.Rectangle, right im
 	return left, right, nil
 }
 
+// HSplitCells returns two new areas created by splitting the provided area
+// after the specified number of cells of its height. The number of cells must
+// be in the range 0 <= cells <= area.Dy().
+// Can return zero size areas.
+func HSplitCells(area image.Rectangle, cells int) (top image.Rectangle, bottom image.Rectangle, err error) {
+	if min, max := 0, area.Dy(); cells < min || cells > max {
+		return image.ZR, image.ZR, fmt.Errorf("invalid cells %d, must be in range %d <= cells <= %d", cells, min, max)
+	}
+	top = image.Rect(area.Min.X, area.Min.Y, area.Max.X, area.Min.Y+cells)
+	if top.Dy() == 0 {
+		top = image.ZR
+	}
+	bottom = image.Rect(area.Min.X, area.Min.Y+cells, area.Max.X, area.Max.Y)
+	if bottom.Dy() == 0 {
+		bottom = image.ZR
+	}
+	return top, bottom, nil
+}
+
+// VSplitCells returns two new areas created by splitting the provided area
+// after the specified number of cells of its width. The number of cells must
+// be in the range 0 <= cells <= area.Dx().
+// Can return zero size areas.
+func VSplitCells(area image.Rectangle, cells int) (left image.Rectangle, right image.Rectangle, err error) {
+	if min, max := 0, area.Dx(); cells < min || cells > max {
+		return image.ZR, image.ZR, fmt.Errorf("invalid cells %d, must be in range %d <= cells <= %d", cells, min, max)
+	}
+	left = image.Rect(area.Min.X, area.Min.Y, area.Min.X+cells, area.Max.Y)
+	if left.Dx() == 0 {
+		left = image.ZR
+	}
+	right = image.Rect(area.Min.X+cells, area.Min.Y, area.Max.X, area.Max.Y)
+	if right.Dx() == 0 {
+		right = image.ZR
+	}
+	return left, right, nil
+}
+
 // ExcludeBorder returns a new area created by subtracting a border around the
 // provided area. Return the zero area if there isn't enough space to exclude
 // the border.
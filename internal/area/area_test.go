@@ -282,6 +282,146 @@ func TestVSplit(t *testing.T) {
 	}
 }
 
+func TestHSplitCells(t *testing.T) {
+	tests := []struct {
+		desc    string
+		area    image.Rectangle
+		cells   int
+		wantTop image.Rectangle
+		wantBot image.Rectangle
+		wantErr bool
+	}{
+		{
+			desc:    "fails on negative cells",
+			area:    image.Rect(1, 1, 2, 2),
+			cells:   -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on cells larger than the height",
+			area:    image.Rect(1, 1, 2, 2),
+			cells:   2,
+			wantErr: true,
+		},
+		{
+			desc:    "zero area to begin with",
+			area:    image.ZR,
+			cells:   0,
+			wantTop: image.ZR,
+			wantBot: image.ZR,
+		},
+		{
+			desc:    "splitting results in zero height area on the top",
+			area:    image.Rect(1, 1, 2, 2),
+			cells:   0,
+			wantTop: image.ZR,
+			wantBot: image.Rect(1, 1, 2, 2),
+		},
+		{
+			desc:    "splitting results in zero height area on the bottom",
+			area:    image.Rect(1, 1, 2, 2),
+			cells:   1,
+			wantTop: image.Rect(1, 1, 2, 2),
+			wantBot: image.ZR,
+		},
+		{
+			desc:    "splits to unequal areas",
+			area:    image.Rect(0, 1, 4, 6),
+			cells:   2,
+			wantTop: image.Rect(0, 1, 4, 3),
+			wantBot: image.Rect(0, 3, 4, 6),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotTop, gotBot, err := HSplitCells(tc.area, tc.cells)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("HSplitCells => unexpected error:%v, wantErr:%v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.wantTop, gotTop); diff != "" {
+				t.Errorf("HSplitCells => first value unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantBot, gotBot); diff != "" {
+				t.Errorf("HSplitCells => second value unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVSplitCells(t *testing.T) {
+	tests := []struct {
+		desc      string
+		area      image.Rectangle
+		cells     int
+		wantLeft  image.Rectangle
+		wantRight image.Rectangle
+		wantErr   bool
+	}{
+		{
+			desc:    "fails on negative cells",
+			area:    image.Rect(1, 1, 2, 2),
+			cells:   -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on cells larger than the width",
+			area:    image.Rect(1, 1, 2, 2),
+			cells:   2,
+			wantErr: true,
+		},
+		{
+			desc:      "zero area to begin with",
+			area:      image.ZR,
+			cells:     0,
+			wantLeft:  image.ZR,
+			wantRight: image.ZR,
+		},
+		{
+			desc:      "splitting results in zero width area on the left",
+			area:      image.Rect(1, 1, 2, 2),
+			cells:     0,
+			wantLeft:  image.ZR,
+			wantRight: image.Rect(1, 1, 2, 2),
+		},
+		{
+			desc:      "splitting results in zero width area on the right",
+			area:      image.Rect(1, 1, 2, 2),
+			cells:     1,
+			wantLeft:  image.Rect(1, 1, 2, 2),
+			wantRight: image.ZR,
+		},
+		{
+			desc:      "splits to unequal areas",
+			area:      image.Rect(1, 0, 6, 4),
+			cells:     2,
+			wantLeft:  image.Rect(1, 0, 3, 4),
+			wantRight: image.Rect(3, 0, 6, 4),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotLeft, gotRight, err := VSplitCells(tc.area, tc.cells)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("VSplitCells => unexpected error:%v, wantErr:%v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.wantLeft, gotLeft); diff != "" {
+				t.Errorf("VSplitCells => left value unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantRight, gotRight); diff != "" {
+				t.Errorf("VSplitCells => right value unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestExcludeBorder(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -71,6 +71,53 @@ func (t *Tracker) Add(low, high, attrIdx int) error {
 	return nil
 }
 
+// Overlay adds a new range of items that share attributes with the specified
+// index. Unlike Add, the new range can overlap any existing ranges.
+// Existing ranges that overlap with the new range are split so that the new
+// range overrides their attributes on the overlapping portion. The new range
+// is merged with adjacent ranges that have the same attribute index.
+func (t *Tracker) Overlay(low, high, attrIdx int) error {
+	if low >= high {
+		return fmt.Errorf("invalid range, low:%d must be less than high:%d", low, high)
+	}
+
+	for k, ar := range t.ranges {
+		if ar.High <= low || ar.Low >= high {
+			continue // No overlap.
+		}
+		delete(t.ranges, k)
+		if ar.Low < low {
+			t.ranges[ar.Low] = newAttrRange(ar.Low, low, ar.AttrIdx)
+		}
+		if ar.High > high {
+			t.ranges[high] = newAttrRange(high, ar.High, ar.AttrIdx)
+		}
+	}
+
+	var left, right *AttrRange
+	for _, ar := range t.ranges {
+		if ar.AttrIdx != attrIdx {
+			continue
+		}
+		switch {
+		case ar.High == low:
+			left = ar
+		case ar.Low == high:
+			right = ar
+		}
+	}
+	if left != nil {
+		delete(t.ranges, left.Low)
+		low = left.Low
+	}
+	if right != nil {
+		delete(t.ranges, right.Low)
+		high = right.High
+	}
+	t.ranges[low] = newAttrRange(low, high, attrIdx)
+	return nil
+}
+
 // ForPosition returns attribute index that apply to the specified position.
 // Returns ErrNotFound when the requested position wasn't found in any of the
 // known ranges.
@@ -101,3 +148,42 @@ func (t *Tracker) ForPosition(pos int) (*AttrRange, error) {
 	}
 	return res, nil
 }
+
+// ForRange returns all the attribute ranges that intersect with the span of
+// items low <= b < high ordered by their low positions.
+// The returned ranges are copies clipped to the requested span, i.e. the Low
+// and High of each returned range fall within the span. Modifying them doesn't
+// affect the tracker.
+// Returns an empty slice when no known ranges intersect the span.
+func (t *Tracker) ForRange(low, high int) ([]*AttrRange, error) {
+	if low >= high {
+		return nil, fmt.Errorf("invalid span, low:%d must be less than high:%d", low, high)
+	}
+
+	var keys []int
+	for k := range t.ranges {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	res := []*AttrRange{}
+	for _, k := range keys {
+		ar := t.ranges[k]
+		if ar.Low >= high {
+			break
+		}
+		if ar.High <= low {
+			continue
+		}
+
+		clipped := newAttrRange(ar.Low, ar.High, ar.AttrIdx)
+		if clipped.Low < low {
+			clipped.Low = low
+		}
+		if clipped.High > high {
+			clipped.High = high
+		}
+		res = append(res, clipped)
+	}
+	return res, nil
+}
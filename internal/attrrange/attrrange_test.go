@@ -163,3 +163,349 @@ func TestForPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestOverlay(t *testing.T) {
+	tests := []struct {
+		desc string
+		// if not nil, called before calling Overlay.
+		// Can add ranges.
+		update  func(*Tracker) error
+		low     int
+		high    int
+		attrIdx int
+		want    map[int]*AttrRange
+		wantErr bool
+	}{
+		{
+			desc:    "fails when low equals high",
+			low:     2,
+			high:    2,
+			wantErr: true,
+		},
+		{
+			desc:    "fails when low is greater than high",
+			low:     3,
+			high:    2,
+			wantErr: true,
+		},
+		{
+			desc:    "adds to an empty tracker",
+			low:     2,
+			high:    5,
+			attrIdx: 40,
+			want: map[int]*AttrRange{
+				2: newAttrRange(2, 5, 40),
+			},
+		},
+		{
+			desc: "doesn't affect non-overlapping ranges",
+			update: func(tr *Tracker) error {
+				if err := tr.Add(0, 2, 40); err != nil {
+					return err
+				}
+				return tr.Add(5, 10, 41)
+			},
+			low:     2,
+			high:    5,
+			attrIdx: 42,
+			want: map[int]*AttrRange{
+				0: newAttrRange(0, 2, 40),
+				2: newAttrRange(2, 5, 42),
+				5: newAttrRange(5, 10, 41),
+			},
+		},
+		{
+			desc: "partial overlap on the left of an existing range",
+			update: func(tr *Tracker) error {
+				return tr.Add(2, 10, 40)
+			},
+			low:     0,
+			high:    5,
+			attrIdx: 41,
+			want: map[int]*AttrRange{
+				0: newAttrRange(0, 5, 41),
+				5: newAttrRange(5, 10, 40),
+			},
+		},
+		{
+			desc: "partial overlap on the right of an existing range",
+			update: func(tr *Tracker) error {
+				return tr.Add(2, 10, 40)
+			},
+			low:     5,
+			high:    12,
+			attrIdx: 41,
+			want: map[int]*AttrRange{
+				2: newAttrRange(2, 5, 40),
+				5: newAttrRange(5, 12, 41),
+			},
+		},
+		{
+			desc: "fully contained within an existing range",
+			update: func(tr *Tracker) error {
+				return tr.Add(2, 10, 40)
+			},
+			low:     4,
+			high:    6,
+			attrIdx: 41,
+			want: map[int]*AttrRange{
+				2: newAttrRange(2, 4, 40),
+				4: newAttrRange(4, 6, 41),
+				6: newAttrRange(6, 10, 40),
+			},
+		},
+		{
+			desc: "exactly replaces an existing range",
+			update: func(tr *Tracker) error {
+				return tr.Add(2, 10, 40)
+			},
+			low:     2,
+			high:    10,
+			attrIdx: 41,
+			want: map[int]*AttrRange{
+				2: newAttrRange(2, 10, 41),
+			},
+		},
+		{
+			desc: "fully covers multiple existing ranges",
+			update: func(tr *Tracker) error {
+				if err := tr.Add(0, 2, 40); err != nil {
+					return err
+				}
+				if err := tr.Add(2, 5, 41); err != nil {
+					return err
+				}
+				if err := tr.Add(5, 7, 42); err != nil {
+					return err
+				}
+				return tr.Add(7, 10, 43)
+			},
+			low:     1,
+			high:    8,
+			attrIdx: 44,
+			want: map[int]*AttrRange{
+				0: newAttrRange(0, 1, 40),
+				1: newAttrRange(1, 8, 44),
+				8: newAttrRange(8, 10, 43),
+			},
+		},
+		{
+			desc: "merges with adjacent ranges that have the same attributes",
+			update: func(tr *Tracker) error {
+				if err := tr.Add(0, 4, 40); err != nil {
+					return err
+				}
+				if err := tr.Add(4, 6, 41); err != nil {
+					return err
+				}
+				return tr.Add(6, 10, 40)
+			},
+			low:     4,
+			high:    6,
+			attrIdx: 40,
+			want: map[int]*AttrRange{
+				0: newAttrRange(0, 10, 40),
+			},
+		},
+		{
+			desc: "merges after splitting a range with the same attributes",
+			update: func(tr *Tracker) error {
+				return tr.Add(0, 10, 40)
+			},
+			low:     4,
+			high:    6,
+			attrIdx: 40,
+			want: map[int]*AttrRange{
+				0: newAttrRange(0, 10, 40),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := NewTracker()
+			if tc.update != nil {
+				if err := tc.update(tr); err != nil {
+					t.Fatalf("tc.update => unexpected error:%v", err)
+				}
+			}
+
+			err := tr.Overlay(tc.low, tc.high, tc.attrIdx)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Overlay => unexpected error:%v, wantErr:%v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.want, tr.ranges); diff != "" {
+				t.Errorf("Overlay => unexpected ranges (-want, +got):\n%s", diff)
+			}
+
+			got, err := tr.ForPosition(tc.low)
+			if err != nil {
+				t.Fatalf("ForPosition => unexpected error: %v", err)
+			}
+			if got.AttrIdx != tc.attrIdx {
+				t.Errorf("ForPosition(%d) => AttrIdx %d, want %d", tc.low, got.AttrIdx, tc.attrIdx)
+			}
+		})
+	}
+}
+
+func TestForRange(t *testing.T) {
+	tests := []struct {
+		desc string
+		// if not nil, called before calling ForRange.
+		// Can add ranges.
+		update  func(*Tracker) error
+		low     int
+		high    int
+		want    []*AttrRange
+		wantErr bool
+	}{
+		{
+			desc:    "fails when low equals high",
+			low:     2,
+			high:    2,
+			wantErr: true,
+		},
+		{
+			desc:    "fails when low is greater than high",
+			low:     3,
+			high:    2,
+			wantErr: true,
+		},
+		{
+			desc: "no ranges given",
+			low:  0,
+			high: 5,
+			want: []*AttrRange{},
+		},
+		{
+			desc: "span falls before all ranges",
+			update: func(tr *Tracker) error {
+				return tr.Add(5, 10, 40)
+			},
+			low:  0,
+			high: 5,
+			want: []*AttrRange{},
+		},
+		{
+			desc: "span falls after all ranges",
+			update: func(tr *Tracker) error {
+				return tr.Add(5, 10, 40)
+			},
+			low:  10,
+			high: 15,
+			want: []*AttrRange{},
+		},
+		{
+			desc: "span exactly matches a range",
+			update: func(tr *Tracker) error {
+				if err := tr.Add(0, 5, 40); err != nil {
+					return err
+				}
+				if err := tr.Add(5, 10, 41); err != nil {
+					return err
+				}
+				return tr.Add(10, 15, 42)
+			},
+			low:  5,
+			high: 10,
+			want: []*AttrRange{
+				newAttrRange(5, 10, 41),
+			},
+		},
+		{
+			desc: "span intersects multiple ranges, they are clipped and ordered",
+			update: func(tr *Tracker) error {
+				if err := tr.Add(10, 15, 42); err != nil {
+					return err
+				}
+				if err := tr.Add(0, 5, 40); err != nil {
+					return err
+				}
+				return tr.Add(5, 10, 41)
+			},
+			low:  3,
+			high: 12,
+			want: []*AttrRange{
+				newAttrRange(3, 5, 40),
+				newAttrRange(5, 10, 41),
+				newAttrRange(10, 12, 42),
+			},
+		},
+		{
+			desc: "span falls within a single range",
+			update: func(tr *Tracker) error {
+				return tr.Add(0, 10, 40)
+			},
+			low:  3,
+			high: 4,
+			want: []*AttrRange{
+				newAttrRange(3, 4, 40),
+			},
+		},
+		{
+			desc: "span covers a gap between ranges",
+			update: func(tr *Tracker) error {
+				if err := tr.Add(0, 2, 40); err != nil {
+					return err
+				}
+				return tr.Add(5, 10, 41)
+			},
+			low:  1,
+			high: 6,
+			want: []*AttrRange{
+				newAttrRange(1, 2, 40),
+				newAttrRange(5, 6, 41),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := NewTracker()
+			if tc.update != nil {
+				if err := tc.update(tr); err != nil {
+					t.Fatalf("tc.update => unexpected error:%v", err)
+				}
+			}
+
+			got, err := tr.ForRange(tc.low, tc.high)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ForRange => unexpected error:%v, wantErr:%v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("ForRange => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestForRangeDoesNotModifyTracker(t *testing.T) {
+	tr := NewTracker()
+	if err := tr.Add(0, 10, 40); err != nil {
+		t.Fatalf("Add => unexpected error: %v", err)
+	}
+
+	got, err := tr.ForRange(3, 5)
+	if err != nil {
+		t.Fatalf("ForRange => unexpected error: %v", err)
+	}
+	got[0].Low = 4
+
+	want := newAttrRange(0, 10, 40)
+	ar, err := tr.ForPosition(0)
+	if err != nil {
+		t.Fatalf("ForPosition => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare(want, ar); diff != "" {
+		t.Errorf("ForPosition => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -65,12 +65,19 @@ func (c *Canvas) Area() image.Rectangle {
 }
 
 // Clear clears all the content on the canvas.
-func (c *Canvas) Clear() error {
-	b, err := buffer.New(c.Size())
-	if err != nil {
-		return err
+// If no options are provided, all the cells are reset to empty cells with the
+// default cell options. Otherwise all the cells are reset to the space rune
+// with the provided options, e.g. to clear the canvas to a background color.
+func (c *Canvas) Clear(opts ...cell.Option) error {
+	var r rune
+	if len(opts) > 0 {
+		r = ' '
+	}
+	for col := range c.buffer {
+		for row := range c.buffer[col] {
+			c.buffer[col][row] = buffer.NewCell(r, opts...)
+		}
 	}
-	c.buffer = b
 	return nil
 }
 
@@ -84,6 +91,7 @@ func (c *Canvas) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error
 }
 
 // Cell returns a copy of the specified cell.
+// Cells that were never set contain the zero rune and the default options.
 func (c *Canvas) Cell(p image.Point) (*buffer.Cell, error) {
 	ar, err := area.FromSize(c.Size())
 	if err != nil {
@@ -96,6 +104,16 @@ func (c *Canvas) Cell(p image.Point) (*buffer.Cell, error) {
 	return c.buffer[p.X][p.Y].Copy(), nil
 }
 
+// RuneAt returns the rune in the specified cell.
+// Returns the zero rune for cells that were never set.
+func (c *Canvas) RuneAt(p image.Point) (rune, error) {
+	cell, err := c.Cell(p)
+	if err != nil {
+		return 0, err
+	}
+	return cell.Rune, nil
+}
+
 // SetCellOpts sets options on the specified cell of the canvas without
 // modifying the content of the cell.
 // Sets the default cell options if no options are provided.
@@ -245,3 +263,67 @@ func (c *Canvas) CopyTo(dst *Canvas) error {
 	offset := c.area.Min
 	return c.copyTo(offset, fn)
 }
+
+// MergeTransparent is like CopyTo, but treats the cells of this canvas that
+// contain the transparent rune as transparent, i.e. it doesn't copy them so the
+// content of the destination canvas shows through. The remaining cells are
+// copied with their cell options. Use the zero rune to skip the cells that
+// were never set.
+func (c *Canvas) MergeTransparent(dst *Canvas, transparent rune) error {
+	if !c.area.In(dst.Area()) {
+		return fmt.Errorf("the canvas area %v doesn't fit or lie inside the destination canvas area %v", c.area, dst.Area())
+	}
+
+	fn := setCellFunc(func(p image.Point, r rune, opts ...cell.Option) error {
+		if r == transparent {
+			return nil
+		}
+		if _, err := dst.SetCell(p, r, opts...); err != nil {
+			return fmt.Errorf("dst.SetCell => %v", err)
+		}
+		return nil
+	})
+	return c.copyTo(c.area.Min, fn)
+}
+
+// CopyRegion copies the content of the region of this canvas onto the
+// destination canvas, placing the top left corner of the region at the
+// provided point. Both the region and the point use the zero-based
+// coordinates of the respective canvases, i.e. unlike CopyTo this ignores the
+// offsets of the canvases. The cell options are copied along with the runes.
+// Full-width runes are only copied if both of the cells they occupy fall
+// within the region.
+// Returns an error if the region doesn't fit inside this canvas or if it
+// doesn't fit inside the destination canvas when placed at the point.
+func (c *Canvas) CopyRegion(dst *Canvas, srcAr image.Rectangle, dstPoint image.Point) error {
+	if !srcAr.In(c.Area()) {
+		return fmt.Errorf("the source region %v doesn't fit inside the canvas area %v", srcAr, c.Area())
+	}
+	dstAr := srcAr.Sub(srcAr.Min).Add(dstPoint)
+	if !dstAr.In(dst.Area()) {
+		return fmt.Errorf("the source region %v placed at point %v occupies area %v that doesn't fit inside the destination canvas area %v", srcAr, dstPoint, dstAr, dst.Area())
+	}
+
+	offset := dstPoint.Sub(srcAr.Min)
+	for col := srcAr.Min.X; col < srcAr.Max.X; col++ {
+		for row := srcAr.Min.Y; row < srcAr.Max.Y; row++ {
+			p := image.Point{col, row}
+			partial, err := c.buffer.IsPartial(p)
+			if err != nil {
+				return err
+			}
+			if partial {
+				// The full-width rune starts in the preceding cell.
+				continue
+			}
+			cell := c.buffer[col][row]
+			if col+runewidth.RuneWidth(cell.Rune) > srcAr.Max.X {
+				continue // The full-width rune doesn't fit the region.
+			}
+			if _, err := dst.SetCell(p.Add(offset), cell.Rune, cell.Opts); err != nil {
+				return fmt.Errorf("dst.SetCell => %v", err)
+			}
+		}
+	}
+	return nil
+}
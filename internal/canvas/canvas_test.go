@@ -948,6 +948,16 @@ func TestCell(t *testing.T) {
 				),
 			},
 		},
+		{
+			desc: "returns the zero cell for a point that was never set",
+			cvs: func() (*Canvas, error) {
+				return New(image.Rect(0, 0, 2, 2))
+			},
+			point: image.Point{0, 1},
+			want: &buffer.Cell{
+				Opts: cell.NewOptions(),
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1154,3 +1164,294 @@ func TestCopyTo(t *testing.T) {
 		})
 	}
 }
+
+func TestRuneAt(t *testing.T) {
+	cvs := mustNew(image.Rect(0, 0, 3, 1))
+	mustSetCell(cvs, image.Point{0, 0}, 'A', cell.FgColor(cell.ColorRed))
+	mustSetCell(cvs, image.Point{1, 0}, '世')
+
+	tests := []struct {
+		desc    string
+		point   image.Point
+		want    rune
+		wantErr bool
+	}{
+		{
+			desc:    "fails on point outside of the canvas",
+			point:   image.Point{3, 0},
+			wantErr: true,
+		},
+		{
+			desc:  "returns the rune",
+			point: image.Point{0, 0},
+			want:  'A',
+		},
+		{
+			desc:  "returns full-width rune",
+			point: image.Point{1, 0},
+			want:  '世',
+		},
+		{
+			desc:  "returns zero rune for the cell occupied by the second half of a full-width rune",
+			point: image.Point{2, 0},
+			want:  0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := cvs.RuneAt(tc.point)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("RuneAt => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want {
+				t.Errorf("RuneAt => got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClearWithOptions(t *testing.T) {
+	c := mustNew(image.Rect(0, 0, 2, 1))
+	mustSetCell(c, image.Point{0, 0}, 'X', cell.FgColor(cell.ColorRed))
+
+	if err := c.Clear(cell.BgColor(cell.ColorBlue)); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+
+	want := buffer.Buffer{
+		{
+			buffer.NewCell(' ', cell.BgColor(cell.ColorBlue)),
+		},
+		{
+			buffer.NewCell(' ', cell.BgColor(cell.ColorBlue)),
+		},
+	}
+	if diff := pretty.Compare(want, c.buffer); diff != "" {
+		t.Errorf("Clear => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCopyRegion(t *testing.T) {
+	tests := []struct {
+		desc     string
+		src      *Canvas
+		dst      *Canvas
+		srcAr    image.Rectangle
+		dstPoint image.Point
+		want     *Canvas
+		wantErr  bool
+	}{
+		{
+			desc:    "fails when the region doesn't fit the source canvas",
+			src:     mustNew(image.Rect(0, 0, 2, 2)),
+			dst:     mustNew(image.Rect(0, 0, 3, 3)),
+			srcAr:   image.Rect(1, 1, 3, 3),
+			want:    mustNew(image.Rect(0, 0, 3, 3)),
+			wantErr: true,
+		},
+		{
+			desc:     "fails when the region doesn't fit the destination canvas at the point",
+			src:      mustNew(image.Rect(0, 0, 2, 2)),
+			dst:      mustNew(image.Rect(0, 0, 3, 3)),
+			srcAr:    image.Rect(0, 0, 2, 2),
+			dstPoint: image.Point{2, 0},
+			want:     mustNew(image.Rect(0, 0, 3, 3)),
+			wantErr:  true,
+		},
+		{
+			desc: "copies the region to the point with cell options",
+			src: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 3))
+				mustFill(c, 'X')
+				mustSetCell(c, image.Point{1, 1}, 'A', cell.FgColor(cell.ColorRed))
+				mustSetCell(c, image.Point{2, 1}, 'B', cell.BgColor(cell.ColorBlue))
+				return c
+			}(),
+			dst:      mustNew(image.Rect(0, 0, 3, 3)),
+			srcAr:    image.Rect(1, 1, 3, 2),
+			dstPoint: image.Point{0, 2},
+			want: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 3))
+				mustSetCell(c, image.Point{0, 2}, 'A', cell.FgColor(cell.ColorRed))
+				mustSetCell(c, image.Point{1, 2}, 'B', cell.BgColor(cell.ColorBlue))
+				return c
+			}(),
+		},
+		{
+			desc: "ignores the offsets of the canvases",
+			src: func() *Canvas {
+				c := mustNew(image.Rect(5, 5, 7, 7))
+				mustSetCell(c, image.Point{1, 1}, 'A')
+				return c
+			}(),
+			dst:      mustNew(image.Rect(0, 0, 3, 3)),
+			srcAr:    image.Rect(1, 1, 2, 2),
+			dstPoint: image.Point{2, 2},
+			want: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 3))
+				mustSetCell(c, image.Point{2, 2}, 'A')
+				return c
+			}(),
+		},
+		{
+			desc: "copies full-width runes that fit the region",
+			src: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 1))
+				mustSetCell(c, image.Point{0, 0}, '世')
+				mustSetCell(c, image.Point{2, 0}, 'A')
+				return c
+			}(),
+			dst:   mustNew(image.Rect(0, 0, 3, 1)),
+			srcAr: image.Rect(0, 0, 3, 1),
+			want: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 1))
+				mustSetCell(c, image.Point{0, 0}, '世')
+				mustSetCell(c, image.Point{2, 0}, 'A')
+				return c
+			}(),
+		},
+		{
+			desc: "skips full-width runes cut by the region",
+			src: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 4, 1))
+				mustSetCell(c, image.Point{0, 0}, '世')
+				mustSetCell(c, image.Point{2, 0}, '界')
+				return c
+			}(),
+			dst:   mustNew(image.Rect(0, 0, 4, 1)),
+			srcAr: image.Rect(1, 0, 3, 1),
+			want:  mustNew(image.Rect(0, 0, 4, 1)),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.src.CopyRegion(tc.dst, tc.srcAr, tc.dstPoint)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("CopyRegion => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			ftSize := image.Point{10, 10}
+			got, err := faketerm.New(ftSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := tc.dst.Apply(got); err != nil {
+				t.Fatalf("tc.dst.Apply => unexpected error: %v", err)
+			}
+
+			want, err := faketerm.New(ftSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := tc.want.Apply(want); err != nil {
+				t.Fatalf("tc.want.Apply => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("CopyRegion => %v", diff)
+			}
+		})
+	}
+}
+
+func TestMergeTransparent(t *testing.T) {
+	tests := []struct {
+		desc        string
+		src         *Canvas
+		dst         *Canvas
+		transparent rune
+		want        *Canvas
+		wantErr     bool
+	}{
+		{
+			desc:    "fails when the canvas doesn't fit",
+			src:     mustNew(image.Rect(0, 0, 3, 3)),
+			dst:     mustNew(image.Rect(0, 0, 2, 2)),
+			want:    mustNew(image.Rect(0, 0, 2, 2)),
+			wantErr: true,
+		},
+		{
+			desc: "skips cells with the transparent rune",
+			src: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 1))
+				mustFill(c, ' ')
+				mustSetCell(c, image.Point{1, 0}, 'A', cell.FgColor(cell.ColorRed))
+				return c
+			}(),
+			dst: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 1))
+				mustFill(c, 'X')
+				return c
+			}(),
+			transparent: ' ',
+			want: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 1))
+				mustSetCell(c, image.Point{0, 0}, 'X')
+				mustSetCell(c, image.Point{1, 0}, 'A', cell.FgColor(cell.ColorRed))
+				mustSetCell(c, image.Point{2, 0}, 'X')
+				return c
+			}(),
+		},
+		{
+			desc: "the zero rune skips cells that were never set",
+			src: func() *Canvas {
+				c := mustNew(image.Rect(1, 0, 3, 1))
+				mustSetCell(c, image.Point{1, 0}, ' ', cell.BgColor(cell.ColorBlue))
+				return c
+			}(),
+			dst: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 1))
+				mustFill(c, 'X')
+				return c
+			}(),
+			want: func() *Canvas {
+				c := mustNew(image.Rect(0, 0, 3, 1))
+				mustSetCell(c, image.Point{0, 0}, 'X')
+				mustSetCell(c, image.Point{1, 0}, 'X')
+				mustSetCell(c, image.Point{2, 0}, ' ', cell.BgColor(cell.ColorBlue))
+				return c
+			}(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.src.MergeTransparent(tc.dst, tc.transparent)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("MergeTransparent => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			ftSize := image.Point{10, 10}
+			got, err := faketerm.New(ftSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := tc.dst.Apply(got); err != nil {
+				t.Fatalf("tc.dst.Apply => unexpected error: %v", err)
+			}
+
+			want, err := faketerm.New(ftSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := tc.want.Apply(want); err != nil {
+				t.Fatalf("tc.want.Apply => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("MergeTransparent => %v", diff)
+			}
+		})
+	}
+}
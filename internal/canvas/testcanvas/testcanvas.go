@@ -59,6 +59,13 @@ func MustSetAreaCells(c *canvas.Canvas, cellArea image.Rectangle, r rune, opts .
 	}
 }
 
+// MustSetAreaCellOpts sets the cell options in the area or panics.
+func MustSetAreaCellOpts(c *canvas.Canvas, cellArea image.Rectangle, opts ...cell.Option) {
+	if err := c.SetAreaCellOpts(cellArea, opts...); err != nil {
+		panic(fmt.Sprintf("canvas.SetAreaCellOpts => unexpected error: %v", err))
+	}
+}
+
 // MustCell returns the cell or panics.
 func MustCell(c *canvas.Canvas, p image.Point) *buffer.Cell {
 	cell, err := c.Cell(p)
@@ -59,6 +59,7 @@ type BrailleLineOption interface {
 type brailleLineOptions struct {
 	cellOpts    []cell.Option
 	pixelChange braillePixelChange
+	dash        int
 }
 
 // newBrailleLineOptions returns a new brailleLineOptions instance.
@@ -94,6 +95,16 @@ func BrailleLineClearPixels() BrailleLineOption {
 	})
 }
 
+// BrailleLineDashed changes the behavior of BrailleLine, so that it draws a
+// dashed line. The dashes and the gaps between them are both the specified
+// number of pixels long, starting with a dash at the start point.
+// The dash length must be a positive integer.
+func BrailleLineDashed(dash int) BrailleLineOption {
+	return brailleLineOption(func(opts *brailleLineOptions) {
+		opts.dash = dash
+	})
+}
+
 // BrailleLine draws an approximated line segment on the braille canvas between
 // the two provided points.
 // Both start and end must be valid points within the canvas. Start and end can
@@ -112,9 +123,15 @@ func BrailleLine(bc *braille.Canvas, start, end image.Point, opts ...BrailleLine
 	for _, o := range opts {
 		o.set(opt)
 	}
+	if opt.dash < 0 {
+		return fmt.Errorf("invalid BrailleLineDashed(%d), the dash length must be a positive integer", opt.dash)
+	}
 
 	points := brailleLinePoints(start, end)
-	for _, p := range points {
+	for i, p := range points {
+		if opt.dash > 0 && (i/opt.dash)%2 == 1 {
+			continue // A gap between the dashes.
+		}
 		switch opt.pixelChange {
 		case braillePixelChangeSet:
 			if err := bc.SetPixel(p, opt.cellOpts...); err != nil {
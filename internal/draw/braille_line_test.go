@@ -412,6 +412,35 @@ func TestBrailleLine(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "fails on negative dash length",
+			canvas: image.Rect(0, 0, 3, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{5, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDashed(-1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws a dashed line",
+			canvas: image.Rect(0, 0, 3, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{5, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDashed(2),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustSetPixel(bc, image.Point{1, 0})
+				testbraille.MustSetPixel(bc, image.Point{4, 0})
+				testbraille.MustSetPixel(bc, image.Point{5, 0})
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,136 @@
+// Copyright 2026 The termdash Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draw
+
+// circle.go contains code that draws circles and arcs on a cell canvas.
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/numbers/trig"
+	"github.com/mum4k/termdash/internal/runewidth"
+)
+
+// CircleOption is used to provide options to Circle and Arc.
+type CircleOption interface {
+	// set sets the provided option.
+	set(*circleOptions)
+}
+
+// circleOptions stores the provided options.
+type circleOptions struct {
+	cellOpts []cell.Option
+	rune     rune
+}
+
+// newCircleOptions returns a new circleOptions instance.
+func newCircleOptions() *circleOptions {
+	return &circleOptions{
+		rune: DefaultCircleRune,
+	}
+}
+
+// validate validates the provided options.
+func (opts *circleOptions) validate() error {
+	if got, want := runewidth.RuneWidth(opts.rune), 1; got != want {
+		return fmt.Errorf("invalid CircleRune %q, the rune must occupy exactly %d cell, got %d", opts.rune, want, got)
+	}
+	return nil
+}
+
+// circleOption implements CircleOption.
+type circleOption func(*circleOptions)
+
+// set implements CircleOption.set.
+func (o circleOption) set(opts *circleOptions) {
+	o(opts)
+}
+
+// DefaultCircleRune is the default value for the CircleRune option.
+const DefaultCircleRune = '•'
+
+// CircleRune sets the rune used to plot the cells of the circle or arc.
+// The rune must occupy exactly one cell.
+// Defaults to DefaultCircleRune.
+func CircleRune(r rune) CircleOption {
+	return circleOption(func(opts *circleOptions) {
+		opts.rune = r
+	})
+}
+
+// CircleCellOpts sets options on the cells that contain the circle or arc.
+func CircleCellOpts(cOpts ...cell.Option) CircleOption {
+	return circleOption(func(opts *circleOptions) {
+		opts.cellOpts = cOpts
+	})
+}
+
+// Circle draws a circle with the specified center and radius using the
+// midpoint circle algorithm. The circle is clipped to the canvas, i.e. the
+// parts of the circle that fall outside of the canvas aren't drawn and the
+// center itself can be outside of the canvas.
+// The smallest valid radius is one.
+//
+// Since terminal cells are usually taller than they are wide, the drawn circle
+// appears stretched vertically. Use BrailleCircle for a higher resolution.
+func Circle(cvs *canvas.Canvas, center image.Point, radius int, opts ...CircleOption) error {
+	return Arc(cvs, center, radius, 0, trig.MaxAngle, opts...)
+}
+
+// Arc draws a portion of a circle with the specified center and radius
+// between the two provided angles in degrees, using the midpoint circle
+// algorithm. The arc is clipped to the canvas the same way as in Circle.
+// Each angle must be in range 0 <= angle <= 360. Start and end must not be
+// equal. The zero angle is on the X axis, angles grow counter-clockwise.
+// The smallest valid radius is one.
+func Arc(cvs *canvas.Canvas, center image.Point, radius int, startDegree, endDegree int, opts ...CircleOption) error {
+	if min := 1; radius < min {
+		return fmt.Errorf("unable to draw circle with radius %d, must be in range %d <= radius", radius, min)
+	}
+	if startDegree == endDegree {
+		return fmt.Errorf("invalid degree range, start %d and end %d cannot be equal", startDegree, endDegree)
+	}
+
+	opt := newCircleOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return err
+	}
+
+	// Filtering by angle requires non-negative coordinates, calculate the
+	// points around a local center and move them to the requested one.
+	local := image.Point{radius, radius}
+	points, err := trig.FilterByAngle(circlePoints(local, radius), local, startDegree, endDegree)
+	if err != nil {
+		return err
+	}
+
+	ar := cvs.Area()
+	for _, p := range points {
+		p = p.Sub(local).Add(center)
+		if !p.In(ar) {
+			continue
+		}
+		if _, err := cvs.SetCell(p, opt.rune, opt.cellOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
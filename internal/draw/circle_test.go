@@ -0,0 +1,282 @@
+// Copyright 2026 The termdash Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draw
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/faketerm"
+)
+
+// mustSetCells sets the rune on all the provided cells.
+func mustSetCells(cvs *canvas.Canvas, r rune, cells []image.Point, opts ...cell.Option) {
+	for _, p := range cells {
+		testcanvas.MustSetCell(cvs, p, r, opts...)
+	}
+}
+
+func TestArc(t *testing.T) {
+	// Cells of a circle with radius two and center at (2, 2).
+	circleR2 := []image.Point{
+		{1, 0}, {2, 0}, {3, 0},
+		{0, 1}, {4, 1},
+		{0, 2}, {4, 2},
+		{0, 3}, {4, 3},
+		{1, 4}, {2, 4}, {3, 4},
+	}
+
+	tests := []struct {
+		desc        string
+		canvas      image.Rectangle
+		center      image.Point
+		radius      int
+		startDegree int
+		endDegree   int
+		opts        []CircleOption
+		want        func(size image.Point) *faketerm.Terminal
+		wantErr     bool
+	}{
+		{
+			desc:        "fails on radius too small",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      0,
+			startDegree: 0,
+			endDegree:   360,
+			wantErr:     true,
+		},
+		{
+			desc:        "fails on equal start and end degree",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: 90,
+			endDegree:   90,
+			wantErr:     true,
+		},
+		{
+			desc:        "fails on start degree out of range",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: -1,
+			endDegree:   90,
+			wantErr:     true,
+		},
+		{
+			desc:        "fails on end degree out of range",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: 0,
+			endDegree:   361,
+			wantErr:     true,
+		},
+		{
+			desc:        "fails on full-width rune",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: 0,
+			endDegree:   360,
+			opts: []CircleOption{
+				CircleRune('世'),
+			},
+			wantErr: true,
+		},
+		{
+			desc:        "draws the smallest circle",
+			canvas:      image.Rect(0, 0, 3, 3),
+			center:      image.Point{1, 1},
+			radius:      1,
+			startDegree: 0,
+			endDegree:   360,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustSetCells(c, DefaultCircleRune, []image.Point{
+					{0, 0}, {1, 0}, {2, 0},
+					{0, 1}, {2, 1},
+					{0, 2}, {1, 2}, {2, 2},
+				})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:        "draws a full circle",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: 0,
+			endDegree:   360,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustSetCells(c, DefaultCircleRune, circleR2)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:        "draws a circle with custom rune and cell options",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: 0,
+			endDegree:   360,
+			opts: []CircleOption{
+				CircleRune('o'),
+				CircleCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustSetCells(c, 'o', circleR2, cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:        "clips the circle to the canvas",
+			canvas:      image.Rect(0, 0, 3, 3),
+			center:      image.Point{0, 0},
+			radius:      2,
+			startDegree: 0,
+			endDegree:   360,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustSetCells(c, DefaultCircleRune, []image.Point{
+					{2, 0}, {2, 1}, {1, 2}, {0, 2},
+				})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:        "clips the circle with center outside of the canvas",
+			canvas:      image.Rect(0, 0, 5, 3),
+			center:      image.Point{2, -1},
+			radius:      2,
+			startDegree: 0,
+			endDegree:   360,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustSetCells(c, DefaultCircleRune, []image.Point{
+					{0, 0}, {4, 0},
+					{1, 1}, {2, 1}, {3, 1},
+				})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:        "draws the top right quarter",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: 0,
+			endDegree:   90,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustSetCells(c, DefaultCircleRune, []image.Point{
+					{2, 0}, {3, 0},
+					{4, 1},
+					{4, 2},
+				})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:        "draws an arc crossing the zero angle",
+			canvas:      image.Rect(0, 0, 5, 5),
+			center:      image.Point{2, 2},
+			radius:      2,
+			startDegree: 270,
+			endDegree:   90,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustSetCells(c, DefaultCircleRune, []image.Point{
+					{2, 0}, {3, 0},
+					{4, 1},
+					{4, 2},
+					{4, 3},
+					{2, 4}, {3, 4},
+				})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+
+			err = Arc(c, tc.center, tc.radius, tc.startDegree, tc.endDegree, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Arc => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Arc => %v", diff)
+			}
+		})
+	}
+}
+
+func TestCircle(t *testing.T) {
+	c := testcanvas.MustNew(image.Rect(0, 0, 5, 5))
+	if err := Circle(c, image.Point{2, 2}, 2); err != nil {
+		t.Fatalf("Circle => unexpected error: %v", err)
+	}
+	got := faketerm.MustNew(c.Size())
+	testcanvas.MustApply(c, got)
+
+	want := faketerm.MustNew(c.Size())
+	wantCvs := testcanvas.MustNew(want.Area())
+	if err := Arc(wantCvs, image.Point{2, 2}, 2, 0, 360); err != nil {
+		t.Fatalf("Arc => unexpected error: %v", err)
+	}
+	testcanvas.MustApply(wantCvs, want)
+
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("Circle => %v", diff)
+	}
+}
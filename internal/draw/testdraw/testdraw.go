@@ -33,7 +33,7 @@ func MustBorder(c *canvas.Canvas, border image.Rectangle, opts ...draw.BorderOpt
 
 // MustText draws the text on the canvas or panics.
 func MustText(c *canvas.Canvas, text string, start image.Point, opts ...draw.TextOption) {
-	if err := draw.Text(c, text, start, opts...); err != nil {
+	if _, err := draw.Text(c, text, start, opts...); err != nil {
 		panic(fmt.Sprintf("draw.Text => unexpected error: %v", err))
 	}
 }
@@ -149,10 +149,14 @@ func TrimText(text string, maxCells int, om OverrunMode) (string, error) {
 }
 
 // Text prints the provided text on the canvas starting at the provided point.
-func Text(c *canvas.Canvas, text string, start image.Point, opts ...TextOption) error {
+// The text is trimmed according to the TextOverrunMode option if it doesn't
+// fit, full-width runes are never cut in half.
+// Returns the number of cells the drawn text occupies, so that callers can
+// position any following content.
+func Text(c *canvas.Canvas, text string, start image.Point, opts ...TextOption) (int, error) {
 	ar := c.Area()
 	if !start.In(ar) {
-		return fmt.Errorf("the requested start point %v falls outside of the provided canvas %v", start, ar)
+		return 0, fmt.Errorf("the requested start point %v falls outside of the provided canvas %v", start, ar)
 	}
 
 	opt := &textOptions{}
@@ -161,7 +165,7 @@ func Text(c *canvas.Canvas, text string, start image.Point, opts ...TextOption)
 	}
 
 	if opt.maxX < 0 || opt.maxX > ar.Max.X {
-		return fmt.Errorf("invalid TextMaxX(%v), must be a positive number that is <= canvas.width %v", opt.maxX, ar.Dx())
+		return 0, fmt.Errorf("invalid TextMaxX(%v), must be a positive number that is <= canvas.width %v", opt.maxX, ar.Dx())
 	}
 
 	var wantMaxX int
@@ -174,22 +178,23 @@ func Text(c *canvas.Canvas, text string, start image.Point, opts ...TextOption)
 	maxCells := wantMaxX - start.X
 	trimmed, err := TrimText(text, maxCells, opt.overrunMode)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	cur := start
 	for _, r := range trimmed {
 		cells, err := c.SetCell(cur, r, opt.cellOpts...)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		cur = image.Point{cur.X + cells, cur.Y}
 	}
-	return nil
+	return cur.X - start.X, nil
 }
 
 // ResizeNeeded draws an unicode character indicating that the canvas size is
 // too small to draw meaningful content.
 func ResizeNeeded(cvs *canvas.Canvas) error {
-	return Text(cvs, "⇄", image.Point{0, 0})
+	_, err := Text(cvs, "⇄", image.Point{0, 0})
+	return err
 }
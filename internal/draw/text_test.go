@@ -578,7 +578,7 @@ func TestText(t *testing.T) {
 				t.Fatalf("canvas.New => unexpected error: %v", err)
 			}
 
-			err = Text(c, tc.text, tc.start, tc.opts...)
+			_, err = Text(c, tc.text, tc.start, tc.opts...)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("Text => unexpected error: %v, wantErr: %v", err, tc.wantErr)
 			}
@@ -654,3 +654,77 @@ func TestResizeNeeded(t *testing.T) {
 		})
 	}
 }
+
+func TestTextReturnsCells(t *testing.T) {
+	tests := []struct {
+		desc      string
+		text      string
+		start     image.Point
+		opts      []TextOption
+		wantCells int
+	}{
+		{
+			desc:      "empty text",
+			text:      "",
+			wantCells: 0,
+		},
+		{
+			desc:      "half-width runes",
+			text:      "abc",
+			start:     image.Point{1, 0},
+			wantCells: 3,
+		},
+		{
+			desc:      "full-width runes",
+			text:      "a世界",
+			wantCells: 5,
+		},
+		{
+			desc:  "trimmed text",
+			text:  "abcdefghijk",
+			start: image.Point{2, 0},
+			opts: []TextOption{
+				TextOverrunMode(OverrunModeTrim),
+			},
+			wantCells: 8,
+		},
+		{
+			desc: "full-width rune isn't cut in half when trimming",
+			text: "abcdefghi世",
+			opts: []TextOption{
+				TextOverrunMode(OverrunModeTrim),
+			},
+			wantCells: 9,
+		},
+		{
+			desc: "ellipsis replaces the full-width rune that doesn't fit",
+			text: "abcdefgh世世",
+			opts: []TextOption{
+				TextOverrunMode(OverrunModeThreeDot),
+			},
+			wantCells: 9,
+		},
+		{
+			desc: "trimmed to the maximum X coordinate",
+			text: "abcdefghijk",
+			opts: []TextOption{
+				TextMaxX(4),
+				TextOverrunMode(OverrunModeThreeDot),
+			},
+			wantCells: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := testcanvas.MustNew(image.Rect(0, 0, 10, 1))
+			got, err := Text(c, tc.text, tc.start, tc.opts...)
+			if err != nil {
+				t.Fatalf("Text => unexpected error: %v", err)
+			}
+			if got != tc.wantCells {
+				t.Errorf("Text => %d cells, want %d", got, tc.wantCells)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/runewidth"
 )
 
 // VerticalTextOption is used to provide options to Text().
@@ -36,6 +37,7 @@ type verticalTextOptions struct {
 	cellOpts    []cell.Option
 	maxY        int
 	overrunMode OverrunMode
+	rotate      bool
 }
 
 // verticalTextOption implements VerticalTextOption.
@@ -73,6 +75,59 @@ func VerticalTextOverrunMode(om OverrunMode) VerticalTextOption {
 	})
 }
 
+// VerticalTextRotate indicates that runes which have a rotated form should be
+// drawn in that form, e.g. the horizontal line '─' is drawn as '│' and the
+// ellipsis '…' that indicates trimmed text is drawn as '⋮'. Runes without a
+// rotated form are stacked as they are.
+// Runes are only replaced by rotated forms that occupy the same number of
+// cells.
+func VerticalTextRotate() VerticalTextOption {
+	return verticalTextOption(func(vtOpts *verticalTextOptions) {
+		vtOpts.rotate = true
+	})
+}
+
+// rotatedRunes maps runes to their forms rotated for vertical text.
+var rotatedRunes = map[rune]rune{
+	'-': '|',
+	'|': '-',
+	'─': '│',
+	'│': '─',
+	'━': '┃',
+	'┃': '━',
+	'═': '║',
+	'║': '═',
+	'…': '⋮',
+	'⋯': '⋮',
+	'，': '︐',
+	'、': '︑',
+	'。': '︒',
+	'：': '︓',
+	'；': '︔',
+	'！': '︕',
+	'？': '︖',
+	'（': '︵',
+	'）': '︶',
+	'｛': '︷',
+	'｝': '︸',
+	'【': '︻',
+	'】': '︼',
+	'「': '﹁',
+	'」': '﹂',
+	'『': '﹃',
+	'』': '﹄',
+}
+
+// rotateRune returns the rotated form of the rune if one exists and occupies
+// the same number of cells. Otherwise returns the rune unchanged.
+func rotateRune(r rune) rune {
+	rotated, ok := rotatedRunes[r]
+	if !ok || runewidth.RuneWidth(rotated) != runewidth.RuneWidth(r) {
+		return r
+	}
+	return rotated
+}
+
 // VerticalText prints the provided text on the canvas starting at the provided point.
 // The text is printed in a vertical orientation, i.e:
 //   H
@@ -110,6 +165,9 @@ func VerticalText(c *canvas.Canvas, text string, start image.Point, opts ...Vert
 
 	cur := start
 	for _, r := range trimmed {
+		if opt.rotate {
+			r = rotateRune(r)
+		}
 		cells, err := c.SetCell(cur, r, opt.cellOpts...)
 		if err != nil {
 			return err
@@ -387,6 +387,65 @@ func TestVerticalText(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "rotates runes that have a rotated form",
+			canvas: image.Rect(0, 0, 2, 10),
+			text:   "a-b─c（）",
+			start:  image.Point{0, 0},
+			opts: []VerticalTextOption{
+				VerticalTextRotate(),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, 'a')
+				testcanvas.MustSetCell(c, image.Point{0, 1}, '|')
+				testcanvas.MustSetCell(c, image.Point{0, 2}, 'b')
+				testcanvas.MustSetCell(c, image.Point{0, 3}, '│')
+				testcanvas.MustSetCell(c, image.Point{0, 4}, 'c')
+				testcanvas.MustSetCell(c, image.Point{0, 5}, '︵')
+				testcanvas.MustSetCell(c, image.Point{0, 7}, '︶')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "rotates the ellipsis that indicates trimmed text",
+			canvas: image.Rect(0, 0, 1, 3),
+			text:   "abcd",
+			start:  image.Point{0, 0},
+			opts: []VerticalTextOption{
+				VerticalTextRotate(),
+				VerticalTextOverrunMode(OverrunModeThreeDot),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, 'a')
+				testcanvas.MustSetCell(c, image.Point{0, 1}, 'b')
+				testcanvas.MustSetCell(c, image.Point{0, 2}, '⋮')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't rotate runes without the option",
+			canvas: image.Rect(0, 0, 1, 3),
+			text:   "a-─",
+			start:  image.Point{0, 0},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, 'a')
+				testcanvas.MustSetCell(c, image.Point{0, 1}, '-')
+				testcanvas.MustSetCell(c, image.Point{0, 2}, '─')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -34,11 +34,25 @@ type Callback func(terminalapi.Event)
 
 // queue is a queue of terminal events.
 type queue interface {
-	Push(e terminalapi.Event)
+	// TryPush pushes the event onto the queue and reports whether it was
+	// pushed or dropped.
+	TryPush(e terminalapi.Event) bool
 	Pull(ctx context.Context) terminalapi.Event
 	Close()
 }
 
+// unboundQueue adapts the unbound queue to the queue interface.
+type unboundQueue struct {
+	*eventqueue.Unbound
+}
+
+// TryPush implements queue.TryPush.
+// The unbound queue never drops events.
+func (u unboundQueue) TryPush(e terminalapi.Event) bool {
+	u.Push(e)
+	return true
+}
+
 // subscriber represents a single subscriber.
 type subscriber struct {
 	// cb is the callback the subscriber receives events on.
@@ -59,7 +73,14 @@ type subscriber struct {
 	// delivered to the callback.
 	processed int
 
-	// mu protects busy.
+	// pending is the number of events pushed onto the queue that weren't
+	// processed yet.
+	pending int
+
+	// idle is broadcast when pending drops to zero.
+	idle *sync.Cond
+
+	// mu protects processed and pending.
 	mu sync.Mutex
 }
 
@@ -75,7 +96,7 @@ func newSubscriber(filter []terminalapi.Event, cb Callback, opts *subscribeOptio
 	if opts.throttle {
 		q = eventqueue.NewThrottled(opts.maxRep)
 	} else {
-		q = eventqueue.New()
+		q = unboundQueue{eventqueue.New()}
 	}
 
 	s := &subscriber{
@@ -84,6 +105,7 @@ func newSubscriber(filter []terminalapi.Event, cb Callback, opts *subscribeOptio
 		queue:  q,
 		cancel: cancel,
 	}
+	s.idle = sync.NewCond(&s.mu)
 
 	// Terminates when stop() is called.
 	go s.run(ctx)
@@ -98,6 +120,7 @@ func (s *subscriber) callback(ev terminalapi.Event) {
 		s.mu.Lock()
 		defer s.mu.Unlock()
 		s.processed++
+		s.done()
 	}()
 }
 
@@ -118,15 +141,48 @@ func (s *subscriber) run(ctx context.Context) {
 	}
 }
 
-// event forwards an event to the subscriber.
-func (s *subscriber) event(ev terminalapi.Event) {
-	if len(s.filter) == 0 {
-		s.queue.Push(ev)
+// done marks one pending event as processed.
+// The caller must hold s.mu.
+func (s *subscriber) done() {
+	if s.pending > 0 {
+		s.pending--
+	}
+	if s.pending == 0 {
+		s.idle.Broadcast()
+	}
+}
+
+// push pushes the event onto the queue while tracking the pending events.
+func (s *subscriber) push(ev terminalapi.Event) {
+	s.mu.Lock()
+	s.pending++
+	s.mu.Unlock()
+
+	if !s.queue.TryPush(ev) {
+		s.mu.Lock()
+		s.done()
+		s.mu.Unlock()
+	}
+}
+
+// wantsEvent determines if the event passes the subscription filter.
+func (s *subscriber) wantsEvent(ev terminalapi.Event) bool {
+	return len(s.filter) == 0 || s.filter[reflect.TypeOf(ev)]
+}
+
+// waitIdle blocks until all the events pushed onto the queue were processed.
+func (s *subscriber) waitIdle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.pending > 0 {
+		s.idle.Wait()
 	}
+}
 
-	t := reflect.TypeOf(ev)
-	if s.filter[t] {
-		s.queue.Push(ev)
+// event forwards an event to the subscriber.
+func (s *subscriber) event(ev terminalapi.Event) {
+	if s.wantsEvent(ev) {
+		s.push(ev)
 	}
 }
 
@@ -141,6 +197,12 @@ func (s *subscriber) processedEvents() int {
 func (s *subscriber) stop() {
 	s.cancel()
 	s.queue.Close()
+
+	// Events remaining in the queue will never be processed.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = 0
+	s.idle.Broadcast()
 }
 
 // DistributionSystem distributes events to subscribers.
@@ -183,6 +245,31 @@ func (eds *DistributionSystem) Event(ev terminalapi.Event) {
 	}
 }
 
+// EventAndWait is like Event, but blocks until all the subscribers that
+// receive the event have processed it along with any of their previously
+// queued events.
+// Events dropped by throttled subscribers count as processed.
+func (eds *DistributionSystem) EventAndWait(ev terminalapi.Event) {
+	var subs []*subscriber
+	func() {
+		eds.mu.Lock()
+		defer eds.mu.Unlock()
+
+		for _, sub := range eds.subscribers {
+			if sub.wantsEvent(ev) {
+				sub.push(ev)
+				subs = append(subs, sub)
+			}
+		}
+	}()
+
+	// Waiting without holding the lock, the subscribers might send events
+	// to the distribution system while processing.
+	for _, sub := range subs {
+		sub.waitIdle()
+	}
+}
+
 // StopFunc when called unsubscribes the subscriber from all events and
 // releases resources tied to the subscriber.
 type StopFunc func()
@@ -423,3 +423,95 @@ func TestProcessed(t *testing.T) {
 		})
 	}
 }
+
+func TestEventAndWait(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		filter   []terminalapi.Event
+		maxRep   int
+		throttle bool
+		events   []terminalapi.Event
+		// want is the number of events received by the subscriber once
+		// EventAndWait returns.
+		want int
+	}{
+		{
+			desc: "subscriber with an empty filter receives all events",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{1, 1}},
+			},
+			want: 2,
+		},
+		{
+			desc: "waits until the subscriber processes the event",
+			filter: []terminalapi.Event{
+				&terminalapi.Keyboard{},
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: 1,
+		},
+		{
+			desc: "doesn't wait for subscribers that filter the event out",
+			filter: []terminalapi.Event{
+				&terminalapi.Mouse{},
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: 0,
+		},
+		{
+			desc: "repetitive events aren't throttled when each is processed",
+			filter: []terminalapi.Event{
+				&terminalapi.Keyboard{},
+			},
+			throttle: true,
+			maxRep:   0,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc := tc
+			t.Parallel()
+
+			eds := NewDistributionSystem()
+			var (
+				mu  sync.Mutex
+				got int
+			)
+			var opts []SubscribeOption
+			if tc.throttle {
+				opts = append(opts, MaxRepetitive(tc.maxRep))
+			}
+			stop := eds.Subscribe(tc.filter, func(terminalapi.Event) {
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				got++
+			}, opts...)
+			defer stop()
+
+			for _, ev := range tc.events {
+				eds.EventAndWait(ev)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if got != tc.want {
+				t.Errorf("EventAndWait => subscriber received %d events, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -191,12 +191,18 @@ func (t *Throttled) Empty() bool {
 
 // Push pushes an event onto the queue.
 func (t *Throttled) Push(e terminalapi.Event) {
+	t.TryPush(e)
+}
+
+// TryPush is like Push, but reports whether the event was pushed onto the
+// queue or dropped as repetitive.
+func (t *Throttled) TryPush(e terminalapi.Event) bool {
 	t.queue.mu.Lock()
 	defer t.queue.mu.Unlock()
 
 	if t.queue.empty() {
 		t.queue.push(e)
-		return
+		return true
 	}
 
 	var same int
@@ -208,10 +214,11 @@ func (t *Throttled) Push(e terminalapi.Event) {
 		}
 
 		if same > t.max {
-			return // Drop the repetitive event.
+			return false // Drop the repetitive event.
 		}
 	}
 	t.queue.push(e)
+	return true
 }
 
 // Pop pops an event from the queue. Returns nil if the queue is empty.
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"image"
 	"log"
+	"strings"
 	"sync"
 
 	"github.com/mum4k/termdash/cell"
@@ -52,6 +53,20 @@ func WithEventQueue(eq *eventqueue.Unbound) Option {
 	})
 }
 
+// DefaultColorMode is the default value for the ColorMode option.
+// Matches the color mode the real terminal implementations default to.
+const DefaultColorMode = terminalapi.ColorMode256
+
+// ColorMode sets the color mode of the fake terminal.
+// The fake terminal doesn't convert any colors, it only records the mode so
+// that tests can verify which mode was requested.
+// Defaults to DefaultColorMode.
+func ColorMode(cm terminalapi.ColorMode) Option {
+	return option(func(t *Terminal) {
+		t.colorMode = cm
+	})
+}
+
 // Terminal is a fake terminal.
 // This implementation is thread-safe.
 type Terminal struct {
@@ -61,6 +76,9 @@ type Terminal struct {
 	// events is a queue of input events.
 	events *eventqueue.Unbound
 
+	// colorMode is the color mode set with the ColorMode option.
+	colorMode terminalapi.ColorMode
+
 	// mu protects the buffer.
 	mu sync.Mutex
 }
@@ -73,7 +91,8 @@ func New(size image.Point, opts ...Option) (*Terminal, error) {
 	}
 
 	t := &Terminal{
-		buffer: b,
+		buffer:    b,
+		colorMode: DefaultColorMode,
 	}
 	for _, opt := range opts {
 		opt.set(t)
@@ -90,6 +109,11 @@ func MustNew(size image.Point, opts ...Option) *Terminal {
 	return ft
 }
 
+// ColorMode returns the color mode of the terminal.
+func (t *Terminal) ColorMode() terminalapi.ColorMode {
+	return t.colorMode
+}
+
 // Resize resizes the terminal to the provided size.
 // This also clears the internal buffer.
 func (t *Terminal) Resize(size image.Point) error {
@@ -137,6 +161,46 @@ func (t *Terminal) String() string {
 	return b.String()
 }
 
+// CellRune returns the rune in the cell at the specified point.
+// Returns the zero rune for empty cells and for cells that contain the
+// remaining part of a full-width rune.
+// Panics if the point falls outside of the terminal.
+func (t *Terminal) CellRune(p image.Point) rune {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ar := image.Rect(0, 0, t.buffer.Size().X, t.buffer.Size().Y); !p.In(ar) {
+		panic(fmt.Errorf("point %v falls outside of the terminal area %v", p, ar))
+	}
+	return t.buffer[p.X][p.Y].Rune
+}
+
+// CellsAsString returns the runes in the specified area of the terminal as
+// text with one line per row, the lines are joined with newline characters.
+// Empty cells are represented by the space rune and cells that contain the
+// remaining part of a full-width rune are skipped, so that a line reads the
+// same as the text that was drawn on it.
+// Cell options are ignored.
+// Panics if the area falls outside of the terminal.
+func (t *Terminal) CellsAsString(ar image.Rectangle) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tAr := t.area(); !ar.In(tAr) {
+		panic(fmt.Errorf("area %v falls outside of the terminal area %v", ar, tAr))
+	}
+
+	var lines []string
+	var b strings.Builder
+	t.visitCells(ar, func(r rune, _ *cell.Options) {
+		b.WriteRune(r)
+	}, func() {
+		lines = append(lines, b.String())
+		b.Reset()
+	})
+	return strings.Join(lines, "\n")
+}
+
 // Size implements terminalapi.Terminal.Size.
 func (t *Terminal) Size() image.Point {
 	t.mu.Lock()
@@ -0,0 +1,306 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faketerm
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+func TestCellsAsString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		size     image.Point
+		cells    map[image.Point]rune
+		ar       image.Rectangle
+		want     string
+		wantRune rune
+	}{
+		{
+			desc: "empty cells are spaces",
+			size: image.Point{3, 2},
+			ar:   image.Rect(0, 0, 3, 2),
+			want: "   \n   ",
+		},
+		{
+			desc: "returns text in the area",
+			size: image.Point{3, 2},
+			cells: map[image.Point]rune{
+				{0, 0}: 'a',
+				{1, 0}: 'b',
+				{1, 1}: 'c',
+				{2, 1}: 'd',
+			},
+			ar:       image.Rect(1, 0, 3, 2),
+			want:     "b \ncd",
+			wantRune: 'a',
+		},
+		{
+			desc: "skips the remaining part of full-width runes",
+			size: image.Point{4, 1},
+			cells: map[image.Point]rune{
+				{0, 0}: '世',
+				{2, 0}: 'a',
+			},
+			ar:       image.Rect(0, 0, 4, 1),
+			want:     "世a ",
+			wantRune: '世',
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := MustNew(tc.size)
+			for p, r := range tc.cells {
+				if err := ft.SetCell(p, r); err != nil {
+					t.Fatalf("SetCell => unexpected error: %v", err)
+				}
+			}
+			if err := ft.Flush(); err != nil {
+				t.Fatalf("Flush => unexpected error: %v", err)
+			}
+
+			if got := ft.CellsAsString(tc.ar); got != tc.want {
+				t.Errorf("CellsAsString => %q, want %q", got, tc.want)
+			}
+			if got := ft.CellRune(image.Point{0, 0}); got != tc.wantRune {
+				t.Errorf("CellRune => %q, want %q", got, tc.wantRune)
+			}
+		})
+	}
+}
+
+func TestCellsAsStringPanicsOutsideOfTerminal(t *testing.T) {
+	ft := MustNew(image.Point{2, 2})
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("CellsAsString => expected a panic")
+		}
+	}()
+	ft.CellsAsString(image.Rect(0, 0, 3, 2))
+}
+
+func TestColorMode(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want terminalapi.ColorMode
+	}{
+		{
+			desc: "defaults to DefaultColorMode",
+			want: DefaultColorMode,
+		},
+		{
+			desc: "records the provided color mode",
+			opts: []Option{
+				ColorMode(terminalapi.ColorModeGrayscale),
+			},
+			want: terminalapi.ColorModeGrayscale,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := MustNew(image.Point{1, 1}, tc.opts...)
+			if got := ft.ColorMode(); got != tc.want {
+				t.Errorf("ColorMode => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	tests := []struct {
+		desc       string
+		size       image.Point
+		cells      map[image.Point]rune
+		opts       map[image.Point][]cell.Option
+		want       string
+		wantStyled string
+	}{
+		{
+			desc: "empty terminal",
+			size: image.Point{3, 2},
+			want: "   \n   \n",
+			wantStyled: "   \n   \n" +
+				"styles:\n" +
+				"...\n" +
+				"...\n" +
+				"legend:\n",
+		},
+		{
+			desc: "runes and styles",
+			size: image.Point{4, 2},
+			cells: map[image.Point]rune{
+				{0, 0}: 'a',
+				{1, 0}: 'b',
+				{0, 1}: 'c',
+				{3, 1}: 'd',
+			},
+			opts: map[image.Point][]cell.Option{
+				{1, 0}: {cell.FgColor(cell.ColorRed)},
+				{0, 1}: {cell.BgColor(cell.ColorBlue), cell.Blink()},
+				{3, 1}: {cell.FgColor(cell.ColorRed)},
+			},
+			want: "ab  \nc  d\n",
+			wantStyled: "ab  \nc  d\n" +
+				"styles:\n" +
+				".a..\n" +
+				"b..a\n" +
+				"legend:\n" +
+				"a: FgColor:ColorRed BgColor:ColorDefault Blink:false\n" +
+				"b: FgColor:ColorDefault BgColor:ColorBlue Blink:true\n",
+		},
+		{
+			desc: "full-width runes occupy two columns",
+			size: image.Point{4, 1},
+			cells: map[image.Point]rune{
+				{0, 0}: '世',
+				{2, 0}: 'a',
+			},
+			want: "世a \n",
+			wantStyled: "世a \n" +
+				"styles:\n" +
+				"...\n" +
+				"legend:\n",
+		},
+		{
+			desc: "styles line up with the runes after full-width runes",
+			size: image.Point{5, 1},
+			cells: map[image.Point]rune{
+				{0, 0}: '世',
+				{2, 0}: 'a',
+				{3, 0}: 'b',
+			},
+			opts: map[image.Point][]cell.Option{
+				{0, 0}: {cell.FgColor(cell.ColorBlue)},
+				{3, 0}: {cell.FgColor(cell.ColorRed)},
+			},
+			want: "世ab \n",
+			wantStyled: "世ab \n" +
+				"styles:\n" +
+				"a.b.\n" +
+				"legend:\n" +
+				"a: FgColor:ColorBlue BgColor:ColorDefault Blink:false\n" +
+				"b: FgColor:ColorRed BgColor:ColorDefault Blink:false\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := MustNew(tc.size)
+			for p, r := range tc.cells {
+				if err := ft.SetCell(p, r, tc.opts[p]...); err != nil {
+					t.Fatalf("SetCell => unexpected error: %v", err)
+				}
+			}
+
+			var got bytes.Buffer
+			n, err := ft.WriteTo(&got)
+			if err != nil {
+				t.Fatalf("WriteTo => unexpected error: %v", err)
+			}
+			if n != int64(got.Len()) {
+				t.Errorf("WriteTo => reported %d bytes, wrote %d", n, got.Len())
+			}
+			if got.String() != tc.want {
+				t.Errorf("WriteTo => %q, want %q", got.String(), tc.want)
+			}
+
+			var gotStyled bytes.Buffer
+			if _, err := ft.WriteStyledTo(&gotStyled); err != nil {
+				t.Fatalf("WriteStyledTo => unexpected error: %v", err)
+			}
+			if gotStyled.String() != tc.wantStyled {
+				t.Errorf("WriteStyledTo => %q, want %q", gotStyled.String(), tc.wantStyled)
+			}
+		})
+	}
+}
+
+func TestANSIString(t *testing.T) {
+	tests := []struct {
+		desc  string
+		size  image.Point
+		cells map[image.Point]rune
+		opts  map[image.Point][]cell.Option
+		want  string
+	}{
+		{
+			desc: "empty terminal",
+			size: image.Point{3, 2},
+			want: "   \n   \n",
+		},
+		{
+			desc: "styles change only when the cell options change",
+			size: image.Point{4, 2},
+			cells: map[image.Point]rune{
+				{0, 0}: 'a',
+				{1, 0}: 'b',
+				{2, 0}: 'c',
+				{0, 1}: 'd',
+			},
+			opts: map[image.Point][]cell.Option{
+				{1, 0}: {cell.FgColor(cell.ColorRed)},
+				{2, 0}: {cell.FgColor(cell.ColorRed)},
+				{0, 1}: {cell.FgColor(cell.ColorNumber(200)), cell.BgColor(cell.ColorBlue), cell.Blink()},
+			},
+			want: "a\x1b[0;38;5;1mbc\x1b[0m \n" +
+				"\x1b[0;5;38;5;200;48;5;4md\x1b[0m   \n",
+		},
+		{
+			desc: "style is reset at the end of the row",
+			size: image.Point{2, 1},
+			cells: map[image.Point]rune{
+				{1, 0}: 'a',
+			},
+			opts: map[image.Point][]cell.Option{
+				{1, 0}: {cell.BgColor(cell.ColorGreen)},
+			},
+			want: " \x1b[0;48;5;2ma\x1b[0m\n",
+		},
+		{
+			desc: "full-width runes are emitted once",
+			size: image.Point{4, 1},
+			cells: map[image.Point]rune{
+				{0, 0}: '世',
+				{2, 0}: 'a',
+			},
+			opts: map[image.Point][]cell.Option{
+				{0, 0}: {cell.FgColor(cell.ColorRed)},
+			},
+			want: "\x1b[0;38;5;1m世\x1b[0ma \n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := MustNew(tc.size)
+			for p, r := range tc.cells {
+				if err := ft.SetCell(p, r, tc.opts[p]...); err != nil {
+					t.Fatalf("SetCell => unexpected error: %v", err)
+				}
+			}
+
+			if got := ft.ANSIString(); got != tc.want {
+				t.Errorf("ANSIString => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The termdash Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faketerm
+
+// input.go contains helpers that inject input events into the fake terminal.
+
+import (
+	"errors"
+	"image"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Push pushes the events into the queue provided via the WithEventQueue
+// option. The events are returned by Event in the order they were pushed, so
+// they reach the dashboard through the same code path as events from the real
+// terminals.
+// Returns an error if the terminal was created without an event queue.
+func (t *Terminal) Push(events ...terminalapi.Event) error {
+	if t.events == nil {
+		return errors.New("no event queue provided, use the WithEventQueue option when creating the fake terminal")
+	}
+	for _, ev := range events {
+		t.events.Push(ev)
+	}
+	return nil
+}
+
+// PushKeys pushes a keyboard event for each of the provided keys.
+func (t *Terminal) PushKeys(keys ...keyboard.Key) error {
+	var events []terminalapi.Event
+	for _, k := range keys {
+		events = append(events, &terminalapi.Keyboard{Key: k})
+	}
+	return t.Push(events...)
+}
+
+// PushText pushes a keyboard event for each rune of the text, simulating the
+// user typing it.
+func (t *Terminal) PushText(text string) error {
+	var keys []keyboard.Key
+	for _, r := range text {
+		keys = append(keys, keyboard.Key(r))
+	}
+	return t.PushKeys(keys...)
+}
+
+// PushClick pushes a press of the mouse button followed by its release at the
+// provided position, simulating the user clicking.
+func (t *Terminal) PushClick(p image.Point, b mouse.Button) error {
+	return t.Push(
+		&terminalapi.Mouse{Position: p, Button: b},
+		&terminalapi.Mouse{Position: p, Button: mouse.ButtonRelease},
+	)
+}
+
+// PushResize pushes an event that resizes the terminal to the provided size.
+// The terminal is resized when the event is returned by Event.
+func (t *Terminal) PushResize(size image.Point) error {
+	return t.Push(&terminalapi.Resize{Size: size})
+}
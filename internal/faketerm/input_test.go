@@ -0,0 +1,131 @@
+// Copyright 2026 The termdash Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faketerm
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/event/eventqueue"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+func TestPush(t *testing.T) {
+	tests := []struct {
+		desc     string
+		noQueue  bool
+		push     func(*Terminal) error
+		want     []terminalapi.Event
+		wantSize image.Point
+		wantErr  bool
+	}{
+		{
+			desc:    "fails without an event queue",
+			noQueue: true,
+			push: func(ft *Terminal) error {
+				return ft.PushKeys(keyboard.KeyEnter)
+			},
+			wantSize: image.Point{3, 3},
+			wantErr:  true,
+		},
+		{
+			desc: "pushes events",
+			push: func(ft *Terminal) error {
+				return ft.Push(
+					&terminalapi.Keyboard{Key: 'a'},
+					&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				)
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+			},
+			wantSize: image.Point{3, 3},
+		},
+		{
+			desc: "pushes keys and text",
+			push: func(ft *Terminal) error {
+				if err := ft.PushText("hi"); err != nil {
+					return err
+				}
+				return ft.PushKeys(keyboard.KeyEnter, keyboard.KeyEsc)
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'h'},
+				&terminalapi.Keyboard{Key: 'i'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+			},
+			wantSize: image.Point{3, 3},
+		},
+		{
+			desc: "pushes a click",
+			push: func(ft *Terminal) error {
+				return ft.PushClick(image.Point{2, 1}, mouse.ButtonRight)
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{2, 1}, Button: mouse.ButtonRight},
+				&terminalapi.Mouse{Position: image.Point{2, 1}, Button: mouse.ButtonRelease},
+			},
+			wantSize: image.Point{3, 3},
+		},
+		{
+			desc: "resizes the terminal when the resize event is returned",
+			push: func(ft *Terminal) error {
+				return ft.PushResize(image.Point{5, 2})
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{5, 2}},
+			},
+			wantSize: image.Point{5, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var opts []Option
+			if !tc.noQueue {
+				opts = append(opts, WithEventQueue(eventqueue.New()))
+			}
+			ft := MustNew(image.Point{3, 3}, opts...)
+
+			err := tc.push(ft)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("push => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []terminalapi.Event
+			for range tc.want {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				got = append(got, ft.Event(ctx))
+				cancel()
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Event => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if gotSize := ft.Size(); gotSize != tc.wantSize {
+				t.Errorf("Size => %v, want %v", gotSize, tc.wantSize)
+			}
+		})
+	}
+}
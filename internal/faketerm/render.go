@@ -0,0 +1,189 @@
+// Copyright 2026 The termdash Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faketerm
+
+// render.go renders the content of the fake terminal for golden file tests
+// and visual debugging.
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"reflect"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// WriteTo writes the runes of the terminal to the writer with one line per
+// row, each line is terminated by a newline character. Empty cells are
+// represented by the space rune and cells that contain the remaining part of
+// a full-width rune are skipped, so that the full-width rune visually occupies
+// the same columns as on the terminal.
+// Cell options are ignored, see WriteStyledTo.
+// Implements io.WriterTo.
+func (t *Terminal) WriteTo(w io.Writer) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b bytes.Buffer
+	t.writeRunes(&b)
+	return b.WriteTo(w)
+}
+
+// WriteStyledTo is like WriteTo, but additionally writes the styles of the
+// cells. The runes are followed by a map of styles with one character per cell
+// and a legend that lists the cell options of each style. Cells with the
+// default options are marked with '.' in the map, other styles are assigned
+// characters in the order of their first appearance. Like the runes, the map
+// skips cells that contain the remaining part of a full-width rune, so that
+// the styles line up with the runes.
+func (t *Terminal) WriteStyledTo(w io.Writer) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b bytes.Buffer
+	t.writeRunes(&b)
+
+	var styles []*cell.Options
+	b.WriteString("styles:\n")
+	t.visitCells(t.area(), func(_ rune, opts *cell.Options) {
+		b.WriteRune(styleKey(opts, &styles))
+	}, func() {
+		b.WriteRune('\n')
+	})
+
+	b.WriteString("legend:\n")
+	for i, s := range styles {
+		fmt.Fprintf(&b, "%c: FgColor:%v BgColor:%v Blink:%v\n", styleKeys[i], s.FgColor, s.BgColor, s.Blink)
+	}
+	return b.WriteTo(w)
+}
+
+// ANSIString returns the content of the terminal as a string with ANSI escape
+// sequences that reproduce the foreground and background colors and the
+// attributes of the cells when printed to a real terminal. Each row is
+// terminated by a newline character and the style is reset at the end of each
+// row. Cells that contain the remaining part of a full-width rune are skipped.
+// Colors are emitted as 256 color sequences, see terminalapi.ColorMode256.
+func (t *Terminal) ANSIString() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b bytes.Buffer
+	cur := cell.NewOptions()
+	t.visitCells(t.area(), func(r rune, opts *cell.Options) {
+		if opts == nil {
+			opts = cell.NewOptions()
+		}
+		if !reflect.DeepEqual(opts, cur) {
+			b.WriteString(sgr(opts))
+			cur = opts
+		}
+		b.WriteRune(r)
+	}, func() {
+		if !reflect.DeepEqual(cur, cell.NewOptions()) {
+			b.WriteString(sgr(cell.NewOptions()))
+			cur = cell.NewOptions()
+		}
+		b.WriteRune('\n')
+	})
+	return b.String()
+}
+
+// sgr returns the ANSI "Select Graphic Rendition" escape sequence that resets
+// the current style and applies the provided cell options.
+func sgr(opts *cell.Options) string {
+	var b bytes.Buffer
+	b.WriteString("\x1b[0")
+	if opts.Blink {
+		b.WriteString(";5")
+	}
+	// Colors are off-by-one due to cell.ColorDefault being zero.
+	if opts.FgColor != cell.ColorDefault {
+		fmt.Fprintf(&b, ";38;5;%d", opts.FgColor-1)
+	}
+	if opts.BgColor != cell.ColorDefault {
+		fmt.Fprintf(&b, ";48;5;%d", opts.BgColor-1)
+	}
+	b.WriteString("m")
+	return b.String()
+}
+
+// area returns the area of the terminal.
+// The caller must hold the lock.
+func (t *Terminal) area() image.Rectangle {
+	size := t.buffer.Size()
+	return image.Rect(0, 0, size.X, size.Y)
+}
+
+// visitCells walks the cells in the area row by row and calls cellFn for each
+// cell, with empty cells represented by the space rune. Cells that contain the
+// remaining part of a full-width rune are skipped. Calls rowEndFn after the
+// last cell of each row.
+// The caller must hold the lock.
+func (t *Terminal) visitCells(ar image.Rectangle, cellFn func(r rune, opts *cell.Options), rowEndFn func()) {
+	for row := ar.Min.Y; row < ar.Max.Y; row++ {
+		for col := ar.Min.X; col < ar.Max.X; col++ {
+			p := image.Point{col, row}
+			partial, err := t.buffer.IsPartial(p)
+			if err != nil {
+				panic(fmt.Errorf("unable to determine if point %v is a partial rune: %v", p, err))
+			}
+			if partial {
+				continue
+			}
+			c := t.buffer[col][row]
+			r := c.Rune
+			if r == 0 {
+				r = ' '
+			}
+			cellFn(r, c.Opts)
+		}
+		rowEndFn()
+	}
+}
+
+// writeRunes writes the runes of the terminal into the buffer.
+// The caller must hold the lock.
+func (t *Terminal) writeRunes(b *bytes.Buffer) {
+	t.visitCells(t.area(), func(r rune, _ *cell.Options) {
+		b.WriteRune(r)
+	}, func() {
+		b.WriteRune('\n')
+	})
+}
+
+// styleKeys are the characters that identify styles in the map of styles.
+var styleKeys = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+// styleKey returns the character that identifies the cell options in the map
+// of styles, appending the options to the styles if they weren't seen before.
+// Returns '?' if there are more styles than characters to identify them.
+func styleKey(opts *cell.Options, styles *[]*cell.Options) rune {
+	if opts == nil || reflect.DeepEqual(opts, cell.NewOptions()) {
+		return '.'
+	}
+	for i, s := range *styles {
+		if reflect.DeepEqual(s, opts) {
+			return styleKeys[i]
+		}
+	}
+	if len(*styles) >= len(styleKeys) {
+		return '?'
+	}
+	*styles = append(*styles, opts)
+	return styleKeys[len(*styles)-1]
+}
@@ -18,6 +18,15 @@ package runewidth
 
 import runewidth "github.com/mattn/go-runewidth"
 
+// zeroWidthJoiner joins the runes around it into a single emoji.
+const zeroWidthJoiner = '\u200d'
+
+// Emoji modifiers (skin tones) alter the preceding emoji.
+const (
+	emojiModifierFirst = '\U0001F3FB'
+	emojiModifierLast  = '\U0001F3FF'
+)
+
 // RuneWidth returns the number of cells needed to draw r.
 // Background in http://www.unicode.org/reports/tr11/.
 //
@@ -46,6 +55,31 @@ func StringWidth(s string) int {
 	return width
 }
 
+// ExtendsCluster returns true if the rune r that follows the rune prev in a
+// text belongs to the same grapheme cluster as prev, i.e. it modifies how
+// prev is displayed instead of occupying any cells on its own. Use zero for
+// prev if r is the first rune of the text.
+//
+// This is a simplified approximation of the grapheme cluster boundaries
+// defined in http://www.unicode.org/reports/tr29/. It recognizes zero-width
+// runes like combining marks, variation selectors and the zero width joiner,
+// runes that follow the zero width joiner and emoji modifiers. Newline
+// characters are never part of a cluster.
+func ExtendsCluster(prev, r rune) bool {
+	switch {
+	case r == '\n':
+		return false
+	case RuneWidth(r) == 0:
+		return true
+	case prev == zeroWidthJoiner:
+		return true
+	case prev != 0 && prev != '\n' && r >= emojiModifierFirst && r <= emojiModifierLast:
+		return true
+	default:
+		return false
+	}
+}
+
 // inTable determines if the rune falls within the table.
 // Copied from github.com/mattn/go-runewidth/blob/master/runewidth.go.
 func inTable(r rune, t table) bool {
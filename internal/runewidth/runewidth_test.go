@@ -164,3 +164,75 @@ func TestStringWidth(t *testing.T) {
 		})
 	}
 }
+
+func TestExtendsCluster(t *testing.T) {
+	tests := []struct {
+		desc string
+		prev rune
+		r    rune
+		want bool
+	}{
+		{
+			desc: "regular rune",
+			prev: 'a',
+			r:    'b',
+			want: false,
+		},
+		{
+			desc: "newline",
+			prev: 'a',
+			r:    '\n',
+			want: false,
+		},
+		{
+			desc: "combining accent",
+			prev: 'e',
+			r:    '\u0301',
+			want: true,
+		},
+		{
+			desc: "combining accent at the start of the text",
+			prev: 0,
+			r:    '\u0301',
+			want: true,
+		},
+		{
+			desc: "variation selector",
+			prev: '❤',
+			r:    '\ufe0f',
+			want: true,
+		},
+		{
+			desc: "zero width joiner",
+			prev: '👨',
+			r:    '\u200d',
+			want: true,
+		},
+		{
+			desc: "rune after a zero width joiner",
+			prev: '\u200d',
+			r:    '👩',
+			want: true,
+		},
+		{
+			desc: "emoji modifier",
+			prev: '👍',
+			r:    '🏽',
+			want: true,
+		},
+		{
+			desc: "emoji modifier at the start of a line",
+			prev: '\n',
+			r:    '🏽',
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := ExtendsCluster(tc.prev, tc.r); got != tc.want {
+				t.Errorf("ExtendsCluster(%q, %q) => %v, want %v", tc.prev, tc.r, got, tc.want)
+			}
+		})
+	}
+}
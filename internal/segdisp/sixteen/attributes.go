@@ -56,7 +56,12 @@ var diaSegType = map[Segment]segment.DiagonalType{
 func segmentSize(ar image.Rectangle) int {
 	// widthPerc is the relative width of a segment to the width of the canvas.
 	const widthPerc = 9
-	s := int(numbers.Round(float64(ar.Dx()) * widthPerc / 100))
+	return oddSegmentSize(int(numbers.Round(float64(ar.Dx()) * widthPerc / 100)))
+}
+
+// oddSegmentSize adjusts the size of segments to an odd number of pixels if
+// that improves their look.
+func oddSegmentSize(s int) int {
 	if s > 3 && s%2 == 0 {
 		// Segments with odd number of pixels in their width/height look
 		// better, since the spike at the top of their slopes has only one
@@ -113,10 +118,31 @@ type attributes struct {
 }
 
 // newAttributes calculates attributes needed to place the segments for the
-// provided pixel area.
-func newAttributes(bcAr image.Rectangle) *attributes {
-	segSize := segmentSize(bcAr)
+// provided pixel area. The thicknessPerc is the thickness of the segments as
+// a percentage of their default size. If segments thicker than the default
+// don't fit the area, their thickness is reduced until they do, down to the
+// default size.
+func newAttributes(bcAr image.Rectangle, thicknessPerc int) *attributes {
+	def := segmentSize(bcAr)
+	want := int(numbers.Round(float64(def) * float64(thicknessPerc) / 100))
+	if want < 1 {
+		want = 1
+	}
+	if want < def {
+		return attributesForSize(bcAr, want)
+	}
 
+	for size := want; size > def; size-- {
+		if a := attributesForSize(bcAr, oddSegmentSize(size)); a.fits() {
+			return a
+		}
+	}
+	return attributesForSize(bcAr, def)
+}
+
+// attributesForSize calculates attributes needed to place the segments of
+// the specified size for the provided pixel area.
+func attributesForSize(bcAr image.Rectangle, segSize int) *attributes {
 	// diaPerc is the size of the diaGap in percentage of the segment's size.
 	const diaPerc = 40
 	// Ensure there is at least one pixel diagonally between segments so they
@@ -179,6 +205,23 @@ func newAttributes(bcAr image.Rectangle) *attributes {
 	}
 }
 
+// fits determines if the segments fit the area without overlapping, i.e. the
+// horizontal and vertical segments are at least twice as long as they are
+// thick, so that their slopes don't merge, and the diagonal segments have
+// enough space between them.
+func (a *attributes) fits() bool {
+	if a.shortLen < 2*a.segSize || a.longLen < 2*a.segSize {
+		return false
+	}
+	for _, s := range []Segment{H, K, N, L} {
+		ar := a.diaSegArea(s)
+		if ar.Dx() < a.segSize || ar.Dy() < a.segSize {
+			return false
+		}
+	}
+	return true
+}
+
 // hvSegArea returns the area for the specified horizontal or vertical segment.
 func (a *attributes) hvSegArea(s Segment) image.Rectangle {
 	var (
@@ -122,6 +122,25 @@ const (
 	segmentMax // Used for validation.
 )
 
+// SegmentMask is a bit mask of segments. Each segment is represented by one
+// bit, the segment is on when its bit is set. The bits are assigned to
+// segments as follows, starting with the least significant bit:
+//
+//    bit:      0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15
+//    segment: A1  A2   B   C  D1  D2   E   F  G1  G2   H   J   K   L   M   N
+//
+// E.g. the mask 0x000c represents segments B and C.
+type SegmentMask uint16
+
+// Mask returns the mask that has only the bit of this segment set.
+// Returns an empty mask for an unknown segment.
+func (s Segment) Mask() SegmentMask {
+	if s <= segmentUnknown || s >= segmentMax {
+		return 0
+	}
+	return 1 << uint(s-1)
+}
+
 // characterSegments maps characters that can be displayed on their segments.
 // See doc/16-Segment-ASCII-All.jpg and:
 // https://www.partsnotincluded.com/electronics/segmented-led-display-ascii-library
@@ -261,6 +280,23 @@ func Sanitize(s string) string {
 	return b.String()
 }
 
+// CharacterMask returns the mask of the segments that are needed to display
+// the provided character.
+// Returns false if the display doesn't support the character, see
+// SupportsChars().
+func CharacterMask(c rune) (SegmentMask, bool) {
+	seg, ok := characterSegments[c]
+	if !ok {
+		return 0, false
+	}
+
+	var mask SegmentMask
+	for _, s := range seg {
+		mask |= s.Mask()
+	}
+	return mask, true
+}
+
 // AllSegments returns all 16 segments in an undefined order.
 func AllSegments() []Segment {
 	var res []Segment
@@ -291,22 +327,58 @@ func CellOpts(cOpts ...cell.Option) Option {
 	})
 }
 
+// UnlitCellOpts makes the display draw the segments that are off using the
+// provided cell options, e.g. in a dim color, which resembles a real segment
+// display. The segments that are on are drawn over them using the options
+// provided to CellOpts.
+// Since each cell holds a single set of cell options, a cell that contains
+// pixels of both lit and unlit segments is drawn with the options of the lit
+// segments.
+// By default the segments that are off aren't drawn.
+func UnlitCellOpts(cOpts ...cell.Option) Option {
+	return option(func(d *Display) {
+		d.showUnlit = true
+		d.unlitCellOpts = cOpts
+	})
+}
+
+// DefaultThicknessPercent is the default value for the ThicknessPercent
+// option.
+const DefaultThicknessPercent = 100
+
+// ThicknessPercent sets the thickness of the segments as a percentage of
+// their default thickness, which is proportional to the size of the display.
+// Thicker segments are easier to read on large displays. Segments are always
+// at least one pixel thick.
+// If segments thicker than the default would overlap in the area the display
+// is drawn in, their thickness is reduced until they don't, down to the
+// default thickness. Values smaller than one are ignored.
+func ThicknessPercent(perc int) Option {
+	return option(func(d *Display) {
+		if perc >= 1 {
+			d.thicknessPerc = perc
+		}
+	})
+}
+
 // Display represents the segment display.
 // This object is not thread-safe.
 type Display struct {
-	// segments maps segments to their current status.
-	segments map[Segment]bool
+	// segments has the bits of the segments that are on set.
+	segments SegmentMask
 
-	cellOpts []cell.Option
+	cellOpts      []cell.Option
+	thicknessPerc int
+	showUnlit     bool
+	unlitCellOpts []cell.Option
 }
 
 // New creates a new segment display.
 // Initially all the segments are off.
 func New(opts ...Option) *Display {
 	d := &Display{
-		segments: map[Segment]bool{},
+		thicknessPerc: DefaultThicknessPercent,
 	}
-
 	for _, opt := range opts {
 		opt.set(d)
 	}
@@ -319,7 +391,7 @@ func (d *Display) Clear(opts ...Option) {
 		opt.set(d)
 	}
 
-	d.segments = map[Segment]bool{}
+	d.segments = 0
 }
 
 // SetSegment sets the specified segment on.
@@ -328,7 +400,7 @@ func (d *Display) SetSegment(s Segment) error {
 	if s <= segmentUnknown || s >= segmentMax {
 		return fmt.Errorf("unknown segment %v(%d)", s, s)
 	}
-	d.segments[s] = true
+	d.segments |= s.Mask()
 	return nil
 }
 
@@ -338,7 +410,7 @@ func (d *Display) ClearSegment(s Segment) error {
 	if s <= segmentUnknown || s >= segmentMax {
 		return fmt.Errorf("unknown segment %v(%d)", s, s)
 	}
-	d.segments[s] = false
+	d.segments &^= s.Mask()
 	return nil
 }
 
@@ -348,30 +420,27 @@ func (d *Display) ToggleSegment(s Segment) error {
 	if s <= segmentUnknown || s >= segmentMax {
 		return fmt.Errorf("unknown segment %v(%d)", s, s)
 	}
-	if d.segments[s] {
-		d.segments[s] = false
-	} else {
-		d.segments[s] = true
-	}
+	d.segments ^= s.Mask()
 	return nil
 }
 
+// SetSegments sets the segments whose bits are set in the mask on and all the
+// other segments off. Allows to display arbitrary combinations of segments.
+func (d *Display) SetSegments(mask SegmentMask) {
+	d.segments = mask
+}
+
 // SetCharacter sets all the segments that are needed to display the provided
 // character.
 // The display only supports a subset of ASCII characters, use SupportsChars()
 // or Sanitize() to ensure the provided character is supported.
 // Doesn't clear the display of segments set previously.
 func (d *Display) SetCharacter(c rune) error {
-	seg, ok := characterSegments[c]
+	mask, ok := CharacterMask(c)
 	if !ok {
 		return fmt.Errorf("display doesn't support character %q rune(%v)", c, c)
 	}
-
-	for _, s := range seg {
-		if err := d.SetSegment(s); err != nil {
-			return err
-		}
-	}
+	d.SetSegments(d.segments | mask)
 	return nil
 }
 
@@ -400,10 +469,37 @@ func (d *Display) Draw(cvs *canvas.Canvas, opts ...Option) error {
 		return err
 	}
 
-	attr := newAttributes(bcAr)
+	attr := newAttributes(bcAr, d.thicknessPerc)
+	litOpts := d.cellOpts
+	if d.showUnlit {
+		var unlit SegmentMask
+		for _, s := range AllSegments() {
+			unlit |= s.Mask()
+		}
+		unlit &^= d.segments
+		if err := drawSegments(bc, attr, unlit, d.unlitCellOpts); err != nil {
+			return err
+		}
+		if len(litOpts) == 0 {
+			// Reset the options on cells that are shared with unlit segments.
+			litOpts = []cell.Option{
+				cell.FgColor(cell.ColorDefault),
+				cell.BgColor(cell.ColorDefault),
+			}
+		}
+	}
+	if err := drawSegments(bc, attr, d.segments, litOpts); err != nil {
+		return err
+	}
+	return bc.CopyTo(cvs)
+}
+
+// drawSegments draws the segments whose bits are set in the mask onto the
+// braille canvas using the provided cell options.
+func drawSegments(bc *braille.Canvas, attr *attributes, mask SegmentMask, cellOpts []cell.Option) error {
 	var sOpts []segment.Option
-	if len(d.cellOpts) > 0 {
-		sOpts = append(sOpts, segment.CellOpts(d.cellOpts...))
+	if len(cellOpts) > 0 {
+		sOpts = append(sOpts, segment.CellOpts(cellOpts...))
 	}
 	for _, segArg := range []struct {
 		s    Segment
@@ -426,7 +522,7 @@ func (d *Display) Draw(cvs *canvas.Canvas, opts ...Option) error {
 		{D1, []segment.Option{segment.ReverseSlopes()}},
 		{D2, []segment.Option{segment.ReverseSlopes()}},
 	} {
-		if !d.segments[segArg.s] {
+		if mask&segArg.s.Mask() == 0 {
 			continue
 		}
 		sOpts := append(sOpts, segArg.opts...)
@@ -437,11 +533,11 @@ func (d *Display) Draw(cvs *canvas.Canvas, opts ...Option) error {
 	}
 
 	var dsOpts []segment.DiagonalOption
-	if len(d.cellOpts) > 0 {
-		dsOpts = append(dsOpts, segment.DiagonalCellOpts(d.cellOpts...))
+	if len(cellOpts) > 0 {
+		dsOpts = append(dsOpts, segment.DiagonalCellOpts(cellOpts...))
 	}
 	for _, seg := range []Segment{H, K, N, L} {
-		if !d.segments[seg] {
+		if mask&seg.Mask() == 0 {
 			continue
 		}
 		ar := attr.diaSegArea(seg)
@@ -449,7 +545,7 @@ func (d *Display) Draw(cvs *canvas.Canvas, opts ...Option) error {
 			return fmt.Errorf("failed to draw segment %v, segment.Diagonal => %v", seg, err)
 		}
 	}
-	return bc.CopyTo(cvs)
+	return nil
 }
 
 // Required when given an area of cells, returns either an area of the same
@@ -808,6 +808,47 @@ func TestDraw(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "smallest valid display 6x5, draws unlit segments with their cell options",
+			opts: []Option{
+				CellOpts(cell.FgColor(cell.ColorRed)),
+				UnlitCellOpts(cell.FgColor(cell.ColorBlue)),
+			},
+			cellCanvas: image.Rect(0, 0, MinCols, MinRows),
+			update: func(d *Display) error {
+				return d.SetSegment(A1)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				uOpts := []cell.Option{cell.FgColor(cell.ColorBlue)}
+				testsegment.MustHV(bc, image.Rect(5, 0, 8, 1), segment.Horizontal, segment.CellOpts(uOpts...)) // A2
+
+				testsegment.MustHV(bc, image.Rect(0, 1, 1, 8), segment.Vertical, segment.CellOpts(uOpts...)) // F
+				testsegment.MustHV(bc, image.Rect(4, 1, 5, 8), segment.Vertical, segment.CellOpts(uOpts...)) // J
+				testsegment.MustHV(bc, image.Rect(8, 1, 9, 8), segment.Vertical, segment.CellOpts(uOpts...)) // B
+
+				testsegment.MustHV(bc, image.Rect(1, 8, 4, 9), segment.Horizontal, segment.CellOpts(uOpts...)) // G1
+				testsegment.MustHV(bc, image.Rect(5, 8, 8, 9), segment.Horizontal, segment.CellOpts(uOpts...)) // G2
+
+				testsegment.MustHV(bc, image.Rect(0, 9, 1, 16), segment.Vertical, segment.CellOpts(uOpts...)) // E
+				testsegment.MustHV(bc, image.Rect(4, 9, 5, 16), segment.Vertical, segment.CellOpts(uOpts...)) // M
+				testsegment.MustHV(bc, image.Rect(8, 9, 9, 16), segment.Vertical, segment.CellOpts(uOpts...)) // C
+
+				testsegment.MustHV(bc, image.Rect(1, 16, 4, 17), segment.Horizontal, segment.CellOpts(uOpts...)) // D1
+				testsegment.MustHV(bc, image.Rect(5, 16, 8, 17), segment.Horizontal, segment.CellOpts(uOpts...)) // D2
+
+				testsegment.MustDiagonal(bc, image.Rect(1, 1, 4, 8), 1, segment.LeftToRight, segment.DiagonalCellOpts(uOpts...))  // H
+				testsegment.MustDiagonal(bc, image.Rect(5, 1, 8, 8), 1, segment.RightToLeft, segment.DiagonalCellOpts(uOpts...))  // K
+				testsegment.MustDiagonal(bc, image.Rect(1, 9, 4, 16), 1, segment.RightToLeft, segment.DiagonalCellOpts(uOpts...)) // N
+				testsegment.MustDiagonal(bc, image.Rect(5, 9, 8, 16), 1, segment.LeftToRight, segment.DiagonalCellOpts(uOpts...)) // L
+
+				testsegment.MustHV(bc, image.Rect(1, 0, 4, 1), segment.Horizontal, segment.CellOpts(cell.FgColor(cell.ColorRed))) // A1
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1612,6 +1653,203 @@ func TestSetCharacter(t *testing.T) {
 	}
 }
 
+func TestSetSegments(t *testing.T) {
+	tests := []struct {
+		desc string
+		// If not nil, it is called before SetSegments is called.
+		update func(*Display) error
+		mask   SegmentMask
+		want   func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc: "empty mask",
+		},
+		{
+			desc: "sets the segments in the mask",
+			mask: A1.Mask() | G2.Mask() | N.Mask(),
+			want: func(size image.Point) *faketerm.Terminal {
+				return mustDrawSegments(size, A1, G2, N)
+			},
+		},
+		{
+			desc: "bit layout matches the documentation",
+			mask: 0x000c,
+			want: func(size image.Point) *faketerm.Terminal {
+				return mustDrawSegments(size, B, C)
+			},
+		},
+		{
+			desc: "clears segments not in the mask",
+			update: func(d *Display) error {
+				return d.SetCharacter('8')
+			},
+			mask: J.Mask() | M.Mask(),
+			want: func(size image.Point) *faketerm.Terminal {
+				return mustDrawSegments(size, J, M)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := New()
+			if tc.update != nil {
+				if err := tc.update(d); err != nil {
+					t.Fatalf("tc.update => unexpected error: %v", err)
+				}
+			}
+			d.SetSegments(tc.mask)
+
+			ar := image.Rect(0, 0, MinCols, MinRows)
+			cvs, err := canvas.New(ar)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := d.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			size := area.Size(ar)
+			want := faketerm.MustNew(size)
+			if tc.want != nil {
+				want = tc.want(size)
+			}
+
+			got, err := faketerm.New(size)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := cvs.Apply(got); err != nil {
+				t.Fatalf("bc.Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Fatalf("SetSegments => %v", diff)
+			}
+		})
+	}
+}
+
+func TestSegmentMask(t *testing.T) {
+	var all SegmentMask
+	for _, s := range AllSegments() {
+		m := s.Mask()
+		if all&m != 0 {
+			t.Errorf("%v.Mask() => %#04x, overlaps with the masks of other segments", s, m)
+		}
+		all |= m
+	}
+	if want := SegmentMask(0xffff); all != want {
+		t.Errorf("masks of all segments => %#04x, want %#04x", all, want)
+	}
+	if got := segmentUnknown.Mask(); got != 0 {
+		t.Errorf("segmentUnknown.Mask() => %#04x, want 0", got)
+	}
+}
+
+func TestCharacterMask(t *testing.T) {
+	tests := []struct {
+		desc   string
+		char   rune
+		want   SegmentMask
+		wantOk bool
+	}{
+		{
+			desc: "unsupported character",
+			char: '.',
+		},
+		{
+			desc:   "space has no segments",
+			char:   ' ',
+			wantOk: true,
+		},
+		{
+			desc:   "supported character",
+			char:   '1',
+			want:   B.Mask() | C.Mask() | K.Mask(),
+			wantOk: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotOk := CharacterMask(tc.char)
+			if got != tc.want || gotOk != tc.wantOk {
+				t.Errorf("CharacterMask(%q) => %#04x, %v, want %#04x, %v", tc.char, got, gotOk, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestThicknessPercent(t *testing.T) {
+	tests := []struct {
+		desc string
+		// cellAr is the area of cells the display is drawn in.
+		cellAr        image.Rectangle
+		thicknessPerc int
+		wantSegSize   int
+	}{
+		{
+			desc:          "default thickness",
+			cellAr:        image.Rect(0, 0, 36, 30),
+			thicknessPerc: DefaultThicknessPercent,
+			wantSegSize:   7,
+		},
+		{
+			desc:          "thinner segments",
+			cellAr:        image.Rect(0, 0, 36, 30),
+			thicknessPerc: 50,
+			wantSegSize:   4,
+		},
+		{
+			desc:          "thinner segments are at least one pixel thick",
+			cellAr:        image.Rect(0, 0, MinCols, MinRows),
+			thicknessPerc: 1,
+			wantSegSize:   1,
+		},
+		{
+			desc:          "thicker segments",
+			cellAr:        image.Rect(0, 0, 36, 30),
+			thicknessPerc: 150,
+			wantSegSize:   11,
+		},
+		{
+			desc:          "thickness is reduced so that segments don't overlap",
+			cellAr:        image.Rect(0, 0, 36, 30),
+			thicknessPerc: 300,
+			wantSegSize:   11,
+		},
+		{
+			desc:          "falls back to the default thickness on small displays",
+			cellAr:        image.Rect(0, 0, MinCols, MinRows),
+			thicknessPerc: 300,
+			wantSegSize:   1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cvs := testcanvas.MustNew(tc.cellAr)
+			_, bcAr, err := toBraille(cvs)
+			if err != nil {
+				t.Fatalf("toBraille => unexpected error: %v", err)
+			}
+
+			attr := newAttributes(bcAr, tc.thicknessPerc)
+			if attr.segSize != tc.wantSegSize {
+				t.Errorf("newAttributes => segSize %d, want %d", attr.segSize, tc.wantSegSize)
+			}
+
+			d := New(ThicknessPercent(tc.thicknessPerc))
+			if err := d.SetCharacter('8'); err != nil {
+				t.Fatalf("SetCharacter => unexpected error: %v", err)
+			}
+			if err := d.Draw(cvs); err != nil {
+				t.Errorf("Draw => unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestRequired(t *testing.T) {
 	tests := []struct {
 		desc     string
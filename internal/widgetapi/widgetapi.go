@@ -19,6 +19,7 @@ import (
 	"image"
 
 	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
 
@@ -115,7 +116,9 @@ type Options struct {
 	// MinimumSize allows a widget to specify the smallest allowed canvas size.
 	// If the terminal size and/or splits cause the assigned canvas to be
 	// smaller than this, the widget will be skipped. I.e. The Draw() method
-	// won't be called until a resize above the specified minimum.
+	// won't be called until a resize above the specified minimum. The
+	// container draws a placeholder indicating that a resize is needed
+	// instead, see container.TooSmallText.
 	MinimumSize image.Point
 
 	// MaximumSize allows a widget to specify the largest allowed canvas size.
@@ -125,11 +128,29 @@ type Options struct {
 	// unlimited.
 	MaximumSize image.Point
 
+	// PreferredSize allows a widget to advertise the natural size of its
+	// content, e.g. two lines for a status bar or the width of a clock.
+	// Unlike MinimumSize, this is only advisory. A container may use it to
+	// size a sub container whose size isn't otherwise constrained, but it is
+	// ignored by splits with an explicit ratio, i.e. SplitPercent and the
+	// equal parts of SplitVerticalN and SplitHorizontalN. The widget must
+	// still draw on a canvas of any size allowed by MinimumSize and
+	// MaximumSize. Setting any of the two coordinates to zero indicates no
+	// preference.
+	PreferredSize image.Point
+
 	// WantKeyboard allows a widget to request keyboard events and specify
 	// their desired scope. If set to KeyScopeNone, no keyboard events are
 	// forwarded to the widget.
 	WantKeyboard KeyScope
 
+	// KeyFilter allows a widget that requested keyboard events to limit the
+	// keys it receives. Only keys for which the function returns true are
+	// forwarded to the widget. Other keys are still delivered to the
+	// subscriber set by termdash.KeyboardSubscriber, so they can serve as
+	// global shortcuts. If nil, all keys within the scope are forwarded.
+	KeyFilter func(keyboard.Key) bool
+
 	// WantMouse allows a widget to request mouse events and specify their
 	// desired scope. If set to MouseScopeNone, no mouse events are forwarded
 	// to the widget.
@@ -175,3 +196,16 @@ type Widget interface {
 	// Draw.
 	Options() Options
 }
+
+// Notifier is an optional interface that can be implemented by widgets whose
+// content changes between redraws, e.g. widgets that stream or animate data.
+type Notifier interface {
+	// SetNotify is called by the infrastructure when the widget is placed into
+	// a container of a running dashboard. The widget should call the provided
+	// function each time its content changes to request a redraw of the
+	// terminal.
+	//
+	// The function is thread-safe and never blocks. Multiple calls made in a
+	// quick succession are collapsed into a single redraw.
+	SetNotify(fn func())
+}
@@ -0,0 +1,196 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keyseq recognizes sequences of keys pressed one after another, e.g.
+// "gg" in vim-style key bindings.
+//
+// The Matcher doesn't depend on the terminal, widgets can pass it the keys
+// they receive in their Keyboard method and it calls the registered functions
+// once a sequence completes.
+package keyseq
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Option is used to provide options to New.
+type Option interface {
+	// set sets the provided option.
+	set(*Matcher)
+}
+
+// option implements Option.
+type option func(*Matcher)
+
+// set implements Option.set.
+func (o option) set(m *Matcher) {
+	o(m)
+}
+
+// DefaultTimeout is the default value for the Timeout option.
+const DefaultTimeout = time.Second
+
+// Timeout sets the longest allowed duration between two consecutive keys of a
+// sequence. If the next key isn't pressed in time, the keys pressed so far are
+// forgotten and the next key starts over.
+// Defaults to DefaultTimeout.
+func Timeout(d time.Duration) Option {
+	return option(func(m *Matcher) {
+		m.timeout = d
+	})
+}
+
+// timeSince is a function that calculates duration since some time.
+// Replaced from tests.
+var timeSince = time.Since
+
+// sequence is a registered sequence of keys.
+type sequence struct {
+	keys []keyboard.Key
+	fn   func()
+}
+
+// Matcher accumulates keys and calls the registered functions when the keys
+// form one of the registered sequences.
+// This object is thread-safe.
+type Matcher struct {
+	// seqs are the registered sequences.
+	seqs []*sequence
+	// pending are the keys pressed so far that form a prefix of at least one
+	// of the sequences.
+	pending []keyboard.Key
+	// lastKey is the time when the last pending key was pressed.
+	lastKey time.Time
+
+	timeout time.Duration
+
+	// mu protects the Matcher.
+	mu sync.Mutex
+}
+
+// New returns a new Matcher with no registered sequences.
+func New(opts ...Option) (*Matcher, error) {
+	m := &Matcher{
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt.set(m)
+	}
+	if m.timeout <= 0 {
+		return nil, fmt.Errorf("invalid Timeout %v, must be a positive duration", m.timeout)
+	}
+	return m, nil
+}
+
+// Register registers a sequence of keys and the function that is called when
+// the sequence completes. The function is called synchronously from Key, so
+// it should return quickly.
+// Returns an error if the sequence is empty or if it is equal to or a prefix
+// of an already registered sequence or vice versa, since such sequences would
+// be ambiguous.
+func (m *Matcher) Register(keys []keyboard.Key, fn func()) error {
+	if len(keys) == 0 {
+		return errors.New("the sequence must contain at least one key")
+	}
+	if fn == nil {
+		return errors.New("the function must not be nil")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.seqs {
+		if hasPrefix(s.keys, keys) || hasPrefix(keys, s.keys) {
+			return fmt.Errorf("the sequence %v conflicts with the registered sequence %v, neither can be a prefix of the other", keys, s.keys)
+		}
+	}
+	m.seqs = append(m.seqs, &sequence{
+		keys: append([]keyboard.Key(nil), keys...),
+		fn:   fn,
+	})
+	return nil
+}
+
+// Key processes the next pressed key. Calls the function registered for a
+// sequence if the key completes it.
+// The keys pressed so far are forgotten if the key doesn't continue any of
+// the sequences or if it was pressed after the timeout, in which case the key
+// is considered as the start of a new sequence.
+// Returns true if the key was consumed, i.e. it is a part of a sequence.
+func (m *Matcher) Key(k keyboard.Key) bool {
+	fn, consumed := m.key(k)
+	if fn != nil {
+		fn()
+	}
+	return consumed
+}
+
+// key is the implementation of Key. Returns the function to call if a
+// sequence completed, it is called without holding the lock.
+func (m *Matcher) key(k keyboard.Key) (func(), bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.pending) > 0 && timeSince(m.lastKey) > m.timeout {
+		m.pending = nil
+	}
+
+	for _, pending := range [][]keyboard.Key{
+		append(m.pending, k),
+		{k}, // The key can start a new sequence after a mismatch.
+	} {
+		var prefix bool
+		for _, s := range m.seqs {
+			if !hasPrefix(s.keys, pending) {
+				continue
+			}
+			if len(s.keys) == len(pending) {
+				m.pending = nil
+				return s.fn, true
+			}
+			prefix = true
+		}
+		if prefix {
+			m.pending = pending
+			m.lastKey = time.Now()
+			return nil, true
+		}
+	}
+	m.pending = nil
+	return nil, false
+}
+
+// Reset forgets the keys pressed so far.
+func (m *Matcher) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = nil
+}
+
+// hasPrefix determines if the keys start with the prefix.
+func hasPrefix(keys, prefix []keyboard.Key) bool {
+	if len(prefix) > len(keys) {
+		return false
+	}
+	for i, k := range prefix {
+		if keys[i] != k {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyseq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// press is a key pressed after the specified duration since the previous key.
+type press struct {
+	key   keyboard.Key
+	after time.Duration
+}
+
+func TestMatcher(t *testing.T) {
+	tests := []struct {
+		desc         string
+		opts         []Option
+		seqs         map[string][]keyboard.Key
+		presses      []press
+		want         []string
+		wantConsumed []bool
+		wantNewErr   bool
+		wantRegErr   bool
+	}{
+		{
+			desc:       "fails on invalid timeout",
+			opts:       []Option{Timeout(0)},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on empty sequence",
+			seqs: map[string][]keyboard.Key{
+				"empty": nil,
+			},
+			wantRegErr: true,
+		},
+		{
+			desc: "fails on sequences that are prefixes of each other",
+			seqs: map[string][]keyboard.Key{
+				"g":  {'g'},
+				"gg": {'g', 'g'},
+			},
+			wantRegErr: true,
+		},
+		{
+			desc: "recognizes a sequence",
+			seqs: map[string][]keyboard.Key{
+				"gg": {'g', 'g'},
+			},
+			presses: []press{
+				{key: 'g'},
+				{key: 'g'},
+			},
+			want:         []string{"gg"},
+			wantConsumed: []bool{true, true},
+		},
+		{
+			desc: "recognizes one of multiple sequences",
+			seqs: map[string][]keyboard.Key{
+				"gg": {'g', 'g'},
+				"gt": {'g', 't'},
+				"dd": {'d', 'd'},
+			},
+			presses: []press{
+				{key: 'g'},
+				{key: 't'},
+				{key: 'd'},
+				{key: 'd'},
+			},
+			want:         []string{"gt", "dd"},
+			wantConsumed: []bool{true, true, true, true},
+		},
+		{
+			desc: "doesn't consume keys that aren't a part of any sequence",
+			seqs: map[string][]keyboard.Key{
+				"gg": {'g', 'g'},
+			},
+			presses: []press{
+				{key: 'x'},
+				{key: keyboard.KeyEnter},
+			},
+			wantConsumed: []bool{false, false},
+		},
+		{
+			desc: "mismatch resets and the key can start a new sequence",
+			seqs: map[string][]keyboard.Key{
+				"gg": {'g', 'g'},
+				"dd": {'d', 'd'},
+			},
+			presses: []press{
+				{key: 'g'},
+				{key: 'd'},
+				{key: 'd'},
+			},
+			want:         []string{"dd"},
+			wantConsumed: []bool{true, true, true},
+		},
+		{
+			desc: "mismatch resets the keys pressed so far",
+			seqs: map[string][]keyboard.Key{
+				"gg": {'g', 'g'},
+			},
+			presses: []press{
+				{key: 'g'},
+				{key: 'x'},
+				{key: 'g'},
+			},
+			wantConsumed: []bool{true, false, true},
+		},
+		{
+			desc: "timeout resets the keys pressed so far",
+			opts: []Option{Timeout(500 * time.Millisecond)},
+			seqs: map[string][]keyboard.Key{
+				"gg": {'g', 'g'},
+			},
+			presses: []press{
+				{key: 'g'},
+				{key: 'g', after: 501 * time.Millisecond},
+				{key: 'g', after: 500 * time.Millisecond},
+			},
+			want:         []string{"gg"},
+			wantConsumed: []bool{true, true, true},
+		},
+		{
+			desc: "single key sequence",
+			seqs: map[string][]keyboard.Key{
+				"esc": {keyboard.KeyEsc},
+			},
+			presses: []press{
+				{key: keyboard.KeyEsc},
+			},
+			want:         []string{"esc"},
+			wantConsumed: []bool{true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var elapsed time.Duration
+			timeSince = func(time.Time) time.Duration {
+				return elapsed
+			}
+			defer func() {
+				timeSince = time.Since
+			}()
+
+			m, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			var regErr error
+			for name, keys := range tc.seqs {
+				name := name
+				if err := m.Register(keys, func() {
+					got = append(got, name)
+				}); err != nil {
+					regErr = err
+				}
+			}
+			if (regErr != nil) != tc.wantRegErr {
+				t.Errorf("Register => unexpected error: %v, wantRegErr: %v", regErr, tc.wantRegErr)
+			}
+			if regErr != nil {
+				return
+			}
+
+			var gotConsumed []bool
+			for _, p := range tc.presses {
+				elapsed = p.after
+				gotConsumed = append(gotConsumed, m.Key(p.key))
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("called sequences => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantConsumed, gotConsumed); diff != "" {
+				t.Errorf("Key => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	m, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	var called bool
+	if err := m.Register([]keyboard.Key{'g', 'g'}, func() {
+		called = true
+	}); err != nil {
+		t.Fatalf("Register => unexpected error: %v", err)
+	}
+
+	m.Key('g')
+	m.Reset()
+	m.Key('g')
+	if called {
+		t.Errorf("Key => called the sequence after Reset, want not called")
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clicks counts consecutive clicks of mouse buttons, i.e. it detects
+// double and triple clicks.
+//
+// The Counter doesn't depend on the terminal, widgets can pass it the mouse
+// events they receive in their Mouse method.
+package clicks
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Option is used to provide options to New.
+type Option interface {
+	// set sets the provided option.
+	set(*Counter)
+}
+
+// option implements Option.
+type option func(*Counter)
+
+// set implements Option.set.
+func (o option) set(c *Counter) {
+	o(c)
+}
+
+// DefaultInterval is the default value for the Interval option.
+const DefaultInterval = 500 * time.Millisecond
+
+// Interval sets the longest allowed duration between two consecutive presses
+// of a button for them to count as one multi-click.
+// Defaults to DefaultInterval.
+func Interval(d time.Duration) Option {
+	return option(func(c *Counter) {
+		c.interval = d
+	})
+}
+
+// DefaultMaxDistance is the default value for the MaxDistance option.
+const DefaultMaxDistance = 0
+
+// MaxDistance sets the largest allowed distance in cells, both horizontally
+// and vertically, between the positions of two consecutive presses of a button
+// for them to count as one multi-click.
+// Defaults to DefaultMaxDistance, i.e. all the presses must happen on the
+// same cell.
+func MaxDistance(cells int) Option {
+	return option(func(c *Counter) {
+		c.maxDistance = cells
+	})
+}
+
+// timeSince is a function that calculates duration since some time.
+// Replaced from tests.
+var timeSince = time.Since
+
+// Counter counts consecutive clicks of the mouse buttons.
+// This object is thread-safe.
+type Counter struct {
+	// button is the button of the last press.
+	button mouse.Button
+	// pos is the position of the last press.
+	pos image.Point
+	// lastPress is the time of the last press.
+	lastPress time.Time
+	// count is the number of consecutive clicks so far.
+	count int
+	// held is true while a button is pressed and wasn't released yet.
+	held bool
+
+	interval    time.Duration
+	maxDistance int
+
+	// mu protects the Counter.
+	mu sync.Mutex
+}
+
+// New returns a new Counter.
+func New(opts ...Option) (*Counter, error) {
+	c := &Counter{
+		interval:    DefaultInterval,
+		maxDistance: DefaultMaxDistance,
+	}
+	for _, opt := range opts {
+		opt.set(c)
+	}
+	if c.interval <= 0 {
+		return nil, fmt.Errorf("invalid Interval %v, must be a positive duration", c.interval)
+	}
+	if c.maxDistance < 0 {
+		return nil, fmt.Errorf("invalid MaxDistance %d, must be zero or a positive integer", c.maxDistance)
+	}
+	return c, nil
+}
+
+// Count processes the next mouse event and returns the number of consecutive
+// clicks if the event is a press of a button, i.e. 1 for a single click, 2 for
+// a double click, 3 for a triple click and so on. A press counts as a
+// consecutive click if it is the same button as the previous press and it
+// happens within the Interval and MaxDistance of the previous press.
+// Returns zero for events that aren't new presses of a button, i.e. releases,
+// mouse wheel events and the events terminals report while a button is held,
+// e.g. when dragging.
+func (c *Counter) Count(m *terminalapi.Mouse) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch m.Button {
+	case mouse.ButtonLeft, mouse.ButtonRight, mouse.ButtonMiddle:
+	case mouse.ButtonRelease:
+		c.held = false
+		return 0
+	default:
+		return 0
+	}
+	if c.held {
+		return 0
+	}
+	c.held = true
+
+	if c.count > 0 && m.Button == c.button && c.near(m.Position) && timeSince(c.lastPress) <= c.interval {
+		c.count++
+	} else {
+		c.count = 1
+	}
+	c.button = m.Button
+	c.pos = m.Position
+	c.lastPress = time.Now()
+	return c.count
+}
+
+// Reset forgets the clicks counted so far.
+func (c *Counter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.count = 0
+	c.held = false
+}
+
+// near determines if the point is within the maximum distance from the
+// position of the last press.
+func (c *Counter) near(p image.Point) bool {
+	d := p.Sub(c.pos)
+	return abs(d.X) <= c.maxDistance && abs(d.Y) <= c.maxDistance
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
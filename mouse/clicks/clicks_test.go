@@ -0,0 +1,190 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clicks
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// event is a mouse event that happens after the specified duration since the
+// previous event.
+type event struct {
+	m     *terminalapi.Mouse
+	after time.Duration
+}
+
+// press returns an event that presses the button at the position.
+func press(b mouse.Button, x, y int, after time.Duration) event {
+	return event{
+		m:     &terminalapi.Mouse{Position: image.Point{x, y}, Button: b},
+		after: after,
+	}
+}
+
+// release returns an event that releases the button at the position.
+func release(x, y int) event {
+	return event{
+		m: &terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonRelease},
+	}
+}
+
+func TestCounter(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		events     []event
+		want       []int
+		wantNewErr bool
+	}{
+		{
+			desc:       "fails on invalid interval",
+			opts:       []Option{Interval(0)},
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on invalid max distance",
+			opts:       []Option{MaxDistance(-1)},
+			wantNewErr: true,
+		},
+		{
+			desc: "single click",
+			events: []event{
+				press(mouse.ButtonLeft, 1, 1, 0),
+				release(1, 1),
+			},
+			want: []int{1, 0},
+		},
+		{
+			desc: "double and triple click",
+			events: []event{
+				press(mouse.ButtonLeft, 1, 1, 0),
+				release(1, 1),
+				press(mouse.ButtonLeft, 1, 1, 100*time.Millisecond),
+				release(1, 1),
+				press(mouse.ButtonLeft, 1, 1, 100*time.Millisecond),
+				release(1, 1),
+			},
+			want: []int{1, 0, 2, 0, 3, 0},
+		},
+		{
+			desc: "events while the button is held aren't presses",
+			events: []event{
+				press(mouse.ButtonLeft, 1, 1, 0),
+				press(mouse.ButtonLeft, 2, 1, 0),
+				release(2, 1),
+			},
+			want: []int{1, 0, 0},
+		},
+		{
+			desc: "press after the interval starts over",
+			opts: []Option{Interval(200 * time.Millisecond)},
+			events: []event{
+				press(mouse.ButtonLeft, 1, 1, 0),
+				release(1, 1),
+				press(mouse.ButtonLeft, 1, 1, 201*time.Millisecond),
+				release(1, 1),
+				press(mouse.ButtonLeft, 1, 1, 200*time.Millisecond),
+			},
+			want: []int{1, 0, 1, 0, 2},
+		},
+		{
+			desc: "press at a different position starts over",
+			events: []event{
+				press(mouse.ButtonLeft, 1, 1, 0),
+				release(1, 1),
+				press(mouse.ButtonLeft, 2, 1, 0),
+			},
+			want: []int{1, 0, 1},
+		},
+		{
+			desc: "press within the max distance continues",
+			opts: []Option{MaxDistance(1)},
+			events: []event{
+				press(mouse.ButtonLeft, 1, 1, 0),
+				release(1, 1),
+				press(mouse.ButtonLeft, 2, 0, 0),
+				release(2, 0),
+				press(mouse.ButtonLeft, 4, 0, 0),
+			},
+			want: []int{1, 0, 2, 0, 1},
+		},
+		{
+			desc: "press of a different button starts over",
+			events: []event{
+				press(mouse.ButtonLeft, 1, 1, 0),
+				release(1, 1),
+				press(mouse.ButtonRight, 1, 1, 0),
+			},
+			want: []int{1, 0, 1},
+		},
+		{
+			desc: "ignores the mouse wheel",
+			events: []event{
+				press(mouse.ButtonWheelUp, 1, 1, 0),
+				press(mouse.ButtonWheelDown, 1, 1, 0),
+			},
+			want: []int{0, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var elapsed time.Duration
+			timeSince = func(time.Time) time.Duration {
+				return elapsed
+			}
+			defer func() {
+				timeSince = time.Since
+			}()
+
+			c, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []int
+			for _, ev := range tc.events {
+				elapsed = ev.after
+				got = append(got, c.Count(ev.m))
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Count => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	c.Count(&terminalapi.Mouse{Button: mouse.ButtonLeft})
+	c.Count(&terminalapi.Mouse{Button: mouse.ButtonRelease})
+	c.Reset()
+	if got, want := c.Count(&terminalapi.Mouse{Button: mouse.ButtonLeft}), 1; got != want {
+		t.Errorf("Count after Reset => %d, want %d", got, want)
+	}
+}
@@ -18,6 +18,7 @@ Package termdash implements a terminal based dashboard.
 While running, the terminal dashboard performs the following:
   - Periodic redrawing of the canvas and all the widgets.
   - Event based redrawing of the widgets (i.e. on Keyboard or Mouse events).
+  - Redrawing of widgets that notify about changes of their content.
   - Forwards input events to widgets and optional subscribers.
   - Handles terminal resize events.
 */
@@ -53,14 +54,40 @@ func (o option) set(td *termdash) {
 }
 
 // RedrawInterval sets how often termdash redraws the container and all the widgets.
-// Defaults to DefaultRedrawInterval. Use the controller to disable the
-// periodic redraw.
+// Defaults to DefaultRedrawInterval. A zero or negative interval disables
+// the periodic redraw, in which case the terminal is only redrawn on input
+// events and on requests received on the RedrawChannel. Use the controller
+// to take full control of redrawing.
 func RedrawInterval(t time.Duration) Option {
 	return option(func(td *termdash) {
 		td.redrawInterval = t
 	})
 }
 
+// RedrawChannel provides a channel that can be used to request an immediate
+// redraw of the container and all the widgets, e.g. by widgets that animate
+// or whose content changes between the periodic redraws.
+//
+// Each value received on the channel causes one redraw. On-demand redraws
+// happen in addition to the periodic redraws and don't reset the
+// RedrawInterval timer. To collapse bursts of requests into a single redraw,
+// use a channel with a buffer of one and send to it without blocking:
+//
+//	select {
+//	case ch <- struct{}{}:
+//	default: // A redraw is already pending.
+//	}
+//
+// Closing the channel stops the on-demand redraws, the dashboard keeps running
+// and redrawing according to the other options.
+// This option is ignored when using the Controller, which redraws only when
+// Redraw is called.
+func RedrawChannel(ch <-chan struct{}) Option {
+	return option(func(td *termdash) {
+		td.redrawCh = ch
+	})
+}
+
 // ErrorHandler is used to provide a function that will be called with all
 // errors that occur while the dashboard is running. If not provided, any
 // errors panic the application.
@@ -73,6 +100,8 @@ func ErrorHandler(f func(error)) Option {
 
 // KeyboardSubscriber registers a subscriber for Keyboard events. Each
 // keyboard event is forwarded to the container and the registered subscriber.
+// The order in which the subscriber and the widgets receive the events is
+// determined by the KeyboardSubscriberOrder option.
 // The provided function must be thread-safe.
 func KeyboardSubscriber(f func(*terminalapi.Keyboard)) Option {
 	return option(func(td *termdash) {
@@ -80,6 +109,52 @@ func KeyboardSubscriber(f func(*terminalapi.Keyboard)) Option {
 	})
 }
 
+// KeyboardOrder determines when the KeyboardSubscriber receives keyboard
+// events relative to the widgets.
+type KeyboardOrder int
+
+// String implements fmt.Stringer()
+func (ko KeyboardOrder) String() string {
+	if n, ok := keyboardOrderNames[ko]; ok {
+		return n
+	}
+	return "KeyboardOrderUnknown"
+}
+
+// keyboardOrderNames maps KeyboardOrder values to human readable names.
+var keyboardOrderNames = map[KeyboardOrder]string{
+	KeyboardOrderConcurrent: "KeyboardOrderConcurrent",
+	KeyboardOrderPreempt:    "KeyboardOrderPreempt",
+	KeyboardOrderFallback:   "KeyboardOrderFallback",
+}
+
+const (
+	// KeyboardOrderConcurrent delivers each keyboard event to the subscriber
+	// and the widgets concurrently, there is no guarantee which of them
+	// receives it first. This is the default.
+	KeyboardOrderConcurrent KeyboardOrder = iota
+
+	// KeyboardOrderPreempt delivers each keyboard event to the subscriber
+	// first. The event is forwarded to the widgets only after the subscriber
+	// returns. Useful for application level shortcuts that must take effect
+	// before the focused widget reacts to the key.
+	KeyboardOrderPreempt
+
+	// KeyboardOrderFallback delivers each keyboard event to the subscriber
+	// only after all the widgets that want keyboard events processed it.
+	KeyboardOrderFallback
+)
+
+// KeyboardSubscriberOrder sets the order in which the KeyboardSubscriber and
+// the widgets receive keyboard events. Defaults to KeyboardOrderConcurrent.
+// Note that with the ordered modes, a slow subscriber or a slow widget delays
+// the processing of the following input events.
+func KeyboardSubscriberOrder(ko KeyboardOrder) Option {
+	return option(func(td *termdash) {
+		td.keyboardOrder = ko
+	})
+}
+
 // MouseSubscriber registers a subscriber for Mouse events. Each mouse event
 // is forwarded to the container and the registered subscriber.
 // The provided function must be thread-safe.
@@ -114,6 +189,7 @@ type Controller struct {
 // NewController initializes termdash and returns an instance of the controller.
 // Periodic redrawing is disabled when using the controller, the RedrawInterval
 // option is ignored.
+// Widgets that notify about changes of their content aren't redrawn either.
 // Close the controller when it isn't needed anymore.
 func NewController(t terminalapi.Terminal, c *container.Container, opts ...Option) (*Controller, error) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -166,6 +242,11 @@ type termdash struct {
 	// exitCh gets closed when the event collecting goroutine actually exits.
 	exitCh chan struct{}
 
+	// notifyCh receives redraw requests from widgets that implement
+	// widgetapi.Notifier. Buffered so that bursts of requests collapse into a
+	// single redraw.
+	notifyCh chan struct{}
+
 	// clearNeeded indicates if the terminal needs to be cleared next time
 	// we're drawing it. Terminal needs to be cleared if its sized changed.
 	clearNeeded bool
@@ -175,9 +256,11 @@ type termdash struct {
 
 	// Options.
 	redrawInterval     time.Duration
+	redrawCh           <-chan struct{}
 	errorHandler       func(error)
 	mouseSubscriber    func(*terminalapi.Mouse)
 	keyboardSubscriber func(*terminalapi.Keyboard)
+	keyboardOrder      KeyboardOrder
 }
 
 // newTermdash creates a new termdash.
@@ -188,6 +271,7 @@ func newTermdash(t terminalapi.Terminal, c *container.Container, opts ...Option)
 		eds:            event.NewDistributionSystem(),
 		closeCh:        make(chan struct{}),
 		exitCh:         make(chan struct{}),
+		notifyCh:       make(chan struct{}, 1),
 		redrawInterval: DefaultRedrawInterval,
 	}
 
@@ -196,9 +280,19 @@ func newTermdash(t terminalapi.Terminal, c *container.Container, opts ...Option)
 	}
 	td.subscribers()
 	c.Subscribe(td.eds)
+	c.Notify(td.notify)
 	return td
 }
 
+// notify requests a redraw on behalf of a widget whose content changed.
+// Never blocks, the request is dropped if a redraw is already pending.
+func (td *termdash) notify() {
+	select {
+	case td.notifyCh <- struct{}{}:
+	default: // A redraw is already pending.
+	}
+}
+
 // subscribers subscribes event receivers that live in this package to EDS.
 func (td *termdash) subscribers() {
 	// Handler for all errors that occur during input event processing.
@@ -222,7 +316,9 @@ func (td *termdash) subscribers() {
 	}, event.MaxRepetitive(0)) // No repetitive events that cause terminal redraw.
 
 	// Keyboard and Mouse subscribers specified via options.
-	if td.keyboardSubscriber != nil {
+	// Ordered keyboard subscribers are called directly when dispatching the
+	// events.
+	if td.keyboardSubscriber != nil && td.keyboardOrder == KeyboardOrderConcurrent {
 		td.eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(ev terminalapi.Event) {
 			td.keyboardSubscriber(ev.(*terminalapi.Keyboard))
 		})
@@ -253,6 +349,8 @@ func (td *termdash) setClearNeeded() {
 }
 
 // redraw redraws the container and its widgets.
+// All the widgets are drawn into the terminal's back buffer and then flushed
+// once, so each redraw appears on the terminal as a single frame.
 // The caller must hold td.mu.
 func (td *termdash) redraw() error {
 	if td.clearNeeded {
@@ -285,13 +383,38 @@ func (td *termdash) evRedraw() error {
 	return td.redraw()
 }
 
-// periodicRedraw is called once each RedrawInterval.
+// periodicRedraw is called once each RedrawInterval, on each request
+// received on the RedrawChannel and when a widget notifies about a change of
+// its content.
 func (td *termdash) periodicRedraw() error {
 	td.mu.Lock()
 	defer td.mu.Unlock()
 	return td.redraw()
 }
 
+// dispatch forwards the event to the subscribers, calling the keyboard
+// subscriber in the configured order.
+func (td *termdash) dispatch(ev terminalapi.Event) {
+	k, ok := ev.(*terminalapi.Keyboard)
+	if !ok || td.keyboardSubscriber == nil {
+		td.eds.Event(ev)
+		return
+	}
+
+	switch td.keyboardOrder {
+	case KeyboardOrderPreempt:
+		td.keyboardSubscriber(k)
+		td.eds.Event(ev)
+
+	case KeyboardOrderFallback:
+		td.eds.EventAndWait(ev)
+		td.keyboardSubscriber(k)
+
+	default:
+		td.eds.Event(ev)
+	}
+}
+
 // processEvents processes terminal input events.
 // This is the body of the event collecting goroutine.
 func (td *termdash) processEvents(ctx context.Context) {
@@ -300,7 +423,7 @@ func (td *termdash) processEvents(ctx context.Context) {
 	for {
 		ev := td.term.Event(ctx)
 		if ev != nil {
-			td.eds.Event(ev)
+			td.dispatch(ev)
 		}
 
 		select {
@@ -314,8 +437,13 @@ func (td *termdash) processEvents(ctx context.Context) {
 // start starts the terminal dashboard. Blocks until the context expires or
 // until stop() is called.
 func (td *termdash) start(ctx context.Context) error {
-	redrawTimer := time.NewTicker(td.redrawInterval)
-	defer redrawTimer.Stop()
+	// A nil channel blocks forever, i.e. disables the periodic redraw.
+	var redrawTick <-chan time.Time
+	if td.redrawInterval > 0 {
+		redrawTimer := time.NewTicker(td.redrawInterval)
+		defer redrawTimer.Stop()
+		redrawTick = redrawTimer.C
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -325,7 +453,22 @@ func (td *termdash) start(ctx context.Context) error {
 
 	for {
 		select {
-		case <-redrawTimer.C:
+		case <-redrawTick:
+			if err := td.periodicRedraw(); err != nil {
+				return err
+			}
+
+		case _, ok := <-td.redrawCh:
+			if !ok {
+				// The channel was closed, stop receiving from it.
+				td.redrawCh = nil
+				continue
+			}
+			if err := td.periodicRedraw(); err != nil {
+				return err
+			}
+
+		case <-td.notifyCh:
 			if err := td.periodicRedraw(); err != nil {
 				return err
 			}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/event/eventqueue"
 	"github.com/mum4k/termdash/internal/event/testevent"
@@ -373,6 +374,40 @@ func TestRun(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "redraws on request when the periodic redraw is disabled",
+			size: image.Point{60, 10},
+			opts: func(*eventHandlers) []Option {
+				ch := make(chan struct{}, 1)
+				ch <- struct{}{}
+				return []Option{
+					RedrawInterval(0),
+					RedrawChannel(ch),
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "doesn't draw when the periodic redraw is disabled and there are no requests",
+			size: image.Point{60, 10},
+			opts: func(*eventHandlers) []Option {
+				return []Option{
+					RedrawInterval(0),
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -629,3 +664,238 @@ func TestController(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyboardSubscriberOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc  string
+		order KeyboardOrder
+		want  []string
+	}{
+		{
+			desc:  "preempt calls the subscriber before the widgets",
+			order: KeyboardOrderPreempt,
+			want:  []string{"subscriber", "widget"},
+		},
+		{
+			desc:  "fallback calls the subscriber after the widgets",
+			order: KeyboardOrderFallback,
+			want:  []string{"widget", "subscriber"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc := tc
+			t.Parallel()
+
+			var (
+				mu  sync.Mutex
+				got []string
+			)
+			record := func(s string) {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, s)
+			}
+
+			ft := faketerm.MustNew(image.Point{10, 10})
+			cont, err := container.New(ft)
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+			td := newTermdash(ft, cont,
+				KeyboardSubscriber(func(*terminalapi.Keyboard) {
+					record("subscriber")
+				}),
+				KeyboardSubscriberOrder(tc.order),
+			)
+			// Simulates a slow widget.
+			td.eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(terminalapi.Event) {
+				time.Sleep(50 * time.Millisecond)
+				record("widget")
+			})
+
+			td.dispatch(&terminalapi.Keyboard{Key: keyboard.KeyEnter})
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				if len(got) < len(tc.want) {
+					return fmt.Errorf("got %d calls, want %d", len(got), len(tc.want))
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("dispatch => unexpected call order, diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// notifyingWidget is a fake widget that implements widgetapi.Notifier.
+type notifyingWidget struct {
+	*fakewidget.Mirror
+
+	mu     sync.Mutex
+	notify func()
+}
+
+// SetNotify implements widgetapi.Notifier.SetNotify.
+func (nw *notifyingWidget) SetNotify(fn func()) {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+	nw.notify = fn
+}
+
+// get returns the function provided to SetNotify.
+func (nw *notifyingWidget) get() func() {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+	return nw.notify
+}
+
+func TestWidgetNotify(t *testing.T) {
+	t.Parallel()
+
+	size := image.Point{60, 10}
+	got, err := faketerm.New(size, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	w := &notifyingWidget{
+		Mirror: fakewidget.New(widgetapi.Options{}),
+	}
+	cont, err := container.New(got, container.PlaceWidget(w))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		// Periodic redraw is disabled, so the terminal is only drawn when the
+		// widget notifies.
+		errCh <- Run(ctx, got, cont, RedrawInterval(0))
+	}()
+
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if w.get() == nil {
+			return errors.New("SetNotify not called yet")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("testevent.WaitFor => %v", err)
+	}
+
+	// A burst of notifications never blocks the widget.
+	notify := w.get()
+	for i := 0; i < 10; i++ {
+		notify()
+	}
+
+	want := faketerm.MustNew(size)
+	fakewidget.MustDraw(want, testcanvas.MustNew(want.Area()), widgetapi.Options{})
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if diff := faketerm.Diff(want, got); diff != "" {
+			return fmt.Errorf("terminal not redrawn yet: %v", diff)
+		}
+		return nil
+	}); err != nil {
+		t.Errorf("testevent.WaitFor => %v", err)
+	}
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Errorf("Run => unexpected error: %v", err)
+	}
+}
+
+// drawCounter is a fake widget that counts the calls to Draw.
+type drawCounter struct {
+	*notifyingWidget
+
+	mu    sync.Mutex
+	draws int
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (dc *drawCounter) Draw(cvs *canvas.Canvas) error {
+	dc.mu.Lock()
+	dc.draws++
+	dc.mu.Unlock()
+	return dc.notifyingWidget.Draw(cvs)
+}
+
+// get returns the number of calls to Draw.
+func (dc *drawCounter) get() int {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	return dc.draws
+}
+
+func TestClosedRedrawChannel(t *testing.T) {
+	t.Parallel()
+
+	got, err := faketerm.New(image.Point{60, 10}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	w := &drawCounter{
+		notifyingWidget: &notifyingWidget{
+			Mirror: fakewidget.New(widgetapi.Options{}),
+		},
+	}
+	cont, err := container.New(got, container.PlaceWidget(w))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ch := make(chan struct{})
+	close(ch)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(ctx, got, cont, RedrawInterval(0), RedrawChannel(ch))
+	}()
+
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if w.notifyingWidget.get() == nil {
+			return errors.New("SetNotify not called yet")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("testevent.WaitFor => %v", err)
+	}
+
+	// The closed channel doesn't cause redraws, the dashboard keeps
+	// redrawing on other requests.
+	time.Sleep(100 * time.Millisecond)
+	w.notifyingWidget.get()()
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if got := w.get(); got == 0 {
+			return errors.New("the terminal wasn't redrawn yet")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("testevent.WaitFor => %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got, want := w.get(), 1; got != want {
+		t.Errorf("Draw called %d times, want %d", got, want)
+	}
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Errorf("Run => unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package headless implements a terminal that doesn't require a TTY.
+//
+// The headless terminal keeps the drawn frame in memory instead of displaying
+// it, which is useful in CI and scripting scenarios where the standard output
+// isn't a terminal and the real terminal implementations return
+// terminalapi.ErrNotATTY. To capture a single rendered frame as text, create
+// the container on the headless terminal, draw it once with
+// termdash.NewController and read the frame with Terminal.String:
+//
+//	t, err := headless.New(image.Point{80, 24})
+//	...
+//	c, err := container.New(t, ...)
+//	...
+//	ctrl, err := termdash.NewController(t, c)
+//	...
+//	defer ctrl.Close()
+//	fmt.Print(t)
+package headless
+
+import (
+	"context"
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/event/eventqueue"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Terminal is a terminal of a fixed size that draws into memory.
+// It never produces any input events.
+// This object is thread-safe.
+// Implements terminalapi.Terminal.
+type Terminal struct {
+	// ft holds the drawn frame.
+	ft *faketerm.Terminal
+	// events is a queue of input events, it always stays empty.
+	events *eventqueue.Unbound
+}
+
+// New returns a new headless Terminal of the specified size.
+// Call Close() when the terminal isn't required anymore.
+func New(size image.Point) (*Terminal, error) {
+	events := eventqueue.New()
+	ft, err := faketerm.New(size, faketerm.WithEventQueue(events))
+	if err != nil {
+		return nil, err
+	}
+	return &Terminal{
+		ft:     ft,
+		events: events,
+	}, nil
+}
+
+// String returns the frame drawn on the terminal, one line of text per row of
+// the terminal. Cell options like colors are ignored.
+// Implements fmt.Stringer.
+func (t *Terminal) String() string {
+	return t.ft.String()
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	return t.ft.Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	return t.ft.Clear(opts...)
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (t *Terminal) Flush() error {
+	return t.ft.Flush()
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+// The headless terminal has no cursor, this is a no-op.
+func (t *Terminal) SetCursor(p image.Point) {}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+// The headless terminal has no cursor, this is a no-op.
+func (t *Terminal) HideCursor() {}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	return t.ft.SetCell(p, r, opts...)
+}
+
+// Event implements terminalapi.Terminal.Event.
+// Blocks until the context gets canceled, since there is no input.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	return t.ft.Event(ctx)
+}
+
+// Close closes the terminal.
+func (t *Terminal) Close() {
+	t.events.Close()
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headless
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// Example shows how to capture a single rendered frame as text.
+func Example() {
+	t, err := New(image.Point{5, 1})
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	txt, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := txt.Write("hello"); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(t, container.PlaceWidget(txt))
+	if err != nil {
+		panic(err)
+	}
+
+	ctrl, err := termdash.NewController(t, c)
+	if err != nil {
+		panic(err)
+	}
+	defer ctrl.Close()
+
+	fmt.Print(t)
+	// Output: hello
+}
+
+func TestString(t *testing.T) {
+	term, err := New(image.Point{3, 2})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	defer term.Close()
+
+	for i, r := range "ab" {
+		if err := term.SetCell(image.Point{i, 1}, r, cell.FgColor(cell.ColorRed)); err != nil {
+			t.Fatalf("SetCell => unexpected error: %v", err)
+		}
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+
+	if got, want := term.String(), "   \nab \n"; got != want {
+		t.Errorf("String => %q, want %q", got, want)
+	}
+
+	if err := term.Clear(); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+	if got, want := term.String(), "   \n   \n"; got != want {
+		t.Errorf("String after Clear => %q, want %q", got, want)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	term, err := New(image.Point{3, 2})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	defer term.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if got := term.Event(ctx); got != nil {
+		t.Errorf("Event => %v, want nil", got)
+	}
+}
+
+func TestNewFailsOnInvalidSize(t *testing.T) {
+	if _, err := New(image.Point{-1, 1}); err == nil {
+		t.Errorf("New => got nil error, want an error")
+	}
+}
@@ -17,6 +17,8 @@ package termbox
 // cell_options.go converts termdash cell options to the termbox format.
 
 import (
+	"time"
+
 	"github.com/mum4k/termdash/cell"
 	tbx "github.com/nsf/termbox-go"
 )
@@ -32,6 +34,27 @@ func cellOptsToFg(opts *cell.Options) tbx.Attribute {
 }
 
 // cellOptsToBg converts the cell options to the termbox background attribute.
-func cellOptsToBg(opts *cell.Options) tbx.Attribute {
-	return cellColor(opts.BgColor)
+// Blinking cells get the attribute that makes the terminal blink them, unless
+// they are blinked in software with a positive period.
+func cellOptsToBg(opts *cell.Options, blinkPeriod time.Duration) tbx.Attribute {
+	a := cellColor(opts.BgColor)
+	if opts.Blink && blinkPeriod <= 0 {
+		// Termbox emits the blink sequence for the bold background
+		// attribute.
+		a |= tbx.AttrBold
+	}
+	return a
+}
+
+// blinkRune returns the rune that should be drawn at the specified time into a
+// cell with the options when blinking in software with the period.
+// Returns the space rune when the content of a blinking cell is hidden.
+func blinkRune(r rune, opts *cell.Options, period time.Duration, now time.Time) rune {
+	if !opts.Blink || period <= 0 {
+		return r
+	}
+	if (now.UnixNano()/int64(period))%2 == 1 {
+		return ' '
+	}
+	return r
 }
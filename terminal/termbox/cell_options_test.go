@@ -16,6 +16,7 @@ package termbox
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	tbx "github.com/nsf/termbox-go"
@@ -48,3 +49,85 @@ func TestCellColor(t *testing.T) {
 		})
 	}
 }
+
+func TestCellOptsToBg(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   *cell.Options
+		period time.Duration
+		want   tbx.Attribute
+	}{
+		{
+			desc: "converts the background color",
+			opts: cell.NewOptions(cell.BgColor(cell.ColorRed)),
+			want: tbx.ColorRed,
+		},
+		{
+			desc: "blinking cell blinks on the terminal",
+			opts: cell.NewOptions(cell.BgColor(cell.ColorRed), cell.Blink()),
+			want: tbx.ColorRed | tbx.AttrBold,
+		},
+		{
+			desc:   "blinking cell doesn't blink on the terminal with software blink",
+			opts:   cell.NewOptions(cell.BgColor(cell.ColorRed), cell.Blink()),
+			period: time.Second,
+			want:   tbx.ColorRed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := cellOptsToBg(tc.opts, tc.period)
+			if got != tc.want {
+				t.Errorf("cellOptsToBg => got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBlinkRune(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   *cell.Options
+		period time.Duration
+		now    time.Time
+		want   rune
+	}{
+		{
+			desc:   "doesn't hide cells without blink",
+			opts:   cell.NewOptions(),
+			period: time.Second,
+			now:    time.Unix(1, 0),
+			want:   'x',
+		},
+		{
+			desc: "doesn't hide blinking cells without software blink",
+			opts: cell.NewOptions(cell.Blink()),
+			now:  time.Unix(1, 0),
+			want: 'x',
+		},
+		{
+			desc:   "blinking cell visible during even periods",
+			opts:   cell.NewOptions(cell.Blink()),
+			period: time.Second,
+			now:    time.Unix(2, 500),
+			want:   'x',
+		},
+		{
+			desc:   "blinking cell hidden during odd periods",
+			opts:   cell.NewOptions(cell.Blink()),
+			period: time.Second,
+			now:    time.Unix(3, 500),
+			want:   ' ',
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := blinkRune('x', tc.opts, tc.period, tc.now)
+			if got != tc.want {
+				t.Errorf("blinkRune => got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
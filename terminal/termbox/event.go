@@ -192,6 +192,7 @@ func convMouse(tbxEv tbx.Event) terminalapi.Event {
 	return &terminalapi.Mouse{
 		Position: image.Point{tbxEv.MouseX, tbxEv.MouseY},
 		Button:   button,
+		Motion:   tbxEv.Mod&tbx.ModMotion != 0,
 	}
 }
 
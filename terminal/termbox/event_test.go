@@ -145,9 +145,11 @@ func TestToTermdashEvents(t *testing.T) {
 
 func TestMouseButtons(t *testing.T) {
 	tests := []struct {
-		key     tbx.Key
-		want    mouse.Button
-		wantErr bool
+		key        tbx.Key
+		mod        tbx.Modifier
+		want       mouse.Button
+		wantMotion bool
+		wantErr    bool
 	}{
 		{wantErr: true},
 		{key: tbx.KeyF1, wantErr: true},
@@ -156,14 +158,18 @@ func TestMouseButtons(t *testing.T) {
 		{key: tbx.MouseMiddle, want: mouse.ButtonMiddle},
 		{key: tbx.MouseRight, want: mouse.ButtonRight},
 		{key: tbx.MouseRelease, want: mouse.ButtonRelease},
+		// Movement of the mouse with a held button.
+		{key: tbx.MouseLeft, mod: tbx.ModMotion, want: mouse.ButtonLeft, wantMotion: true},
+		// Movement of the mouse without any pressed buttons.
+		{key: tbx.MouseRelease, mod: tbx.ModMotion, want: mouse.ButtonRelease, wantMotion: true},
 		{key: tbx.MouseWheelUp, want: mouse.ButtonWheelUp},
 		{key: tbx.MouseWheelDown, want: mouse.ButtonWheelDown},
 	}
 
 	for _, tc := range tests {
-		t.Run(fmt.Sprintf("key:%v want:%v", tc.key, tc.want), func(t *testing.T) {
+		t.Run(fmt.Sprintf("key:%v mod:%v want:%v", tc.key, tc.mod, tc.want), func(t *testing.T) {
 
-			evs := toTermdashEvents(tbx.Event{Type: tbx.EventMouse, Key: tc.key})
+			evs := toTermdashEvents(tbx.Event{Type: tbx.EventMouse, Key: tc.key, Mod: tc.mod})
 			if got, want := len(evs), 1; got != want {
 				t.Fatalf("toTermdashEvents => got %d events, want %d", got, want)
 			}
@@ -181,6 +187,9 @@ func TestMouseButtons(t *testing.T) {
 				if got := e.Button; got != tc.want {
 					t.Errorf("toTermdashEvents => got %v, want %v", got, tc.want)
 				}
+				if got := e.Motion; got != tc.wantMotion {
+					t.Errorf("toTermdashEvents => got motion %v, want %v", got, tc.wantMotion)
+				}
 
 			default:
 				t.Fatalf("toTermdashEvents => unexpected event type %T", e)
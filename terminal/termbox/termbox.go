@@ -17,7 +17,10 @@ package termbox
 
 import (
 	"context"
+	"fmt"
 	"image"
+	"os"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/event/eventqueue"
@@ -42,7 +45,13 @@ func (o option) set(t *Terminal) {
 // DefaultColorMode is the default value for the ColorMode option.
 const DefaultColorMode = terminalapi.ColorMode256
 
-// ColorMode sets the terminal color mode.
+// ColorMode sets the terminal color mode, i.e. the palette used when applying
+// the colors of the cells.
+// The termbox backend doesn't detect the color capabilities of the terminal,
+// it always uses DefaultColorMode unless this option is provided. Use this
+// option to force a different palette on terminals that don't support
+// DefaultColorMode, e.g. terminalapi.ColorModeNormal on terminals limited to
+// the 8 system colors.
 // Defaults to DefaultColorMode.
 func ColorMode(cm terminalapi.ColorMode) Option {
 	return option(func(t *Terminal) {
@@ -50,6 +59,44 @@ func ColorMode(cm terminalapi.ColorMode) Option {
 	})
 }
 
+// SoftwareBlink makes cells with the cell.Blink option blink by alternately
+// drawing and hiding their content instead of asking the terminal to blink
+// them. Useful with terminals that don't support blinking or have it disabled.
+// The content is visible for one period and hidden for the next.
+// The cells are only updated when the screen is redrawn, so the period should
+// be a multiple of the redraw interval.
+// Cells with the cell.Blink option are blinked by the terminal if this isn't
+// provided.
+func SoftwareBlink(period time.Duration) Option {
+	return option(func(t *Terminal) {
+		t.blinkPeriod = period
+	})
+}
+
+// MouseMotion makes the terminal report the movement of the mouse even when no
+// mouse buttons are held. The movement is reported as mouse events with the
+// Motion field set and the mouse.ButtonRelease button. This is required for
+// the container.FocusOnHover mode.
+// The terminal must support the "any event tracking" mouse mode (1003) of
+// xterm. New returns an error if the terminal device can't be opened to
+// enable the mode, e.g. on Windows.
+// Only movement with a held button is reported if this isn't provided.
+func MouseMotion() Option {
+	return option(func(t *Terminal) {
+		t.mouseMotion = true
+	})
+}
+
+// The escape sequences that enable and disable the "any event tracking" mouse
+// mode.
+const (
+	enterMouseMotion = "\x1b[?1003h"
+	exitMouseMotion  = "\x1b[?1003l"
+)
+
+// ttyPath is the path to the terminal device termbox writes to.
+const ttyPath = "/dev/tty"
+
 // Terminal provides input and output to a real terminal. Wraps the
 // nsf/termbox-go terminal implementation. This object is not thread-safe.
 // Implements terminalapi.Terminal.
@@ -60,8 +107,14 @@ type Terminal struct {
 	// done gets closed when Close() is called.
 	done chan struct{}
 
+	// tty is the terminal device used to enable the tracking of the mouse
+	// motion. Nil unless the MouseMotion option was provided.
+	tty *os.File
+
 	// Options.
-	colorMode terminalapi.ColorMode
+	colorMode   terminalapi.ColorMode
+	blinkPeriod time.Duration
+	mouseMotion bool
 }
 
 // newTerminal creates the terminal and applies the options.
@@ -77,9 +130,22 @@ func newTerminal(opts ...Option) *Terminal {
 	return t
 }
 
+// isTerminal determines if the file is a terminal, i.e. a character device.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
 // New returns a new termbox based Terminal.
+// Returns terminalapi.ErrNotATTY if the standard output isn't a terminal.
 // Call Close() when the terminal isn't required anymore.
 func New(opts ...Option) (*Terminal, error) {
+	if !isTerminal(os.Stdout) {
+		return nil, terminalapi.ErrNotATTY
+	}
 	if err := tbx.Init(); err != nil {
 		return nil, err
 	}
@@ -92,6 +158,23 @@ func New(opts ...Option) (*Terminal, error) {
 	}
 	tbx.SetOutputMode(om)
 
+	if t.mouseMotion {
+		// Termbox enables its mouse tracking modes when setting the input mode
+		// above. It writes them directly to the terminal device, so the mode
+		// enabled here is applied after them.
+		tty, err := os.OpenFile(ttyPath, os.O_WRONLY, 0)
+		if err != nil {
+			tbx.Close()
+			return nil, fmt.Errorf("unable to open the terminal device to enable the mouse motion tracking: %v", err)
+		}
+		if _, err := tty.WriteString(enterMouseMotion); err != nil {
+			tty.Close()
+			tbx.Close()
+			return nil, fmt.Errorf("unable to enable the mouse motion tracking: %v", err)
+		}
+		t.tty = tty
+	}
+
 	go t.pollEvents() // Stops when Close() is called.
 	return t, nil
 }
@@ -105,7 +188,7 @@ func (t *Terminal) Size() image.Point {
 // Clear implements terminalapi.Terminal.Clear.
 func (t *Terminal) Clear(opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	return tbx.Clear(cellOptsToFg(o), cellOptsToBg(o))
+	return tbx.Clear(cellOptsToFg(o), cellOptsToBg(o, t.blinkPeriod))
 }
 
 // Flush implements terminalapi.Terminal.Flush.
@@ -126,7 +209,8 @@ func (t *Terminal) HideCursor() {
 // SetCell implements terminalapi.Terminal.SetCell.
 func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	tbx.SetCell(p.X, p.Y, r, cellOptsToFg(o), cellOptsToBg(o))
+	r = blinkRune(r, o, t.blinkPeriod, time.Now())
+	tbx.SetCell(p.X, p.Y, r, cellOptsToFg(o), cellOptsToBg(o, t.blinkPeriod))
 	return nil
 }
 
@@ -160,5 +244,9 @@ func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
 // Implements terminalapi.Terminal.Close.
 func (t *Terminal) Close() {
 	close(t.done)
+	if t.tty != nil {
+		t.tty.WriteString(exitMouseMotion)
+		t.tty.Close()
+	}
 	tbx.Close()
 }
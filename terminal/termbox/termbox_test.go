@@ -15,7 +15,10 @@
 package termbox
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/terminal/terminalapi"
@@ -42,6 +45,26 @@ func TestNewTerminal(t *testing.T) {
 				colorMode: terminalapi.ColorModeNormal,
 			},
 		},
+		{
+			desc: "sets software blink",
+			opts: []Option{
+				SoftwareBlink(500 * time.Millisecond),
+			},
+			want: &Terminal{
+				colorMode:   terminalapi.ColorMode256,
+				blinkPeriod: 500 * time.Millisecond,
+			},
+		},
+		{
+			desc: "enables mouse motion",
+			opts: []Option{
+				MouseMotion(),
+			},
+			want: &Terminal{
+				colorMode:   terminalapi.ColorMode256,
+				mouseMotion: true,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -58,3 +81,42 @@ func TestNewTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "termbox")
+	if err != nil {
+		t.Fatalf("TempFile => unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe => unexpected error: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	tests := []struct {
+		desc string
+		f    *os.File
+		want bool
+	}{
+		{
+			desc: "regular file isn't a terminal",
+			f:    f,
+		},
+		{
+			desc: "pipe isn't a terminal",
+			f:    w,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := isTerminal(tc.f); got != tc.want {
+				t.Errorf("isTerminal => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
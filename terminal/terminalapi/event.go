@@ -63,9 +63,24 @@ func (r Resize) String() string {
 // Implements terminalapi.Event.
 type Mouse struct {
 	// Position of the mouse on the terminal.
+	// On events delivered to widgets, the position is relative to the
+	// widget's canvas instead, i.e. point (0, 0) is the top left corner of
+	// the canvas.
 	Position image.Point
 	// Button identifies the pressed button if any.
 	Button mouse.Button
+	// Motion indicates that the event reports movement of the mouse rather
+	// than a press or a release of the button. The Button is the button held
+	// while the mouse moved or mouse.ButtonRelease if no buttons are held.
+	// Movement without held buttons is only reported by terminals that track
+	// any mouse motion, e.g. termbox with its MouseMotion option.
+	Motion bool
+
+	// TerminalPosition is the position of the mouse on the terminal.
+	// Only populated on events delivered to widgets, useful for widgets that
+	// need to know the original position for events that fall outside of
+	// their canvas.
+	TerminalPosition image.Point
 }
 
 func (*Mouse) isEvent() {}
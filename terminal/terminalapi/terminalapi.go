@@ -17,13 +17,24 @@ package terminalapi
 
 import (
 	"context"
+	"errors"
 	"image"
 
 	"github.com/mum4k/termdash/cell"
 )
 
+// ErrNotATTY is returned by the constructors of terminal implementations that
+// require a TTY when the standard output isn't one, e.g. when it is redirected
+// to a file. See the terminal/headless package for drawing without a TTY.
+var ErrNotATTY = errors.New("the standard output isn't a terminal")
+
 // Terminal abstracts an implementation of a 2-D terminal.
 // A terminal consists of a number of cells.
+//
+// Implementations must be double buffered. Calls to Clear and SetCell only
+// modify an off-screen back buffer and none of the changes become visible
+// until Flush is called. This allows all the widgets to be drawn as a single
+// frame without tearing.
 type Terminal interface {
 	// Size returns the terminal width and height in cells.
 	Size() image.Point
@@ -33,6 +44,7 @@ type Terminal interface {
 	// on all the cell.
 	Clear(opts ...cell.Option) error
 	// Flush flushes the internal back buffer to the terminal.
+	// This is the only call that changes what is visible on the terminal.
 	Flush() error
 
 	// SetCursor sets the position of the cursor.
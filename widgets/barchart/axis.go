@@ -0,0 +1,147 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package barchart
+
+// axis.go contains code that draws the Y axis.
+
+import (
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+)
+
+// Runes used to draw the Y axis.
+const (
+	yAxisRune = '│'
+	yTickRune = '┤'
+)
+
+// yTick is a labeled tick mark on the Y axis.
+type yTick struct {
+	// row is the row of the canvas the tick is on.
+	row int
+	// label is the formatted value of the tick.
+	label string
+}
+
+// yAxis is the Y axis drawn on the left side of the bars.
+type yAxis struct {
+	// ticks are the labeled tick marks ordered by increasing value.
+	ticks []*yTick
+	// labelWidth is the width of the widest label in cells.
+	labelWidth int
+	// top and bottom are the first and the last row occupied by the axis.
+	top, bottom int
+}
+
+// width returns the width of the Y axis in cells, i.e. the labels and the
+// axis line.
+func (ya *yAxis) width() int {
+	return ya.labelWidth + 1
+}
+
+// newYAxis determines the ticks of the Y axis for the values on a canvas
+// with the provided area.
+// The ticks are at multiples of a round step, i.e. 1, 2 or 5 times a power of
+// ten, chosen so that the labels have at least one empty row between them.
+// The maximum value is always labeled at the top of the axis.
+func (bc *BarChart) newYAxis(cvsAr image.Rectangle) *yAxis {
+	bottom := cvsAr.Max.Y - 1
+	if len(bc.opts.labels) > 0 {
+		bottom-- // One line for the bar labels.
+	}
+	top := cvsAr.Min.Y
+	if bc.hasLegend() {
+		top++ // One line for the legend.
+	}
+	available := bottom - top + 1
+
+	// row returns the row of the tick displaying the value.
+	row := func(v int) int {
+		return bottom - int(float64(available-1)*float64(v)/float64(bc.max))
+	}
+
+	ya := &yAxis{
+		top:    top,
+		bottom: bottom,
+	}
+	add := func(v int) {
+		t := &yTick{
+			row:   row(v),
+			label: bc.formatValue(v),
+		}
+		if n := len(ya.ticks); n > 0 && ya.ticks[n-1].row == t.row {
+			ya.ticks[n-1] = t // Prefer the larger value.
+		} else {
+			ya.ticks = append(ya.ticks, t)
+		}
+	}
+
+	step := niceStep(bc.max, available/2)
+	for v := 0; v < bc.max; v += step {
+		add(v)
+	}
+	add(bc.max)
+
+	for _, t := range ya.ticks {
+		if w := runewidth.StringWidth(t.label); w > ya.labelWidth {
+			ya.labelWidth = w
+		}
+	}
+	return ya
+}
+
+// niceStep returns the smallest step between the ticks of the axis that is
+// 1, 2 or 5 times a power of ten and divides the values from zero to max into
+// at most the specified number of intervals.
+func niceStep(max, intervals int) int {
+	if intervals < 1 {
+		return max
+	}
+	raw := float64(max) / float64(intervals)
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if step := m * mag; step >= raw {
+			if s := int(math.Ceil(step)); s > 1 {
+				return s
+			}
+			return 1
+		}
+	}
+	return max
+}
+
+// draw draws the Y axis on the left side of the canvas.
+func (ya *yAxis) draw(cvs *canvas.Canvas) error {
+	x := cvs.Area().Min.X + ya.labelWidth
+	for row := ya.top; row <= ya.bottom; row++ {
+		if _, err := cvs.SetCell(image.Point{x, row}, yAxisRune); err != nil {
+			return err
+		}
+	}
+	for _, t := range ya.ticks {
+		if _, err := cvs.SetCell(image.Point{x, t.row}, yTickRune); err != nil {
+			return err
+		}
+		start := image.Point{x - runewidth.StringWidth(t.label), t.row}
+		if _, err := draw.Text(cvs, t.label, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
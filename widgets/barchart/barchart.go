@@ -21,13 +21,16 @@ import (
 	"fmt"
 	"image"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/alignfor"
+	"github.com/mum4k/termdash/internal/animation"
 	"github.com/mum4k/termdash/internal/area"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
 	"github.com/mum4k/termdash/internal/widgetapi"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
@@ -45,10 +48,27 @@ type BarChart struct {
 	// max is the maximum value of a bar. A bar having this value takes all the
 	// vertical space.
 	max int
+	// segments are the segments of each bar provided on a call to
+	// StackedValues(). Nil if the bars aren't stacked. The values field then
+	// contains the total of each bar.
+	segments [][]int
 
 	// mu protects the BarChart.
 	mu sync.Mutex
 
+	// animFrom are the heights of the bars as fractions of the max value that
+	// were drawn when the current transition started.
+	animFrom []float64
+	// animStart is the time when the current transition started.
+	animStart time.Time
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+	// frames requests redraws while a transition is in progress.
+	frames animation.Frames
+
+	// notify requests a redraw of the terminal, nil until SetNotify is called.
+	notify func()
+
 	// opts are the provided options.
 	opts *options
 }
@@ -64,6 +84,7 @@ func New(opts ...Option) (*BarChart, error) {
 	}
 	return &BarChart{
 		opts: opt,
+		now:  time.Now,
 	}, nil
 }
 
@@ -81,23 +102,56 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas) error {
 		return draw.ResizeNeeded(cvs)
 	}
 
-	for i, v := range bc.values {
-		r, err := bc.barRect(cvs, i, v)
+	barsCvs := cvs
+	if bc.opts.showYAxis && len(bc.values) > 0 {
+		ya := bc.newYAxis(cvs.Area())
+		barsAr := cvs.Area()
+		barsAr.Min.X += ya.width()
+		if barsAr.Dx() < bc.minBarsWidth() {
+			return draw.ResizeNeeded(cvs)
+		}
+		if err := ya.draw(cvs); err != nil {
+			return err
+		}
+		bcvs, err := canvas.New(barsAr)
 		if err != nil {
 			return err
 		}
+		barsCvs = bcvs
+	}
 
-		if r.Dy() > 0 { // Value might be so small so that the rectangle is zero.
-			if err := draw.Rectangle(cvs, r,
-				draw.RectCellOpts(cell.BgColor(bc.barColor(i))),
-				draw.RectChar(bc.opts.barChar),
-			); err != nil {
+	if err := bc.drawBars(barsCvs); err != nil {
+		return err
+	}
+	if barsCvs != cvs {
+		if err := barsCvs.CopyTo(cvs); err != nil {
+			return err
+		}
+	}
+	// The legend is drawn last, since copying the bars would overwrite it
+	// with empty cells.
+	return bc.drawLegend(cvs)
+}
+
+// drawBars draws the bars along with their values and labels.
+func (bc *BarChart) drawBars(cvs *canvas.Canvas) error {
+	drawn := bc.drawnFractions(bc.now())
+	for i, v := range bc.values {
+		if target := float64(v) / float64(bc.max); drawn[i] != target {
+			bc.frames.Schedule(bc.notify)
+		}
+
+		height := drawn[i] * float64(bc.max)
+		if bc.segments != nil {
+			if err := bc.drawSegments(cvs, i, height); err != nil {
 				return err
 			}
+		} else if err := bc.drawBar(cvs, i, 0, height, bc.barColor(i)); err != nil {
+			return err
 		}
 
 		if bc.opts.showValues {
-			if err := bc.drawText(cvs, i, fmt.Sprint(bc.values[i]), bc.valColor(i), insideBar); err != nil {
+			if err := bc.drawText(cvs, i, bc.valText(i), bc.valColor(i), insideBar); err != nil {
 				return err
 			}
 		}
@@ -112,6 +166,84 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas) error {
 	return nil
 }
 
+// drawBar draws the part of the i-th bar that displays values in the range
+// from the value to the value to.
+func (bc *BarChart) drawBar(cvs *canvas.Canvas, i int, from, to float64, color cell.Color) error {
+	r, err := bc.barRect(cvs, i, to)
+	if err != nil {
+		return err
+	}
+	r.Max.Y -= bc.barHeight(cvs, i, from)
+
+	if r.Dy() <= 0 { // Value might be so small so that the rectangle is zero.
+		return nil
+	}
+	return draw.Rectangle(cvs, r,
+		draw.RectCellOpts(cell.BgColor(color)),
+		draw.RectChar(bc.opts.barChar),
+	)
+}
+
+// drawSegments draws the segments of the i-th stacked bar, scaled so that
+// the bar displays the specified value.
+// The heights are determined from the running total so that rounding doesn't
+// make the stacked bar taller or shorter than a bar displaying the total.
+func (bc *BarChart) drawSegments(cvs *canvas.Canvas, i int, value float64) error {
+	if bc.values[i] == 0 {
+		return nil
+	}
+	scale := value / float64(bc.values[i])
+
+	var total int
+	for s, v := range bc.segments[i] {
+		from, to := float64(total)*scale, float64(total+v)*scale
+		if err := bc.drawBar(cvs, i, from, to, bc.segmentColor(s)); err != nil {
+			return err
+		}
+		total += v
+	}
+	return nil
+}
+
+// legendSymbol is the rune that represents the color of a segment in the
+// legend.
+const legendSymbol = '█'
+
+// drawLegend draws the legend describing the segments of stacked bars on the
+// first line of the canvas.
+func (bc *BarChart) drawLegend(cvs *canvas.Canvas) error {
+	if !bc.hasLegend() {
+		return nil
+	}
+
+	ar := cvs.Area()
+	cur := ar.Min
+	for s, l := range bc.opts.segmentLabels {
+		if s > 0 {
+			cur.X++ // Space between the entries.
+		}
+		if !cur.In(ar) {
+			break
+		}
+		entry := fmt.Sprintf("%c %s", legendSymbol, l)
+		if _, err := draw.Text(cvs, entry, cur,
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+		if _, err := cvs.SetCell(cur, legendSymbol, cell.FgColor(bc.segmentColor(s))); err != nil {
+			return err
+		}
+		cur.X += runewidth.StringWidth(entry)
+	}
+	return nil
+}
+
+// hasLegend determines if the legend of stacked bars is drawn.
+func (bc *BarChart) hasLegend() bool {
+	return bc.segments != nil && len(bc.opts.segmentLabels) > 0
+}
+
 // textLoc represents the location of the drawn text.
 type textLoc int
 
@@ -125,7 +257,7 @@ func (bc *BarChart) drawText(cvs *canvas.Canvas, i int, text string, color cell.
 	// Rectangle representing area in which the text will be aligned.
 	var barCol image.Rectangle
 
-	r, err := bc.barRect(cvs, i, bc.max)
+	r, err := bc.barRect(cvs, i, float64(bc.max))
 	if err != nil {
 		return err
 	}
@@ -145,11 +277,12 @@ func (bc *BarChart) drawText(cvs *canvas.Canvas, i int, text string, color cell.
 		return err
 	}
 
-	return draw.Text(cvs, text, start,
+	_, err = draw.Text(cvs, text, start,
 		draw.TextCellOpts(cell.FgColor(color)),
 		draw.TextMaxX(barCol.Max.X),
 		draw.TextOverrunMode(draw.OverrunModeThreeDot),
 	)
+	return err
 }
 
 // barWidth determines the width of a single bar based on options and the canvas.
@@ -170,12 +303,16 @@ func (bc *BarChart) barWidth(cvs *canvas.Canvas) int {
 }
 
 // barHeight determines the height of the i-th bar based on the value it is displaying.
-func (bc *BarChart) barHeight(cvs *canvas.Canvas, i, value int) int {
+func (bc *BarChart) barHeight(cvs *canvas.Canvas, i int, value float64) int {
 	available := cvs.Area().Dy()
 	if len(bc.opts.labels) > 0 {
 		// One line for the bar labels.
 		available--
 	}
+	if bc.hasLegend() {
+		// One line for the legend.
+		available--
+	}
 
 	ratio := float32(value) / float32(bc.max)
 	return int(float32(available) * ratio)
@@ -183,7 +320,7 @@ func (bc *BarChart) barHeight(cvs *canvas.Canvas, i, value int) int {
 
 // barRect returns a rectangle that represents the i-th bar on the canvas that
 // displays the specified value.
-func (bc *BarChart) barRect(cvs *canvas.Canvas, i, value int) (image.Rectangle, error) {
+func (bc *BarChart) barRect(cvs *canvas.Canvas, i int, value float64) (image.Rectangle, error) {
 	bw := bc.barWidth(cvs)
 	minX := bw * i
 	if i > 0 {
@@ -210,6 +347,16 @@ func (bc *BarChart) barColor(i int) cell.Color {
 	return DefaultBarColor
 }
 
+// segmentColor safely determines the color for the s-th segment of stacked
+// bars. Colors are optional and don't have to be specified for all the
+// segments.
+func (bc *BarChart) segmentColor(s int) cell.Color {
+	if len(bc.opts.segmentColors) > s {
+		return bc.opts.segmentColors[s]
+	}
+	return DefaultBarColor
+}
+
 // valColor safely determines the color for the i-th value.
 // Colors are optional and don't have to be specified for all the values.
 func (bc *BarChart) valColor(i int) cell.Color {
@@ -219,6 +366,19 @@ func (bc *BarChart) valColor(i int) cell.Color {
 	return DefaultValueColor
 }
 
+// valText returns the text displaying the value of the i-th bar.
+func (bc *BarChart) valText(i int) string {
+	return bc.formatValue(bc.values[i])
+}
+
+// formatValue formats the value using the ValueFormatter if provided.
+func (bc *BarChart) formatValue(v int) string {
+	if bc.opts.valueFormat != nil {
+		return bc.opts.valueFormat(v)
+	}
+	return fmt.Sprint(v)
+}
+
 // label safely determines the label and its color for the i-th bar.
 // Labels are optional and don't have to be specified for all the bars.
 func (bc *BarChart) label(i int) (string, cell.Color) {
@@ -249,11 +409,96 @@ func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
 	for _, opt := range opts {
 		opt.set(bc.opts)
 	}
+	bc.startAnimation()
 	bc.values = values
 	bc.max = max
+	bc.segments = nil
+	bc.notifyChanged()
 	return nil
 }
 
+// StackedValues sets the values to be displayed by the BarChart as stacked
+// bars. Each element of values is a bar made of segments stacked on top of
+// each other, the first segment is at the bottom of the bar. Bars can have
+// different number of segments.
+// The segments must not be negative and the total of each bar must be less or
+// equal the maximum value. A bar whose total is the maximum value is a full
+// bar, taking all available vertical space. The text displayed by the
+// ShowValues option is the total of each bar.
+// Use the SegmentColors and SegmentLabels options to distinguish the
+// segments.
+// Provided options override values set when New() was called.
+func (bc *BarChart) StackedValues(values [][]int, max int, opts ...Option) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	totals := make([]int, len(values))
+	for i, segs := range values {
+		for s, v := range segs {
+			if v < 0 {
+				return fmt.Errorf("invalid values[%d][%d]: %d, each segment must be 0 <= segment", i, s, v)
+			}
+			totals[i] += v
+		}
+	}
+	if err := validateValues(totals, max); err != nil {
+		return err
+	}
+
+	for _, opt := range opts {
+		opt.set(bc.opts)
+	}
+	bc.startAnimation()
+	bc.values = totals
+	bc.max = max
+	bc.segments = values
+	bc.notifyChanged()
+	return nil
+}
+
+// SetNotify implements widgetapi.Notifier.SetNotify.
+// The widget requests a redraw each time the values change and while the bars
+// transition to new values, see the Animate option.
+func (bc *BarChart) SetNotify(fn func()) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.notify = fn
+}
+
+// notifyChanged requests a redraw of the terminal if SetNotify was called.
+// Caller must hold bc.mu.
+func (bc *BarChart) notifyChanged() {
+	if bc.notify != nil {
+		bc.notify()
+	}
+}
+
+// startAnimation starts a transition from the currently drawn bars.
+// Must be called before the new values are recorded.
+// Caller must hold bc.mu.
+func (bc *BarChart) startAnimation() {
+	now := bc.now()
+	bc.animFrom = bc.drawnFractions(now)
+	bc.animStart = now
+}
+
+// drawnFractions returns the heights of the bars as fractions of the max value
+// that should be drawn at the specified time. Bars that didn't exist when the
+// transition started grow from zero.
+// Caller must hold bc.mu.
+func (bc *BarChart) drawnFractions(now time.Time) []float64 {
+	progress := animation.Progress(bc.animStart, now, bc.opts.animation)
+	res := make([]float64, len(bc.values))
+	for i, v := range bc.values {
+		var from float64
+		if i < len(bc.animFrom) {
+			from = bc.animFrom[i]
+		}
+		res[i] = animation.Interpolate(from, float64(v)/float64(bc.max), progress)
+	}
+	return res
+}
+
 // Keyboard input isn't supported on the BarChart widget.
 func (*BarChart) Keyboard(k *terminalapi.Keyboard) error {
 	return errors.New("the BarChart widget doesn't support keyboard events")
@@ -286,15 +531,28 @@ func (bc *BarChart) minSize() image.Point {
 	if len(bc.opts.labels) > 0 {
 		minHeight++ // One line for the labels.
 	}
+	if bc.hasLegend() {
+		minHeight++ // One line for the legend.
+	}
 
+	minWidth := bc.minBarsWidth()
+	if bc.opts.showYAxis {
+		ya := bc.newYAxis(image.Rect(0, 0, minWidth, minHeight))
+		minWidth += ya.width()
+	}
+	return image.Point{minWidth, minHeight}
+}
+
+// minBarsWidth determines the minimum width required to draw the bars.
+func (bc *BarChart) minBarsWidth() int {
+	bars := len(bc.values)
 	var minBarWidth int
 	if bc.opts.barWidth < 1 {
 		minBarWidth = 1 // At least one char for the bar itself.
 	} else {
 		minBarWidth = bc.opts.barWidth
 	}
-	minWidth := bars*minBarWidth + (bars-1)*bc.opts.barGap
-	return image.Point{minWidth, minHeight}
+	return bars*minBarWidth + (bars-1)*bc.opts.barGap
 }
 
 // validateValues validates the provided values and maximum.
@@ -15,8 +15,10 @@
 package barchart
 
 import (
+	"fmt"
 	"image"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
@@ -67,6 +69,20 @@ func TestBarChart(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "fails on negative animation duration",
+			opts: []Option{
+				Animate(-1),
+			},
+			update: func(bc *BarChart) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
 		{
 			desc: "draws empty for no values",
 			opts: []Option{
@@ -565,6 +581,343 @@ func TestBarChart(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "formats values with the value formatter",
+			opts: []Option{
+				Char('o'),
+				BarWidth(3),
+				ShowValues(),
+				ValueFormatter(func(v int) string {
+					return fmt.Sprintf("%dk", v)
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{2, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "2k", image.Point{0, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+
+				testdraw.MustRectangle(c, image.Rect(4, 0, 7, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "10k", image.Point{4, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "trims formatted values that don't fit the bar",
+			opts: []Option{
+				Char('o'),
+				BarWidth(2),
+				ShowValues(),
+				ValueFormatter(func(v int) string {
+					return fmt.Sprintf("%d MB", v)
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{10}, 10)
+			},
+			canvas: image.Rect(0, 0, 2, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "1…", image.Point{0, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails on negative segment of a stacked bar",
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, -1}}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails when the total of a stacked bar exceeds the maximum",
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{5, 6}}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "draws stacked bars with different number of segments",
+			opts: []Option{
+				Char('o'),
+				BarWidth(1),
+				BarGap(1),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 2}, {3}, {1, 1, 1}}, 4)
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 3, 1, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 1, 1, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 1, 3, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 3, 5, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 2, 5, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 1, 5, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the legend of stacked bars",
+			opts: []Option{
+				Char('o'),
+				BarWidth(1),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+				}),
+				SegmentLabels([]string{"a", "b"}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 1}}, 2)
+			},
+			canvas: image.Rect(0, 0, 7, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, " a", image.Point{1, 0})
+				testdraw.MustText(c, "█", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustText(c, " b", image.Point{5, 0})
+				testdraw.MustText(c, "█", image.Point{4, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorGreen)))
+
+				testdraw.MustRectangle(c, image.Rect(0, 2, 1, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 1, 1, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "setting values replaces stacked bars",
+			opts: []Option{
+				Char('o'),
+				BarWidth(1),
+				SegmentLabels([]string{"a"}),
+			},
+			update: func(bc *BarChart) error {
+				if err := bc.StackedValues([][]int{{1, 1}}, 2); err != nil {
+					return err
+				}
+				return bc.Values([]int{2}, 2)
+			},
+			canvas: image.Rect(0, 0, 3, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the Y axis with labels at round intervals",
+			opts: []Option{
+				Char('o'),
+				ShowYAxis(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 8, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for row := 0; row < 5; row++ {
+					testcanvas.MustSetCell(c, image.Point{2, row}, '│')
+				}
+				testcanvas.MustSetCell(c, image.Point{2, 0}, '┤')
+				testcanvas.MustSetCell(c, image.Point{2, 2}, '┤')
+				testcanvas.MustSetCell(c, image.Point{2, 4}, '┤')
+				testdraw.MustText(c, "10", image.Point{0, 0})
+				testdraw.MustText(c, "5", image.Point{1, 2})
+				testdraw.MustText(c, "0", image.Point{1, 4})
+
+				testdraw.MustRectangle(c, image.Rect(3, 3, 5, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(6, 0, 8, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the Y axis with formatted labels and bar labels",
+			opts: []Option{
+				Char('o'),
+				ShowYAxis(),
+				ValueFormatter(func(v int) string {
+					return fmt.Sprintf("%dk", v)
+				}),
+				Labels([]string{"a"}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{4}, 4)
+			},
+			canvas: image.Rect(0, 0, 6, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for row := 0; row < 3; row++ {
+					testcanvas.MustSetCell(c, image.Point{2, row}, '│')
+				}
+				testcanvas.MustSetCell(c, image.Point{2, 0}, '┤')
+				testcanvas.MustSetCell(c, image.Point{2, 2}, '┤')
+				testdraw.MustText(c, "4k", image.Point{0, 0})
+				testdraw.MustText(c, "0k", image.Point{0, 2})
+
+				testdraw.MustRectangle(c, image.Rect(3, 0, 6, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "a", image.Point{4, 3}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the legend of stacked bars with the Y axis",
+			opts: []Option{
+				Char('o'),
+				BarWidth(1),
+				ShowYAxis(),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+				}),
+				SegmentLabels([]string{"alpha", "beta"}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 1}}, 2)
+			},
+			canvas: image.Rect(0, 0, 14, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, " alpha", image.Point{1, 0})
+				testdraw.MustText(c, "█", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustText(c, " beta", image.Point{9, 0})
+				testdraw.MustText(c, "█", image.Point{8, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorGreen)))
+
+				for row := 1; row < 4; row++ {
+					testcanvas.MustSetCell(c, image.Point{1, row}, '│')
+				}
+				testcanvas.MustSetCell(c, image.Point{1, 1}, '┤')
+				testcanvas.MustSetCell(c, image.Point{1, 3}, '┤')
+				testdraw.MustText(c, "2", image.Point{0, 1})
+				testdraw.MustText(c, "0", image.Point{0, 3})
+
+				testdraw.MustRectangle(c, image.Rect(2, 3, 3, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 1, 3, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws resize needed character when the Y axis doesn't fit",
+			opts: []Option{
+				Char('o'),
+				ShowYAxis(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -615,6 +968,200 @@ func TestBarChart(t *testing.T) {
 	}
 }
 
+func TestAnimate(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc string
+		opts []Option
+		// update updates the bar chart, setNow sets the current time.
+		update func(bc *BarChart, setNow func(time.Duration)) error
+		// drawAt is the time since start when the bar chart is drawn.
+		drawAt time.Duration
+		// want sets the values of a bar chart without animation that the
+		// drawn bar chart should match.
+		want func(bc *BarChart) error
+	}{
+		{
+			desc: "jumps to the new values without animation",
+			update: func(bc *BarChart, setNow func(time.Duration)) error {
+				return bc.Values([]int{4, 8}, 10)
+			},
+			drawAt: 500 * time.Millisecond,
+			want: func(bc *BarChart) error {
+				return bc.Values([]int{4, 8}, 10)
+			},
+		},
+		{
+			desc: "first values animate from zero",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(bc *BarChart, setNow func(time.Duration)) error {
+				return bc.Values([]int{4, 8}, 10)
+			},
+			drawAt: 500 * time.Millisecond,
+			want: func(bc *BarChart) error {
+				return bc.Values([]int{2, 4}, 10)
+			},
+		},
+		{
+			desc: "draws the new values once the animation completes",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(bc *BarChart, setNow func(time.Duration)) error {
+				return bc.Values([]int{4, 8}, 10)
+			},
+			drawAt: 2 * time.Second,
+			want: func(bc *BarChart) error {
+				return bc.Values([]int{4, 8}, 10)
+			},
+		},
+		{
+			desc: "animates from the previous values",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(bc *BarChart, setNow func(time.Duration)) error {
+				if err := bc.Values([]int{2, 8}, 10); err != nil {
+					return err
+				}
+				setNow(time.Second)
+				return bc.Values([]int{6, 4}, 10)
+			},
+			drawAt: 1500 * time.Millisecond,
+			want: func(bc *BarChart) error {
+				return bc.Values([]int{4, 6}, 10)
+			},
+		},
+		{
+			desc: "retargets from the drawn values mid-animation",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(bc *BarChart, setNow func(time.Duration)) error {
+				if err := bc.Values([]int{4, 8}, 10); err != nil {
+					return err
+				}
+				setNow(500 * time.Millisecond)
+				return bc.Values([]int{10, 0}, 10)
+			},
+			drawAt: time.Second,
+			want: func(bc *BarChart) error {
+				return bc.Values([]int{6, 2}, 10)
+			},
+		},
+		{
+			desc: "added bars grow from zero",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(bc *BarChart, setNow func(time.Duration)) error {
+				if err := bc.Values([]int{4}, 10); err != nil {
+					return err
+				}
+				setNow(time.Second)
+				return bc.Values([]int{4, 8}, 10)
+			},
+			drawAt: 1500 * time.Millisecond,
+			want: func(bc *BarChart) error {
+				return bc.Values([]int{4, 4}, 10)
+			},
+		},
+		{
+			desc: "scales the segments of stacked bars",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(bc *BarChart, setNow func(time.Duration)) error {
+				return bc.StackedValues([][]int{{4, 2}, {2, 6}}, 10)
+			},
+			drawAt: 500 * time.Millisecond,
+			want: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{2, 1}, {1, 3}}, 10)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ar := image.Rect(0, 0, 5, 10)
+			segColors := SegmentColors([]cell.Color{cell.ColorRed, cell.ColorBlue})
+			bc, err := New(append(tc.opts, segColors)...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			now := start
+			bc.now = func() time.Time { return now }
+			setNow := func(since time.Duration) {
+				now = start.Add(since)
+			}
+
+			if err := tc.update(bc, setNow); err != nil {
+				t.Fatalf("update => unexpected error: %v", err)
+			}
+			setNow(tc.drawAt)
+			c := testcanvas.MustNew(ar)
+			if err := bc.Draw(c); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			got := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(c, got)
+
+			wantBC, err := New(segColors)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := tc.want(wantBC); err != nil {
+				t.Fatalf("want => unexpected error: %v", err)
+			}
+			wantC := testcanvas.MustNew(ar)
+			if err := wantBC.Draw(wantC); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			want := faketerm.MustNew(wantC.Size())
+			testcanvas.MustApply(wantC, want)
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	bc, err := New(Animate(time.Second))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	bc.now = func() time.Time { return start }
+
+	notified := make(chan struct{}, 10)
+	bc.SetNotify(func() { notified <- struct{}{} })
+
+	if err := bc.Values([]int{4, 8}, 10); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+	select {
+	case <-notified:
+	default:
+		t.Fatalf("Values => didn't request a redraw")
+	}
+
+	// Drawing mid-animation requests the next frame.
+	bc.now = func() time.Time { return start.Add(500 * time.Millisecond) }
+	if err := bc.Draw(testcanvas.MustNew(image.Rect(0, 0, 5, 10))); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Draw => didn't request a redraw during the animation")
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc   string
@@ -740,3 +1287,29 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestNiceStep(t *testing.T) {
+	tests := []struct {
+		max       int
+		intervals int
+		want      int
+	}{
+		{max: 10, intervals: 0, want: 10},
+		{max: 10, intervals: 2, want: 5},
+		{max: 10, intervals: 3, want: 5},
+		{max: 10, intervals: 10, want: 1},
+		{max: 10, intervals: 20, want: 1},
+		{max: 100, intervals: 4, want: 50},
+		{max: 100, intervals: 6, want: 20},
+		{max: 7, intervals: 1, want: 10},
+		{max: 1500, intervals: 5, want: 500},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("max:%d intervals:%d", tc.max, tc.intervals), func(t *testing.T) {
+			if got := niceStep(tc.max, tc.intervals); got != tc.want {
+				t.Errorf("niceStep(%d, %d) => %d, want %d", tc.max, tc.intervals, got, tc.want)
+			}
+		})
+	}
+}
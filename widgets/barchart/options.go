@@ -18,6 +18,7 @@ package barchart
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/draw"
@@ -47,6 +48,14 @@ type options struct {
 	labelColors []cell.Color
 	valueColors []cell.Color
 	labels      []string
+	valueFormat func(int) string
+	showYAxis   bool
+
+	segmentColors []cell.Color
+	segmentLabels []string
+
+	// animation is the duration of the transition between values.
+	animation time.Duration
 }
 
 // validate validates the provided options.
@@ -57,6 +66,9 @@ func (o *options) validate() error {
 	if got, min := o.barGap, 0; got < min {
 		return fmt.Errorf("invalid BarGap %d, must be %d <= BarGap", got, min)
 	}
+	if o.animation < 0 {
+		return fmt.Errorf("invalid animation duration %v, must be zero or positive", o.animation)
+	}
 	return nil
 }
 
@@ -107,6 +119,27 @@ func ShowValues() Option {
 	})
 }
 
+// ValueFormatter sets a function that formats the values displayed inside the
+// bars when the ShowValues option is provided, e.g. to display "1.2k" instead
+// of "1200". Defaults to formatting the values as plain integers.
+// Values that don't fit the width of the bar are trimmed.
+func ValueFormatter(fn func(int) string) Option {
+	return option(func(opts *options) {
+		opts.valueFormat = fn
+	})
+}
+
+// ShowYAxis tells the bar chart to draw a value axis on the left side of the
+// bars. The axis has tick marks labeled with values at round intervals for
+// the range from zero to the maximum value, the labels are formatted by the
+// ValueFormatter if provided. The axis reduces the width available for the
+// bars.
+func ShowYAxis() Option {
+	return option(func(opts *options) {
+		opts.showYAxis = true
+	})
+}
+
 // DefaultBarColor is the default color of a bar, unless specified otherwise
 // via the BarColors option.
 const DefaultBarColor = cell.ColorRed
@@ -160,3 +193,40 @@ func ValueColors(colors []cell.Color) Option {
 		opts.valueColors = colors
 	})
 }
+
+// SegmentColors sets the colors of the segments of stacked bars, i.e. bars
+// created on a call to StackedValues(). The first supplied color applies to
+// the first (bottom) segment of each bar. Any segments that don't have a color
+// specified use the DefaultBarColor.
+// The BarColors option doesn't apply to stacked bars.
+func SegmentColors(colors []cell.Color) Option {
+	return option(func(opts *options) {
+		opts.segmentColors = colors
+	})
+}
+
+// SegmentLabels sets the labels of the segments of stacked bars, i.e. bars
+// created on a call to StackedValues(). If provided, the BarChart draws a
+// legend on its first line that maps each label to the color of the segment,
+// the first label describes the first (bottom) segment of each bar.
+// The legend is trimmed if it doesn't fit the width of the canvas.
+func SegmentLabels(labels []string) Option {
+	return option(func(opts *options) {
+		opts.segmentLabels = labels
+	})
+}
+
+// Animate makes the bars grow or shrink smoothly from the previously set
+// values to the new ones over the specified duration instead of jumping to
+// them. Setting values while a transition is in progress starts a new
+// transition from the currently drawn bars.
+// While the transition is in progress, the BarChart requests up to 25 redraws
+// per second from the dashboard. The values displayed by the ShowValues option
+// and the Y axis always show the new values.
+// Zero disables the animation, this is the default. The duration must not be
+// negative.
+func Animate(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.animation = d
+	})
+}
@@ -147,11 +147,12 @@ func (b *Button) Draw(cvs *canvas.Canvas) error {
 	if err != nil {
 		return err
 	}
-	return draw.Text(cvs, b.text, start,
+	_, err = draw.Text(cvs, b.text, start,
 		draw.TextOverrunMode(draw.OverrunModeThreeDot),
 		draw.TextMaxX(buttonAr.Max.X),
 		draw.TextCellOpts(cell.FgColor(b.opts.textColor)),
 	)
+	return err
 }
 
 // Keyboard processes keyboard events, acts as a button press on the configured
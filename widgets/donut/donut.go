@@ -21,9 +21,11 @@ import (
 	"fmt"
 	"image"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/internal/alignfor"
+	"github.com/mum4k/termdash/internal/animation"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/braille"
 	"github.com/mum4k/termdash/internal/draw"
@@ -72,6 +74,19 @@ type Donut struct {
 	// mu protects the Donut.
 	mu sync.Mutex
 
+	// animFrom is the progress as a fraction of the total that was drawn when
+	// the current transition started.
+	animFrom float64
+	// animStart is the time when the current transition started.
+	animStart time.Time
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+	// frames requests redraws while a transition is in progress.
+	frames animation.Frames
+
+	// notify requests a redraw of the terminal, nil until SetNotify is called.
+	notify func()
+
 	// opts are the provided options.
 	opts *options
 }
@@ -87,6 +102,7 @@ func New(opts ...Option) (*Donut, error) {
 	}
 	return &Donut{
 		opts: opt,
+		now:  time.Now,
 	}, nil
 }
 
@@ -110,9 +126,11 @@ func (d *Donut) Absolute(done, total int, opts ...Option) error {
 		return err
 	}
 
+	d.startAnimation()
 	d.pt = progressTypeAbsolute
 	d.current = done
 	d.total = total
+	d.notifyChanged()
 	return nil
 }
 
@@ -134,12 +152,56 @@ func (d *Donut) Percent(p int, opts ...Option) error {
 		return err
 	}
 
+	d.startAnimation()
 	d.pt = progressTypePercent
 	d.current = p
 	d.total = 100
+	d.notifyChanged()
 	return nil
 }
 
+// SetNotify implements widgetapi.Notifier.SetNotify.
+// The widget requests a redraw each time the progress changes.
+func (d *Donut) SetNotify(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.notify = fn
+}
+
+// notifyChanged requests a redraw of the terminal if SetNotify was called.
+// Caller must hold d.mu.
+func (d *Donut) notifyChanged() {
+	if d.notify != nil {
+		d.notify()
+	}
+}
+
+// startAnimation starts a transition from the currently drawn progress.
+// Must be called before the new progress is recorded.
+func (d *Donut) startAnimation() {
+	now := d.now()
+	d.animFrom = d.drawnFraction(now)
+	d.animStart = now
+}
+
+// targetFraction returns the current progress as a fraction of the total.
+func (d *Donut) targetFraction() float64 {
+	if d.total == 0 {
+		return 0
+	}
+	return float64(d.current) / float64(d.total)
+}
+
+// drawnFraction returns the progress as a fraction of the total that should
+// be drawn at the specified time.
+func (d *Donut) drawnFraction(now time.Time) float64 {
+	progress := animation.Progress(d.animStart, now, d.opts.animation)
+	return animation.Interpolate(d.animFrom, d.targetFraction(), progress)
+}
+
+// animSteps is the total used when drawing the donut during a transition.
+const animSteps = 1000
+
 // progressText returns the textual representation of the current progress.
 func (d *Donut) progressText() string {
 	switch d.pt {
@@ -178,7 +240,7 @@ func (d *Donut) drawText(cvs *canvas.Canvas, mid image.Point, holeR int) error {
 	if err != nil {
 		return fmt.Errorf("alignfor.Text => %v", err)
 	}
-	if err := draw.Text(cvs, t, start, draw.TextMaxX(start.X+needCells), draw.TextCellOpts(d.opts.textCellOpts...)); err != nil {
+	if _, err := draw.Text(cvs, t, start, draw.TextMaxX(start.X+needCells), draw.TextCellOpts(d.opts.textCellOpts...)); err != nil {
 		return fmt.Errorf("draw.Text => %v", err)
 	}
 	return nil
@@ -195,7 +257,12 @@ func (d *Donut) Draw(cvs *canvas.Canvas) error {
 		return fmt.Errorf("braille.New => %v", err)
 	}
 
-	startA, endA := startEndAngles(d.current, d.total, d.opts.startAngle, d.opts.direction)
+	current, total := d.current, d.total
+	if f := d.drawnFraction(d.now()); f != d.targetFraction() {
+		current, total = int(numbers.Round(f*animSteps)), animSteps
+		d.frames.Schedule(d.notify)
+	}
+	startA, endA := startEndAngles(current, total, d.opts.startAngle, d.opts.direction)
 	if startA == endA {
 		// No progress recorded, so nothing to do.
 		return nil
@@ -17,6 +17,7 @@ package donut
 import (
 	"image"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
@@ -57,6 +58,14 @@ func TestDonut(t *testing.T) {
 			canvas:     image.Rect(0, 0, 3, 3),
 			wantNewErr: true,
 		},
+		{
+			desc: "New fails on negative animation duration",
+			opts: []Option{
+				Animate(-1 * time.Second),
+			},
+			canvas:     image.Rect(0, 0, 3, 3),
+			wantNewErr: true,
+		},
 		{
 			desc: "New fails on too small start angle",
 			opts: []Option{
@@ -635,6 +644,126 @@ func TestDonut(t *testing.T) {
 	}
 }
 
+func TestAnimate(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc string
+		opts []Option
+		// update updates the donut, setNow sets the current time.
+		update func(d *Donut, setNow func(time.Duration)) error
+		// drawAt is the time since start when the donut is drawn.
+		drawAt time.Duration
+		// wantPercent is the progress the drawn donut should match.
+		wantPercent int
+	}{
+		{
+			desc: "jumps to the new value without animation",
+			update: func(d *Donut, setNow func(time.Duration)) error {
+				return d.Percent(20)
+			},
+			drawAt:      500 * time.Millisecond,
+			wantPercent: 20,
+		},
+		{
+			desc: "first value animates from zero",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(d *Donut, setNow func(time.Duration)) error {
+				return d.Percent(20)
+			},
+			drawAt:      500 * time.Millisecond,
+			wantPercent: 10,
+		},
+		{
+			desc: "draws the new value once the animation completes",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(d *Donut, setNow func(time.Duration)) error {
+				return d.Percent(20)
+			},
+			drawAt:      2 * time.Second,
+			wantPercent: 20,
+		},
+		{
+			desc: "animates from the previous value",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(d *Donut, setNow func(time.Duration)) error {
+				if err := d.Percent(20); err != nil {
+					return err
+				}
+				setNow(time.Second)
+				return d.Absolute(8, 10)
+			},
+			drawAt:      1250 * time.Millisecond,
+			wantPercent: 35,
+		},
+		{
+			desc: "retargets from the drawn value mid-animation",
+			opts: []Option{
+				Animate(time.Second),
+			},
+			update: func(d *Donut, setNow func(time.Duration)) error {
+				if err := d.Percent(20); err != nil {
+					return err
+				}
+				setNow(500 * time.Millisecond)
+				return d.Percent(80)
+			},
+			drawAt:      time.Second,
+			wantPercent: 45,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ar := image.Rect(0, 0, 15, 15)
+			d, err := New(append(tc.opts, HideTextProgress())...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			now := start
+			d.now = func() time.Time { return now }
+			setNow := func(since time.Duration) {
+				now = start.Add(since)
+			}
+
+			if err := tc.update(d, setNow); err != nil {
+				t.Fatalf("update => unexpected error: %v", err)
+			}
+			setNow(tc.drawAt)
+			c := testcanvas.MustNew(ar)
+			if err := d.Draw(c); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			got := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(c, got)
+
+			wantD, err := New(HideTextProgress())
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := wantD.Percent(tc.wantPercent); err != nil {
+				t.Fatalf("Percent => unexpected error: %v", err)
+			}
+			wantC := testcanvas.MustNew(ar)
+			if err := wantD.Draw(wantC); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			want := faketerm.MustNew(wantC.Size())
+			testcanvas.MustApply(wantC, want)
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
 func TestKeyboard(t *testing.T) {
 	d, err := New()
 	if err != nil {
@@ -18,6 +18,7 @@ package donut
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 )
@@ -49,6 +50,9 @@ type options struct {
 	// The direction in which the donut completes as progress increases.
 	// Positive for counter-clockwise, negative for clockwise.
 	direction int
+
+	// animation is the duration of the transition between progress values.
+	animation time.Duration
 }
 
 // validate validates the provided options.
@@ -61,6 +65,10 @@ func (o *options) validate() error {
 		return fmt.Errorf("invalid start angle %d, must be in range %d <= angle < %d", o.startAngle, min, max)
 	}
 
+	if o.animation < 0 {
+		return fmt.Errorf("invalid animation duration %v, must be zero or positive", o.animation)
+	}
+
 	return nil
 }
 
@@ -157,3 +165,19 @@ func CounterClockwise() Option {
 		opts.direction = 1
 	})
 }
+
+// Animate makes the donut transition smoothly from the previously set progress
+// to the new one over the specified duration instead of jumping to it.
+// Setting the progress while a transition is in progress starts a new
+// transition from the currently drawn progress.
+// While the transition is in progress, the donut requests up to 25 redraws
+// per second from the dashboard. The transition otherwise only advances when
+// the donut gets redrawn, see termdash.RedrawInterval.
+// The text progress always shows the new value.
+// Zero disables the animation, this is the default. The duration must not be
+// negative.
+func Animate(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.animation = d
+	})
+}
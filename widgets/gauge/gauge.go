@@ -21,9 +21,11 @@ import (
 	"fmt"
 	"image"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/alignfor"
+	"github.com/mum4k/termdash/internal/animation"
 	"github.com/mum4k/termdash/internal/area"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/draw"
@@ -73,6 +75,18 @@ type Gauge struct {
 	// mu protects the Gauge.
 	mu sync.Mutex
 
+	// bandStart is the time when the band was first drawn in the
+	// indeterminate mode, determines the position of the moving band. Zero
+	// until the band is drawn.
+	bandStart time.Time
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+	// frames requests redraws while the band moves.
+	frames animation.Frames
+
+	// notify requests a redraw of the terminal, nil until SetNotify is called.
+	notify func()
+
 	// opts are the provided options.
 	opts *options
 }
@@ -89,53 +103,104 @@ func New(opts ...Option) (*Gauge, error) {
 
 	return &Gauge{
 		opts: opt,
+		now:  time.Now,
 	}, nil
 }
 
+// withOptions returns a copy of the current options with the provided options
+// applied. Setting the progress switches the Gauge out of the indeterminate
+// mode, unless the Indeterminate option is provided.
+// Caller must hold g.mu.
+func (g *Gauge) withOptions(opts []Option) *options {
+	o := *g.opts
+	o.indeterminate = false
+	for _, opt := range opts {
+		opt.set(&o)
+	}
+	return &o
+}
+
 // Absolute sets the progress in absolute numbers, i.e. 7 out of 10.
 // The total amount must be a non-zero positive integer. The done amount must
-// be a zero or a positive integer such that done <= total.
+// be a zero or a positive integer such that done <= total, or in the
+// BaselineCenter mode an integer such that -total <= done <= total.
 // Provided options override values set when New() was called.
 func (g *Gauge) Absolute(done, total int, opts ...Option) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	if done < 0 || total < 1 || done > total {
+	o := g.withOptions(opts)
+	if o.baselineCenter {
+		if total < 1 || done < -total || done > total {
+			return fmt.Errorf("invalid progress, done(%d) must be -total <= done <= total(%d) in the BaselineCenter mode "+
+				"and total must be a non-zero positive number", done, total)
+		}
+	} else if done < 0 || total < 1 || done > total {
 		return fmt.Errorf("invalid progress, done(%d) must be <= total(%d), done must be zero or positive "+
 			"and total must be a non-zero positive number", done, total)
 	}
 
-	for _, opt := range opts {
-		opt.set(g.opts)
-	}
-
+	g.setOptions(o)
 	g.pt = progressTypeAbsolute
 	g.current = done
 	g.total = total
+	g.notifyChanged()
 	return nil
 }
 
 // Percent sets the current progress in percentage.
-// The provided value must be between 0 and 100.
+// The provided value must be between 0 and 100, or between -100 and 100 in the
+// BaselineCenter mode.
 // Provided options override values set when New() was called.
 func (g *Gauge) Percent(p int, opts ...Option) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	if p < 0 || p > 100 {
+	o := g.withOptions(opts)
+	if o.baselineCenter {
+		if p < -100 || p > 100 {
+			return fmt.Errorf("invalid percentage, p(%d) must be -100 <= p <= 100 in the BaselineCenter mode", p)
+		}
+	} else if p < 0 || p > 100 {
 		return fmt.Errorf("invalid percentage, p(%d) must be 0 <= p <= 100", p)
 	}
 
-	for _, opt := range opts {
-		opt.set(g.opts)
-	}
-
+	g.setOptions(o)
 	g.pt = progressTypePercent
 	g.current = p
 	g.total = 100
+	g.notifyChanged()
 	return nil
 }
 
+// setOptions replaces the options of the Gauge. Leaving the indeterminate mode
+// resets the band, so that it starts at the left edge when the mode is entered
+// again.
+// Caller must hold g.mu.
+func (g *Gauge) setOptions(o *options) {
+	if !o.indeterminate {
+		g.bandStart = time.Time{}
+	}
+	g.opts = o
+}
+
+// SetNotify implements widgetapi.Notifier.SetNotify.
+// The widget requests a redraw each time the progress changes and while the
+// band moves in the indeterminate mode.
+func (g *Gauge) SetNotify(fn func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.notify = fn
+}
+
+// notifyChanged requests a redraw of the terminal if SetNotify was called.
+// Caller must hold g.mu.
+func (g *Gauge) notifyChanged() {
+	if g.notify != nil {
+		g.notify()
+	}
+}
+
 // width determines the required width of the gauge drawn on the provided area
 // in order to represent the current progress.
 func (g *Gauge) width(ar image.Rectangle) int {
@@ -144,6 +209,61 @@ func (g *Gauge) width(ar image.Rectangle) int {
 	return int(width)
 }
 
+// progressArea determines the area of the bar that is filled up to represent
+// the current progress. In the BaselineCenter mode, each half of the bar
+// represents the full range of the values with one sign.
+func (g *Gauge) progressArea(bar image.Rectangle) image.Rectangle {
+	if !g.opts.baselineCenter {
+		return image.Rect(bar.Min.X, bar.Min.Y, bar.Min.X+g.width(bar), bar.Max.Y)
+	}
+
+	half := image.Rect(0, 0, bar.Dx()/2, bar.Dy())
+	if g.current < 0 {
+		w := int(float32(half.Dx()) * float32(-g.current) / float32(g.total))
+		start := bar.Min.X + half.Dx()
+		return image.Rect(start-w, bar.Min.Y, start, bar.Max.Y)
+	}
+	start := bar.Max.X - half.Dx()
+	return image.Rect(start, bar.Min.Y, start+g.width(half), bar.Max.Y)
+}
+
+// fillColor returns the color of the filled up part of the gauge.
+func (g *Gauge) fillColor() cell.Color {
+	if g.opts.baselineCenter && g.current < 0 && !g.opts.indeterminate {
+		return g.opts.negativeColor
+	}
+	return g.opts.color
+}
+
+// bandStepInterval is the time it takes the band in the indeterminate mode to
+// move by one cell.
+const bandStepInterval = 100 * time.Millisecond
+
+// band determines the area of the band drawn on the provided area at the
+// specified time in the indeterminate mode. The band bounces between the edges
+// of the area, moving by one cell each bandStepInterval.
+// Caller must hold g.mu.
+func (g *Gauge) band(ar image.Rectangle, now time.Time) image.Rectangle {
+	if g.bandStart.IsZero() {
+		g.bandStart = now
+	}
+	step := int(now.Sub(g.bandStart) / bandStepInterval)
+
+	width := ar.Dx() / 4
+	if width < 1 {
+		width = 1
+	}
+
+	var pos int
+	if maxPos := ar.Dx() - width; maxPos > 0 {
+		pos = step % (2 * maxPos)
+		if pos > maxPos {
+			pos = 2*maxPos - pos
+		}
+	}
+	return image.Rect(ar.Min.X+pos, ar.Min.Y, ar.Min.X+pos+width, ar.Max.Y)
+}
+
 // hasBorder determines of the gauge has a border.
 func (g *Gauge) hasBorder() bool {
 	return g.opts.border != linestyle.None
@@ -159,9 +279,12 @@ func (g *Gauge) usable(cvs *canvas.Canvas) image.Rectangle {
 
 // progressText returns the textual representation of the current progress.
 func (g *Gauge) progressText() string {
-	if g.opts.hideTextProgress {
+	if g.opts.hideTextProgress || g.opts.indeterminate {
 		return ""
 	}
+	if g.opts.progressFormat != nil {
+		return g.opts.progressFormat(g.current, g.total)
+	}
 
 	switch g.pt {
 	case progressTypePercent:
@@ -187,14 +310,37 @@ func (g *Gauge) gaugeText() string {
 	return b.String()
 }
 
-// drawText draws the text enumerating the progress and the text label.
-func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
+// textOutside determines if the text is drawn on a separate line outside of
+// the bar.
+func (g *Gauge) textOutside() bool {
+	return g.opts.textPosition != TextPositionInside
+}
+
+// layout splits the usable area into the area for the bar and the area for
+// the text. The areas are the same unless the text is drawn outside of the
+// bar.
+func (g *Gauge) layout(usable image.Rectangle) (bar, text image.Rectangle) {
+	switch g.opts.textPosition {
+	case TextPositionAbove:
+		text = image.Rect(usable.Min.X, usable.Min.Y, usable.Max.X, usable.Min.Y+1)
+		bar = image.Rect(usable.Min.X, usable.Min.Y+1, usable.Max.X, usable.Max.Y)
+	case TextPositionBelow:
+		text = image.Rect(usable.Min.X, usable.Max.Y-1, usable.Max.X, usable.Max.Y)
+		bar = image.Rect(usable.Min.X, usable.Min.Y, usable.Max.X, usable.Max.Y-1)
+	default:
+		bar, text = usable, usable
+	}
+	return bar, text
+}
+
+// drawText draws the text enumerating the progress and the text label within
+// the provided area.
+func (g *Gauge) drawText(cvs *canvas.Canvas, ar, progress image.Rectangle) error {
 	text := g.gaugeText()
 	if text == "" {
 		return nil
 	}
 
-	ar := g.usable(cvs)
 	trimmed, err := draw.TrimText(text, ar.Dx(), draw.OverrunModeThreeDot)
 	if err != nil {
 		return err
@@ -224,7 +370,7 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 			)
 			if err := draw.Rectangle(cvs, fixup,
 				draw.RectChar(g.opts.gaugeChar),
-				draw.RectCellOpts(cell.BgColor(g.opts.color)),
+				draw.RectCellOpts(cell.BgColor(g.fillColor())),
 			); err != nil {
 				return err
 			}
@@ -273,22 +419,27 @@ func (g *Gauge) Draw(cvs *canvas.Canvas) error {
 		}
 	}
 
-	usable := g.usable(cvs)
-	progress := image.Rect(
-		usable.Min.X,
-		usable.Min.Y,
-		usable.Min.X+g.width(usable),
-		usable.Max.Y,
-	)
+	bar, textAr := g.layout(g.usable(cvs))
+	var progress image.Rectangle
+	if g.opts.indeterminate {
+		progress = g.band(bar, g.now())
+		g.frames.Schedule(g.notify)
+	} else {
+		progress = g.progressArea(bar)
+	}
 	if progress.Dx() > 0 {
 		if err := draw.Rectangle(cvs, progress,
 			draw.RectChar(g.opts.gaugeChar),
-			draw.RectCellOpts(cell.BgColor(g.opts.color)),
+			draw.RectCellOpts(cell.BgColor(g.fillColor())),
 		); err != nil {
 			return err
 		}
 	}
-	return g.drawText(cvs, progress)
+	if g.textOutside() {
+		// The text doesn't overlap the progress.
+		return g.drawText(cvs, textAr, image.ZR)
+	}
+	return g.drawText(cvs, textAr, progress)
 }
 
 // Keyboard input isn't supported on the Gauge widget.
@@ -304,6 +455,9 @@ func (g *Gauge) Mouse(m *terminalapi.Mouse) error {
 // maxSize determines the maximum size of the canvas.
 func (g *Gauge) maxSize() image.Point {
 	maxHeight := g.opts.height
+	if maxHeight > 0 && g.textOutside() {
+		maxHeight++ // One line for the text.
+	}
 	if g.hasBorder() {
 		// Add the required space for the border.
 		maxHeight += 2
@@ -315,6 +469,9 @@ func (g *Gauge) maxSize() image.Point {
 func (g *Gauge) minSize() image.Point {
 	minWidth := 1  // Shorter gauge than this cannot display anything.
 	minHeight := 1 // At least one line for the gauge itself.
+	if g.textOutside() {
+		minHeight++ // One line for the text.
+	}
 	if g.hasBorder() {
 		// Add the required space for the border.
 		minWidth += 2
@@ -15,12 +15,15 @@
 package gauge
 
 import (
+	"fmt"
 	"image"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/animation"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/draw"
@@ -741,6 +744,361 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "fails on nil theme",
+			opts: []Option{
+				WithTheme(nil),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "uses colors from the theme",
+			opts: []Option{
+				Char('o'),
+				Border(linestyle.Light),
+				WithTheme(&cell.Theme{
+					Border: cell.Options{FgColor: cell.ColorBlue},
+					Text:   cell.Options{FgColor: cell.ColorWhite},
+					Accent: cell.Options{FgColor: cell.ColorRed},
+				}),
+			},
+			percent: &percentCall{p: 35},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, image.Rect(0, 0, 10, 3),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 1, 3, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testdraw.MustText(c, "35%", image.Point{3, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorWhite)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "border after the theme overrides its border colors",
+			opts: []Option{
+				Char('o'),
+				WithTheme(&cell.Theme{
+					Border: cell.Options{FgColor: cell.ColorBlue},
+				}),
+				Border(linestyle.Light, cell.FgColor(cell.ColorGreen)),
+			},
+			percent: &percentCall{p: 35},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, image.Rect(0, 0, 10, 3),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 1, 3, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorDefault)),
+				)
+				testdraw.MustText(c, "35%", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "options after the theme override its colors",
+			opts: []Option{
+				Char('o'),
+				WithTheme(&cell.Theme{
+					Accent: cell.Options{FgColor: cell.ColorRed},
+				}),
+				Color(cell.ColorYellow),
+			},
+			percent: &percentCall{p: 35},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testdraw.MustText(c, "35%", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "gauge with custom progress formatter",
+			opts: []Option{
+				Char('o'),
+				ProgressFormatter(func(current, total int) string {
+					return fmt.Sprintf("%d/%d MB", current, total)
+				}),
+			},
+			absolute: &absoluteCall{done: 20, total: 100},
+			canvas:   image.Rect(0, 0, 12, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "2", image.Point{1, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "0/100 MB", image.Point{2, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom progress formatter receives percentage and text is trimmed",
+			opts: []Option{
+				Char('o'),
+				ProgressFormatter(func(current, total int) string {
+					return fmt.Sprintf("done %d of %d", current, total)
+				}),
+				TextLabel("l"),
+			},
+			percent: &percentCall{p: 0},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "done 0 of…", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom progress formatter has no effect when text progress is hidden",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				ProgressFormatter(func(current, total int) string {
+					return "hidden"
+				}),
+			},
+			percent: &percentCall{p: 0},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "baseline center, zero progress isn't filled",
+			opts: []Option{
+				Char('o'),
+				BaselineCenter(),
+			},
+			percent: &percentCall{p: 0},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "0%", image.Point{4, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "baseline center, positive progress fills from the center to the right",
+			opts: []Option{
+				Char('o'),
+				BaselineCenter(),
+			},
+			percent: &percentCall{p: 100},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(5, 0, 10, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "10", image.Point{3, 1})
+				testdraw.MustText(c, "0%", image.Point{5, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "baseline center, negative progress fills from the center to the left",
+			opts: []Option{
+				Char('o'),
+				BaselineCenter(),
+			},
+			percent: &percentCall{p: -100},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 5, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testdraw.MustText(c, "-10", image.Point{2, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "0%", image.Point{5, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "baseline center, partial negative absolute progress in custom color",
+			opts: []Option{
+				Char('o'),
+				BaselineCenter(),
+				NegativeColor(cell.ColorBlue),
+			},
+			absolute: &absoluteCall{done: -25, total: 50},
+			canvas:   image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(3, 0, 5, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustText(c, "-", image.Point{2, 1})
+				testdraw.MustText(c, "25", image.Point{3, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "/50", image.Point{5, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "baseline center, the middle cell of an odd width bar isn't filled",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 100, opts: []Option{BaselineCenter()}},
+			canvas:  image.Rect(0, 0, 11, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(6, 0, 11, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "baseline center, fails on percentage below -100",
+			opts: []Option{
+				BaselineCenter(),
+			},
+			percent: &percentCall{p: -101},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "baseline center, fails on done below -total",
+			opts: []Option{
+				BaselineCenter(),
+			},
+			absolute: &absoluteCall{done: -51, total: 50},
+			canvas:   image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:    "fails on negative percentage without baseline center",
+			percent: &percentCall{p: -1},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails on invalid text position",
+			opts: []Option{
+				TextPositioning(TextPosition(-1)),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "draws the text above the bar",
+			opts: []Option{
+				Char('o'),
+				TextLabel("l"),
+				TextPositioning(TextPositionAbove),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "50% (l)", image.Point{1, 0})
+				testdraw.MustRectangle(c, image.Rect(0, 1, 5, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the text below the bar in a border, aligned to the left",
+			opts: []Option{
+				Char('o'),
+				Border(linestyle.Light),
+				HorizontalTextAlign(align.HorizontalLeft),
+				TextPositioning(TextPositionBelow),
+			},
+			percent: &percentCall{p: 100},
+			canvas:  image.Rect(0, 0, 10, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, c.Area())
+				testdraw.MustRectangle(c, image.Rect(1, 1, 9, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "100%", image.Point{1, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -843,6 +1201,19 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "text outside of the bar is accounted for in maximum and minimum size",
+			opts: []Option{
+				TextPositioning(TextPositionAbove),
+				Height(2),
+			},
+			want: widgetapi.Options{
+				MaximumSize:  image.Point{0, 3},
+				MinimumSize:  image.Point{1, 2},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -860,3 +1231,248 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestIndeterminate(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		// draws are the times after the first draw at which the gauge is
+		// drawn before the compared draw.
+		draws []time.Duration
+		// elapsed is the time after the first draw at which the compared
+		// draw happens.
+		elapsed time.Duration
+		// update if set is called before the compared draw.
+		update func(*Gauge) error
+		want   func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc: "draws the band at the start",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				TextLabel("wait"),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "(", image.Point{1, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "wait)", image.Point{2, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the band moves with time",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				HideTextProgress(),
+			},
+			draws:   []time.Duration{0},
+			elapsed: 2*bandStepInterval + bandStepInterval/2,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(2, 0, 4, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the band doesn't move with additional draws",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				HideTextProgress(),
+			},
+			draws: []time.Duration{0, 0, 0, bandStepInterval / 2},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the band starts moving at the first draw",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				HideTextProgress(),
+			},
+			elapsed: 10 * bandStepInterval,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the band bounces back at the end",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+			},
+			draws:   []time.Duration{0},
+			elapsed: 7 * bandStepInterval,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(5, 0, 7, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "setting the progress resumes the normal mode",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				HideTextProgress(),
+			},
+			draws:   []time.Duration{0},
+			elapsed: 3 * bandStepInterval,
+			update: func(g *Gauge) error {
+				return g.Percent(50)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 4, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the band starts at the left edge when the mode is entered again",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				HideTextProgress(),
+			},
+			draws:   []time.Duration{0},
+			elapsed: 3 * bandStepInterval,
+			update: func(g *Gauge) error {
+				if err := g.Percent(50); err != nil {
+					return err
+				}
+				return g.Percent(0, Indeterminate())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			ar := image.Rect(0, 0, 8, 1)
+			for _, d := range tc.draws {
+				g.now = func() time.Time { return start.Add(d) }
+				if err := g.Draw(testcanvas.MustNew(ar)); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+			if tc.update != nil {
+				if err := tc.update(g); err != nil {
+					t.Fatalf("update => unexpected error: %v", err)
+				}
+			}
+
+			g.now = func() time.Time { return start.Add(tc.elapsed) }
+			c := testcanvas.MustNew(ar)
+			if err := g.Draw(c); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			got := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(c, got)
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	g, err := New(Indeterminate())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	notified := make(chan struct{}, 10)
+	g.SetNotify(func() { notified <- struct{}{} })
+
+	// Drawing in the indeterminate mode requests the next frame.
+	if err := g.Draw(testcanvas.MustNew(image.Rect(0, 0, 8, 1))); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Draw => didn't request a redraw in the indeterminate mode")
+	}
+
+	if err := g.Percent(50); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	select {
+	case <-notified:
+	default:
+		t.Fatalf("Percent => didn't request a redraw")
+	}
+
+	// Drawing the progress doesn't request further redraws.
+	if err := g.Draw(testcanvas.MustNew(image.Rect(0, 0, 8, 1))); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	select {
+	case <-notified:
+		t.Fatalf("Draw => unexpected redraw request")
+	case <-time.After(5 * animation.FrameInterval):
+	}
+}
@@ -37,9 +37,14 @@ type options struct {
 	hideTextProgress bool
 	height           int
 	textLabel        string
+	progressFormat   func(current, total int) string
+	indeterminate    bool
 	hTextAlign       align.Horizontal
 	vTextAlign       align.Vertical
+	textPosition     TextPosition
 	color            cell.Color
+	negativeColor    cell.Color
+	baselineCenter   bool
 	filledTextColor  cell.Color
 	emptyTextColor   cell.Color
 	// If set, draws a border around the gauge.
@@ -47,6 +52,8 @@ type options struct {
 	borderCellOpts    []cell.Option
 	borderTitle       string
 	borderTitleHAlign align.Horizontal
+	// nilTheme indicates that WithTheme was provided with a nil theme.
+	nilTheme bool
 }
 
 // newOptions returns options with the default values set.
@@ -56,6 +63,7 @@ func newOptions() *options {
 		hTextAlign:      DefaultHorizontalTextAlign,
 		vTextAlign:      DefaultVerticalTextAlign,
 		color:           DefaultColor,
+		negativeColor:   DefaultNegativeColor,
 		filledTextColor: DefaultFilledTextColor,
 		emptyTextColor:  DefaultEmptyTextColor,
 	}
@@ -66,6 +74,12 @@ func (o *options) validate() error {
 	if got, min := o.height, 0; got < min {
 		return fmt.Errorf("invalid Height %d, must be %d <= Height", got, min)
 	}
+	if _, ok := textPositionNames[o.textPosition]; !ok {
+		return fmt.Errorf("invalid TextPositioning %v(%d)", o.textPosition, o.textPosition)
+	}
+	if o.nilTheme {
+		return fmt.Errorf("WithTheme requires a non-nil theme")
+	}
 	return nil
 }
 
@@ -123,16 +137,69 @@ func TextLabel(text string) Option {
 	})
 }
 
+// ProgressFormatter configures the Gauge to display the progress text returned
+// by the provided function instead of the default percentage ("35%") or
+// absolute ("35/50") format. The function is called on each redraw with the
+// current progress and the total that represents completion, i.e. with the
+// values provided to Percent() (and a total of 100) or Absolute().
+// Has no effect if the HideTextProgress() option is provided.
+// The text is aligned and trimmed to fit the Gauge like the default progress
+// text.
+func ProgressFormatter(fn func(current, total int) string) Option {
+	return option(func(opts *options) {
+		opts.progressFormat = fn
+	})
+}
+
+// Indeterminate configures the Gauge to indicate that an operation is in
+// progress without knowing how much of it is done. Instead of the progress,
+// the Gauge draws a band that moves back and forth across it at a constant
+// speed. The Gauge requests the redraws needed to animate the band, see
+// widgetapi.Notifier.
+// The progress text isn't displayed in this mode, the text label is.
+// Calling Percent() or Absolute() switches the Gauge back to displaying the
+// progress, unless this option is provided to the call.
+func Indeterminate() Option {
+	return option(func(opts *options) {
+		opts.indeterminate = true
+	})
+}
+
 // DefaultColor is the default value for the Color option.
 const DefaultColor = cell.ColorGreen
 
 // Color sets the color of the gauge.
+// In the BaselineCenter mode, this is the color of positive progress.
 func Color(c cell.Color) Option {
 	return option(func(opts *options) {
 		opts.color = c
 	})
 }
 
+// DefaultNegativeColor is the default value for the NegativeColor option.
+const DefaultNegativeColor = cell.ColorRed
+
+// NegativeColor sets the color of the gauge when it represents negative
+// progress in the BaselineCenter mode. Has no effect in other modes.
+func NegativeColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.negativeColor = c
+	})
+}
+
+// BaselineCenter configures the Gauge to represent a signed value relative to
+// a baseline in the center of the bar. Positive progress fills the bar from
+// the center to the right in the Color, negative progress fills it from the
+// center to the left in the NegativeColor.
+// In this mode Percent() accepts values in the range -100 <= p <= 100 and
+// Absolute() accepts done values in the range -total <= done <= total. If the
+// bar has an odd width, the middle cell is never filled.
+func BaselineCenter() Option {
+	return option(func(opts *options) {
+		opts.baselineCenter = true
+	})
+}
+
 // DefaultFilledTextColor is the default value for the FilledTextColor option.
 const DefaultFilledTextColor = cell.ColorBlack
 
@@ -179,6 +246,49 @@ func VerticalTextAlign(v align.Vertical) Option {
 	})
 }
 
+// TextPosition indicates where the gauge draws the text progress and the text
+// label relative to the bar.
+type TextPosition int
+
+// String implements fmt.Stringer()
+func (tp TextPosition) String() string {
+	if n, ok := textPositionNames[tp]; ok {
+		return n
+	}
+	return "TextPositionUnknown"
+}
+
+// textPositionNames maps TextPosition values to human readable names.
+var textPositionNames = map[TextPosition]string{
+	TextPositionInside: "TextPositionInside",
+	TextPositionAbove:  "TextPositionAbove",
+	TextPositionBelow:  "TextPositionBelow",
+}
+
+const (
+	// TextPositionInside draws the text inside the bar, aligned according to
+	// HorizontalTextAlign and VerticalTextAlign.
+	TextPositionInside TextPosition = iota
+
+	// TextPositionAbove draws the text on a separate line above the bar.
+	TextPositionAbove
+
+	// TextPositionBelow draws the text on a separate line below the bar.
+	TextPositionBelow
+)
+
+// TextPositioning sets where the gauge draws the text progress and the text
+// label. When the text is drawn above or below the bar, the text takes one
+// line of the gauge, which reduces the height of the bar, and it is
+// horizontally aligned according to HorizontalTextAlign. This is useful for
+// thin gauges where the text wouldn't fit inside the bar.
+// Defaults to TextPositionInside.
+func TextPositioning(tp TextPosition) Option {
+	return option(func(opts *options) {
+		opts.textPosition = tp
+	})
+}
+
 // Border configures the gauge to have a border of the specified style.
 func Border(ls linestyle.LineStyle, cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
@@ -187,6 +297,24 @@ func Border(ls linestyle.LineStyle, cOpts ...cell.Option) Option {
 	})
 }
 
+// WithTheme sets the colors of the gauge from the theme. The gauge is drawn
+// in the foreground color of the theme's Accent role, the text outside of the
+// filled up part uses the foreground color of the Text role and the border (if
+// any) uses the Border role.
+// Options provided after this one override the colors from the theme.
+// The theme must not be nil.
+func WithTheme(t *cell.Theme) Option {
+	return option(func(opts *options) {
+		if t == nil {
+			opts.nilTheme = true
+			return
+		}
+		opts.color = t.Accent.FgColor
+		opts.emptyTextColor = t.Text.FgColor
+		opts.borderCellOpts = t.BorderOpts()
+	})
+}
+
 // BorderTitle sets a text title within the border.
 func BorderTitle(title string) Option {
 	return option(func(opts *options) {
@@ -0,0 +1,126 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// crosshair.go contains code that draws the crosshair and the readout of
+// values under it.
+
+import (
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+// crosshairRune is the rune used to draw the crosshair in cells that don't
+// contain any part of the graph.
+const crosshairRune = '│'
+
+// isEmptyCell determines if the cell doesn't contain any part of the graph.
+func isEmptyCell(r rune) bool {
+	// The braille pattern blank is the rune of a braille cell without any set
+	// pixels.
+	return r == 0 || r == ' ' || r == '⠀'
+}
+
+// crosshairReadout returns the lines of the readout for the specified column
+// of the graph. The column is relative to the start of the graph.
+func (lc *LineChart) crosshairReadout(col int, xd *axes.XDetails, yd *axes.YDetails) ([]string, error) {
+	xv, err := xd.Scale.CellLabel(col)
+	if err != nil {
+		return nil, err
+	}
+	i := int(xv.Value)
+
+	xText := xv.Text()
+	if l, ok := lc.xLabels[i]; ok {
+		xText = l
+	}
+	lines := []string{fmt.Sprintf("x: %s", xText)}
+
+	var names []string
+	for name := range lc.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sv := lc.series[name]
+		if i < 0 || i >= len(sv.values) {
+			continue
+		}
+		v := axes.NewFormattedValue(sv.values[i], yd.Scale.Min.NonZeroDecimals, lc.opts.yAxisFormatter)
+		lines = append(lines, fmt.Sprintf("%s: %s", name, v.Text()))
+	}
+	return lines, nil
+}
+
+// drawCrosshair draws the crosshair and the readout if the last mouse event
+// occurred within the graph area.
+func (lc *LineChart) drawCrosshair(cvs *canvas.Canvas, graphAr image.Rectangle, xd *axes.XDetails, yd *axes.YDetails) error {
+	if !lc.opts.crosshair || lc.crosshairAt == nil || !lc.crosshairAt.In(graphAr) {
+		return nil
+	}
+
+	col := lc.crosshairAt.X
+	for y := graphAr.Min.Y; y < graphAr.Max.Y; y++ {
+		p := image.Point{col, y}
+		r, err := cvs.RuneAt(p)
+		if err != nil {
+			return err
+		}
+		if !isEmptyCell(r) {
+			continue
+		}
+		if _, err := cvs.SetCell(p, crosshairRune, lc.opts.crosshairCellOpts...); err != nil {
+			return err
+		}
+	}
+
+	lines, err := lc.crosshairReadout(col-graphAr.Min.X, xd, yd)
+	if err != nil {
+		return err
+	}
+	var width int
+	for _, l := range lines {
+		if w := runewidth.StringWidth(l); w > width {
+			width = w
+		}
+	}
+	if width > graphAr.Dx() {
+		width = graphAr.Dx()
+	}
+	height := len(lines)
+	if height > graphAr.Dy() {
+		height = graphAr.Dy()
+	}
+
+	box := image.Rect(graphAr.Max.X-width, graphAr.Min.Y, graphAr.Max.X, graphAr.Min.Y+height)
+	if err := cvs.SetAreaCells(box, ' ', lc.opts.crosshairCellOpts...); err != nil {
+		return err
+	}
+	for i, l := range lines[:height] {
+		if _, err := draw.Text(cvs, l, image.Point{box.Min.X, box.Min.Y + i},
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(lc.opts.crosshairCellOpts...),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
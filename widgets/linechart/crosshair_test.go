@@ -0,0 +1,159 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+func TestCrosshair(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		events []*terminalapi.Mouse
+		want   []string
+	}{
+		{
+			desc: "nothing is drawn without the option",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{12, 5}, Button: mouse.ButtonRelease},
+			},
+			want: []string{
+				"     │         ⡰⠑⠤⡀        ",
+				"     │       ⢀⠜   ⠈⠒⢄      ",
+				"     │      ⢀⠎       ⠉⠢⣀   ",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │    ⡔⠁              ⠈",
+				"     │  ⢀⠎                 ",
+				"     │ ⢠⠊                  ",
+				"    0│⡴⠥⠤⠤⠤⠤⠤⠤⠤⠤⠄          ",
+				"     └─────────────────────",
+				"      a         1         2",
+			},
+		},
+		{
+			desc: "draws the crosshair and the readout",
+			opts: []Option{
+				Crosshair(),
+			},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{12, 5}, Button: mouse.ButtonRelease},
+			},
+			want: []string{
+				"     │      │  ⡰⠑x: 1      ",
+				"     │      │⢀⠜  b: 4      ",
+				"     │      ⢀⠎   first: 100",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │    ⡔⠁│             ⠈",
+				"     │  ⢀⠎  │              ",
+				"     │ ⢠⠊   │              ",
+				"    0│⡴⠥⠤⠤⠤⠤⠤⠤⠤⠤⠄          ",
+				"     └─────────────────────",
+				"      a         1         2",
+			},
+		},
+		{
+			desc: "the readout uses custom X labels",
+			opts: []Option{
+				Crosshair(),
+			},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{6, 5}, Button: mouse.ButtonRelease},
+			},
+			want: []string{
+				"     ││        ⡰⠑⠤⡀x: a    ",
+				"     ││      ⢀⠜   ⠈b: 3    ",
+				"     ││     ⢀⠎     first: 0",
+				"51.68││    ⡠⠃           ⠑⠤⡀",
+				"     ││   ⡔⠁              ⠈",
+				"     ││ ⢀⠎                 ",
+				"     ││⢠⠊                  ",
+				"    0│⡴⠥⠤⠤⠤⠤⠤⠤⠤⠤⠄          ",
+				"     └─────────────────────",
+				"      a         1         2",
+			},
+		},
+		{
+			desc: "the crosshair disappears after a mouse event outside of the graph",
+			opts: []Option{
+				Crosshair(),
+			},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{12, 5}, Button: mouse.ButtonRelease},
+				{Position: image.Point{-1, -1}, Button: mouse.ButtonRelease},
+			},
+			want: []string{
+				"     │         ⡰⠑⠤⡀        ",
+				"     │       ⢀⠜   ⠈⠒⢄      ",
+				"     │      ⢀⠎       ⠉⠢⣀   ",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │    ⡔⠁              ⠈",
+				"     │  ⢀⠎                 ",
+				"     │ ⢠⠊                  ",
+				"    0│⡴⠥⠤⠤⠤⠤⠤⠤⠤⠤⠄          ",
+				"     └─────────────────────",
+				"      a         1         2",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := lc.Series("first", []float64{0, 100, 50}); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			if err := lc.Series("b", []float64{3, 4}, SeriesXLabels(map[int]string{0: "a"})); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			ar := image.Rect(0, 0, 27, 10)
+			// Draw once so the position of the graph is known.
+			if err := lc.Draw(testcanvas.MustNew(ar)); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := lc.Mouse(ev); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+
+			c := testcanvas.MustNew(ar)
+			if err := lc.Draw(c); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			ft := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(c, ft)
+
+			var got []string
+			for y := 0; y < ar.Dy(); y++ {
+				got = append(got, ft.CellsAsString(image.Rect(0, y, ar.Dx(), y+1)))
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Draw => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// decimation.go contains code that reduces the number of points that are
+// connected with lines when a series has more values than there are pixels on
+// the X axis.
+
+import (
+	"image"
+)
+
+// DecimationMode determines how are the points of a series reduced before
+// they are connected with lines.
+type DecimationMode int
+
+// String implements fmt.Stringer()
+func (dm DecimationMode) String() string {
+	if n, ok := decimationModeNames[dm]; ok {
+		return n
+	}
+	return "DecimationModeUnknown"
+}
+
+// decimationModeNames maps DecimationMode values to human readable names.
+var decimationModeNames = map[DecimationMode]string{
+	DecimationMinMax: "DecimationMinMax",
+	DecimationNone:   "DecimationNone",
+}
+
+const (
+	// DecimationMinMax keeps at most four points in each braille pixel column
+	// of the graph, the first, the smallest, the largest and the last point
+	// that fall into the column. This preserves the peaks and results in the
+	// same image as connecting all the points.
+	DecimationMinMax DecimationMode = iota
+	// DecimationNone connects all the points of the series.
+	DecimationNone
+)
+
+// decimate reduces the points according to the decimation mode.
+// The points must be ordered by their X coordinate. The returned points keep
+// the original order.
+func decimate(dm DecimationMode, points []image.Point) []image.Point {
+	if dm != DecimationMinMax || len(points) <= 2 {
+		return points
+	}
+
+	var res []image.Point
+	for start := 0; start < len(points); {
+		end := start + 1
+		for end < len(points) && points[end].X == points[start].X {
+			end++
+		}
+		res = append(res, minMaxPoints(points[start:end])...)
+		start = end
+	}
+	return res
+}
+
+// minMaxPoints given points that all share the same X coordinate returns the
+// first, the smallest, the largest and the last of them in their original
+// order and without duplicates.
+func minMaxPoints(points []image.Point) []image.Point {
+	if len(points) <= 4 {
+		return points
+	}
+
+	minIdx, maxIdx := 0, 0
+	for i, p := range points {
+		if p.Y < points[minIdx].Y {
+			minIdx = i
+		}
+		if p.Y > points[maxIdx].Y {
+			maxIdx = i
+		}
+	}
+
+	last := len(points) - 1
+	res := []image.Point{points[0]}
+	for _, i := range sortedUnique(minIdx, maxIdx) {
+		if i != 0 && i != last {
+			res = append(res, points[i])
+		}
+	}
+	return append(res, points[last])
+}
+
+// sortedUnique returns the two indexes in ascending order, only once if they
+// are equal.
+func sortedUnique(a, b int) []int {
+	switch {
+	case a == b:
+		return []int{a}
+	case a < b:
+		return []int{a, b}
+	default:
+		return []int{b, a}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/faketerm"
+)
+
+func TestDecimate(t *testing.T) {
+	tests := []struct {
+		desc   string
+		mode   DecimationMode
+		points []image.Point
+		want   []image.Point
+	}{
+		{
+			desc: "no points",
+			mode: DecimationMinMax,
+		},
+		{
+			desc:   "keeps all points when disabled",
+			mode:   DecimationNone,
+			points: []image.Point{{0, 0}, {0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}},
+			want:   []image.Point{{0, 0}, {0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}},
+		},
+		{
+			desc:   "keeps points that are in separate columns",
+			mode:   DecimationMinMax,
+			points: []image.Point{{0, 3}, {1, 2}, {2, 5}},
+			want:   []image.Point{{0, 3}, {1, 2}, {2, 5}},
+		},
+		{
+			desc:   "keeps up to four points in a column",
+			mode:   DecimationMinMax,
+			points: []image.Point{{0, 3}, {0, 2}, {0, 5}, {0, 4}},
+			want:   []image.Point{{0, 3}, {0, 2}, {0, 5}, {0, 4}},
+		},
+		{
+			desc:   "keeps first, min, max and last in the original order",
+			mode:   DecimationMinMax,
+			points: []image.Point{{0, 3}, {0, 4}, {0, 7}, {0, 5}, {0, 1}, {0, 2}, {0, 4}},
+			want:   []image.Point{{0, 3}, {0, 7}, {0, 1}, {0, 4}},
+		},
+		{
+			desc:   "doesn't duplicate first and last when they are the extremes",
+			mode:   DecimationMinMax,
+			points: []image.Point{{0, 0}, {0, 3}, {0, 4}, {0, 2}, {0, 5}},
+			want:   []image.Point{{0, 0}, {0, 5}},
+		},
+		{
+			desc: "decimates each column separately",
+			mode: DecimationMinMax,
+			points: []image.Point{
+				{0, 3}, {0, 4}, {0, 7}, {0, 5}, {0, 1}, {0, 2},
+				{1, 2},
+				{2, 6}, {2, 6}, {2, 6}, {2, 6}, {2, 6},
+			},
+			want: []image.Point{
+				{0, 3}, {0, 7}, {0, 1}, {0, 2},
+				{1, 2},
+				{2, 6}, {2, 6},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := decimate(tc.mode, tc.points)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("decimate => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestDecimationMatchesAllPoints verifies that a series decimated with
+// DecimationMinMax results in the same image as drawing all of its points.
+func TestDecimationMatchesAllPoints(t *testing.T) {
+	var values []float64
+	for i := 0; i < 1000; i++ {
+		values = append(values, math.Sin(float64(i)/10)*float64(i%37))
+	}
+
+	tests := []struct {
+		desc  string
+		opts  []Option
+		sOpts []SeriesOption
+	}{
+		{
+			desc: "line",
+		},
+		{
+			desc:  "filled area",
+			sOpts: []SeriesOption{FillArea()},
+		},
+		{
+			desc:  "dashed line",
+			sOpts: []SeriesOption{SeriesLineStyle(LineStyleDashed)},
+		},
+		{
+			desc: "unscaled X axis",
+			opts: []Option{XAxisUnscaled()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			draw := func(dm DecimationMode) *faketerm.Terminal {
+				ar := image.Rect(0, 0, 40, 20)
+				ft := faketerm.MustNew(ar.Size())
+				lc, err := New(append(tc.opts, Decimation(dm))...)
+				if err != nil {
+					t.Fatalf("New => unexpected error: %v", err)
+				}
+				if err := lc.Series("series", values, tc.sOpts...); err != nil {
+					t.Fatalf("Series => unexpected error: %v", err)
+				}
+				c, err := canvas.New(ar)
+				if err != nil {
+					t.Fatalf("canvas.New => unexpected error: %v", err)
+				}
+				if err := lc.Draw(c); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+				if err := c.Apply(ft); err != nil {
+					t.Fatalf("Apply => unexpected error: %v", err)
+				}
+				return ft
+			}
+
+			want := draw(DecimationNone)
+			got := draw(DecimationMinMax)
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
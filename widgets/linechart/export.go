@@ -0,0 +1,200 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// export.go contains code that exports the data of the LineChart.
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// ExportOption is used to provide options to Export and WriteCSV.
+type ExportOption interface {
+	// set sets the provided option.
+	set(*exportOptions)
+}
+
+// exportOptions stores the provided export options.
+type exportOptions struct {
+	all bool
+}
+
+// exportOption implements ExportOption.
+type exportOption func(*exportOptions)
+
+// set implements ExportOption.set.
+func (eo exportOption) set(opts *exportOptions) {
+	eo(opts)
+}
+
+// ExportAll exports all the values of the series instead of only the values
+// visible on the X axis.
+func ExportAll() ExportOption {
+	return exportOption(func(opts *exportOptions) {
+		opts.all = true
+	})
+}
+
+// ExportedSeries is one series of the LineChart as returned by Export.
+type ExportedSeries struct {
+	// Label is the label of the series.
+	Label string
+	// Values are the values of the series, one for each of the X values of
+	// the export. The value is math.NaN() for X values beyond the end of the
+	// series, use Len to tell these apart from NaN values stored in the
+	// series.
+	Values []float64
+	// Len is the number of values in the series. X values greater than or
+	// equal to Len are beyond the end of the series.
+	Len int
+}
+
+// Exported is the data of the LineChart as returned by Export.
+type Exported struct {
+	// X are the exported values on the X axis, i.e. the indexes into the
+	// values of the series.
+	X []int
+	// XLabels are the custom labels of the X values provided via
+	// SeriesXLabels, one for each of the X values, an empty string for values
+	// without a label. Nil if no custom labels were provided.
+	XLabels []string
+	// Series are the exported series sorted by their labels.
+	Series []*ExportedSeries
+}
+
+// Export returns the values of the series. By default only the values
+// visible on the X axis as of the last call to Draw are exported, i.e. the
+// values within the current zoom and not hidden due to the XAxisUnscaled
+// option. Values that share a pixel of the graph due to the Decimation option
+// are all exported. All the values are exported if Draw wasn't called yet or
+// when the ExportAll option is provided.
+func (lc *LineChart) Export(opts ...ExportOption) *Exported {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	eOpts := &exportOptions{}
+	for _, opt := range opts {
+		opt.set(eOpts)
+	}
+
+	first, last := 0, -1 // Nothing to export without any series.
+	if len(lc.series) > 0 {
+		last = lc.maxXValue()
+	}
+	if xd := lc.lastXD; xd != nil && !eOpts.all {
+		if min := int(xd.Scale.Min.Value); min > first {
+			first = min
+		}
+		if max := int(xd.Scale.Max.Value); max < last {
+			last = max
+		}
+	}
+
+	res := &Exported{}
+	for i := first; i <= last; i++ {
+		res.X = append(res.X, i)
+	}
+	if len(lc.xLabels) > 0 {
+		res.XLabels = make([]string, len(res.X))
+		for i, x := range res.X {
+			res.XLabels[i] = lc.xLabels[x]
+		}
+	}
+
+	var names []string
+	for name := range lc.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sv := lc.series[name]
+		es := &ExportedSeries{
+			Label:  name,
+			Values: make([]float64, len(res.X)),
+			Len:    len(sv.values),
+		}
+		for i, x := range res.X {
+			if x < len(sv.values) {
+				es.Values[i] = sv.values[x]
+			} else {
+				es.Values[i] = math.NaN()
+			}
+		}
+		res.Series = append(res.Series, es)
+	}
+	return res
+}
+
+// seriesColumnPrefix is prepended to the label of a series in the CSV header
+// when the label is already used as the name of another column.
+const seriesColumnPrefix = "series:"
+
+// WriteCSV writes the values returned by Export to the writer in the CSV
+// format. The first record is a header with the column names, followed by
+// one record for each of the X values. The columns are the X value, the
+// custom X label if any labels were provided via SeriesXLabels and one column
+// per series named by the label of the series. Values beyond the end of a
+// series are written as empty fields, NaN values stored in a series are
+// written as "NaN".
+// The column names in the header are unique. A series whose label is already
+// used as the name of a column, e.g. a series labeled "x", gets its label
+// prefixed with "series:" until the name is unique.
+func (lc *LineChart) WriteCSV(w io.Writer, opts ...ExportOption) error {
+	exp := lc.Export(opts...)
+
+	header := []string{"x"}
+	if exp.XLabels != nil {
+		header = append(header, "x_label")
+	}
+	used := map[string]bool{}
+	for _, h := range header {
+		used[h] = true
+	}
+	for _, es := range exp.Series {
+		name := es.Label
+		for used[name] {
+			name = seriesColumnPrefix + name
+		}
+		used[name] = true
+		header = append(header, name)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i, x := range exp.X {
+		rec := []string{strconv.Itoa(x)}
+		if exp.XLabels != nil {
+			rec = append(rec, exp.XLabels[i])
+		}
+		for _, es := range exp.Series {
+			var field string
+			if x < es.Len {
+				field = strconv.FormatFloat(es.Values[i], 'g', -1, 64)
+			}
+			rec = append(rec, field)
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
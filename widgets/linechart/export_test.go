@@ -0,0 +1,217 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"bytes"
+	"image"
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/canvas"
+)
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		canvas  image.Rectangle // Draw isn't called if empty.
+		writes  func(lc *LineChart) error
+		expOpts []ExportOption
+		want    *Exported
+		wantCSV string
+	}{
+		{
+			desc: "no series",
+			writes: func(lc *LineChart) error {
+				return nil
+			},
+			want:    &Exported{},
+			wantCSV: "x\n",
+		},
+		{
+			desc: "exports all values before the first draw",
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("second", []float64{3, 4}); err != nil {
+					return err
+				}
+				return lc.Series("first", []float64{0, 1.5, 2})
+			},
+			want: &Exported{
+				X: []int{0, 1, 2},
+				Series: []*ExportedSeries{
+					{Label: "first", Values: []float64{0, 1.5, 2}, Len: 3},
+					{Label: "second", Values: []float64{3, 4, math.NaN()}, Len: 2},
+				},
+			},
+			wantCSV: "x,first,second\n" +
+				"0,0,3\n" +
+				"1,1.5,4\n" +
+				"2,2,\n",
+		},
+		{
+			desc: "tells NaN values apart from values beyond the end of the series",
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, math.NaN(), 2}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{math.NaN()})
+			},
+			want: &Exported{
+				X: []int{0, 1, 2},
+				Series: []*ExportedSeries{
+					{Label: "first", Values: []float64{0, math.NaN(), 2}, Len: 3},
+					{Label: "second", Values: []float64{math.NaN(), math.NaN(), math.NaN()}, Len: 1},
+				},
+			},
+			wantCSV: "x,first,second\n" +
+				"0,0,NaN\n" +
+				"1,NaN,\n" +
+				"2,2,\n",
+		},
+		{
+			desc: "prefixes series columns labeled like the X columns",
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("x_label", []float64{1}); err != nil {
+					return err
+				}
+				return lc.Series("x", []float64{0}, SeriesXLabels(map[int]string{
+					0: "start",
+				}))
+			},
+			want: &Exported{
+				X:       []int{0},
+				XLabels: []string{"start"},
+				Series: []*ExportedSeries{
+					{Label: "x", Values: []float64{0}, Len: 1},
+					{Label: "x_label", Values: []float64{1}, Len: 1},
+				},
+			},
+			wantCSV: "x,x_label,series:x,series:x_label\n" +
+				"0,start,0,1\n",
+		},
+		{
+			desc: "keeps prefixing until the series column name is unique",
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("series:x", []float64{1}); err != nil {
+					return err
+				}
+				return lc.Series("x", []float64{0})
+			},
+			want: &Exported{
+				X: []int{0},
+				Series: []*ExportedSeries{
+					{Label: "series:x", Values: []float64{1}, Len: 1},
+					{Label: "x", Values: []float64{0}, Len: 1},
+				},
+			},
+			wantCSV: "x,series:x,series:series:x\n" +
+				"0,1,0\n",
+		},
+		{
+			desc: "exports custom X labels",
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 1}, SeriesXLabels(map[int]string{
+					1: "12:00",
+				}))
+			},
+			want: &Exported{
+				X:       []int{0, 1},
+				XLabels: []string{"", "12:00"},
+				Series: []*ExportedSeries{
+					{Label: "first", Values: []float64{0, 1}, Len: 2},
+				},
+			},
+			wantCSV: "x,x_label,first\n" +
+				"0,,0\n" +
+				"1,12:00,1\n",
+		},
+		{
+			desc: "exports only the visible values after draw",
+			opts: []Option{
+				XAxisUnscaled(),
+			},
+			canvas: image.Rect(0, 0, 11, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19})
+			},
+			want: &Exported{
+				X: []int{8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+				Series: []*ExportedSeries{
+					{Label: "first", Values: []float64{8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, Len: 20},
+				},
+			},
+			wantCSV: "x,first\n" +
+				"8,8\n9,9\n10,10\n11,11\n12,12\n13,13\n14,14\n15,15\n16,16\n17,17\n18,18\n19,19\n",
+		},
+		{
+			desc: "exports all values after draw with ExportAll",
+			opts: []Option{
+				XAxisUnscaled(),
+			},
+			canvas: image.Rect(0, 0, 11, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19})
+			},
+			expOpts: []ExportOption{
+				ExportAll(),
+			},
+			want: &Exported{
+				X: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+				Series: []*ExportedSeries{
+					{Label: "first", Values: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, Len: 20},
+				},
+			},
+			wantCSV: "x,first\n" +
+				"0,0\n1,1\n2,2\n3,3\n4,4\n5,5\n6,6\n7,7\n8,8\n9,9\n" +
+				"10,10\n11,11\n12,12\n13,13\n14,14\n15,15\n16,16\n17,17\n18,18\n19,19\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := tc.writes(lc); err != nil {
+				t.Fatalf("writes => unexpected error: %v", err)
+			}
+			if !tc.canvas.Empty() {
+				c, err := canvas.New(tc.canvas)
+				if err != nil {
+					t.Fatalf("canvas.New => unexpected error: %v", err)
+				}
+				if err := lc.Draw(c); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			got := lc.Export(tc.expOpts...)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Export => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			var b bytes.Buffer
+			if err := lc.WriteCSV(&b, tc.expOpts...); err != nil {
+				t.Fatalf("WriteCSV => unexpected error: %v", err)
+			}
+			if got := b.String(); got != tc.wantCSV {
+				t.Errorf("WriteCSV => %q, want %q", got, tc.wantCSV)
+			}
+		})
+	}
+}
@@ -51,14 +51,15 @@ type YDetails struct {
 
 // RequiredWidth calculates the minimum width required in order to draw the Y
 // axis and its labels when displaying values that have this minimum and
-// maximum among all the series.
-func RequiredWidth(minVal, maxVal float64) int {
+// maximum among all the series. The value formatter is optional and formats
+// the labels if provided.
+func RequiredWidth(minVal, maxVal float64, vf ValueFormatter) int {
 	// This is an estimation only, it is possible that more labels in the
 	// middle will be generated and might be wider than this. Such cases are
 	// handled on the call to Details when the size of canvas is known.
 	return longestLabel([]*Label{
-		{Value: NewValue(minVal, nonZeroDecimals)},
-		{Value: NewValue(maxVal, nonZeroDecimals)},
+		{Value: NewFormattedValue(minVal, nonZeroDecimals, vf)},
+		{Value: NewFormattedValue(maxVal, nonZeroDecimals, vf)},
 	}) + axisWidth
 }
 
@@ -72,6 +73,8 @@ type YProperties struct {
 	ReqXHeight int
 	// ScaleMode determines how the Y axis scales.
 	ScaleMode YScaleMode
+	// ValueFormatter if not nil, formats the labels on the Y axis.
+	ValueFormatter ValueFormatter
 }
 
 // NewYDetails retrieves details about the Y axis required to draw it on a
@@ -80,7 +83,7 @@ func NewYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
 	cvsWidth := cvsAr.Dx()
 	cvsHeight := cvsAr.Dy()
 	maxWidth := cvsWidth - 1 // Reserve one column for the line chart itself.
-	if req := RequiredWidth(yp.Min, yp.Max); maxWidth < req {
+	if req := RequiredWidth(yp.Min, yp.Max, yp.ValueFormatter); maxWidth < req {
 		return nil, fmt.Errorf("the available maxWidth %d is smaller than the reported required width %d", maxWidth, req)
 	}
 
@@ -92,7 +95,7 @@ func NewYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
 
 	// See how the labels would look like on the entire maxWidth.
 	maxLabelWidth := maxWidth - axisWidth
-	labels, err := yLabels(scale, maxLabelWidth)
+	labels, err := yLabels(scale, maxLabelWidth, yp.ValueFormatter)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +106,7 @@ func NewYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
 	widest := longestLabel(labels)
 	if widest < maxLabelWidth {
 		// Save the space and recalculate the labels, since they need to be realigned.
-		l, err := yLabels(scale, widest)
+		l, err := yLabels(scale, widest, yp.ValueFormatter)
 		if err != nil {
 			return nil, err
 		}
@@ -172,6 +175,9 @@ type XProperties struct {
 	CustomLabels map[int]string
 	// LO is the desired orientation of labels under the X axis.
 	LO LabelOrientation
+	// MaxLabels is the maximum number of labels under the X axis.
+	// Zero means no limit, i.e. as many labels as fit.
+	MaxLabels int
 }
 
 // NewXDetails retrieves details about the X axis required to draw it on a canvas
@@ -201,7 +207,12 @@ func NewXDetails(cvsAr image.Rectangle, xp *XProperties) (*XDetails, error) {
 		xp.ReqYWidth + 1,
 		cvsAr.Dy() - reqHeight - 1,
 	}
-	labels, err := xLabels(scale, graphZero, xp.CustomLabels, xp.LO)
+	var labels []*Label
+	if xp.MaxLabels > 0 {
+		labels, err = xLabelsLimited(scale, graphZero, xp.CustomLabels, xp.LO, xp.MaxLabels)
+	} else {
+		labels, err = xLabels(scale, graphZero, xp.CustomLabels, xp.LO)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -28,6 +28,9 @@ type updateY struct {
 }
 
 func TestY(t *testing.T) {
+	mb := func(v float64) string {
+		return fmt.Sprintf("%.0f MB", v)
+	}
 	tests := []struct {
 		desc      string
 		yp        *YProperties
@@ -192,11 +195,44 @@ func TestY(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "reserves width for formatted labels",
+			yp: &YProperties{
+				Min:            0,
+				Max:            3,
+				ReqXHeight:     2,
+				ValueFormatter: mb,
+			},
+			cvsAr:     image.Rect(0, 0, 10, 4),
+			wantWidth: 5,
+			want: &YDetails{
+				Width: 5,
+				Start: image.Point{4, 0},
+				End:   image.Point{4, 2},
+				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored),
+				Labels: []*Label{
+					{NewFormattedValue(0, nonZeroDecimals, mb), image.Point{0, 1}},
+					{NewFormattedValue(1.72, nonZeroDecimals, mb), image.Point{0, 0}},
+				},
+			},
+		},
+		{
+			desc: "fails when canvas is too narrow for formatted labels",
+			yp: &YProperties{
+				Min:            0,
+				Max:            3,
+				ReqXHeight:     2,
+				ValueFormatter: mb,
+			},
+			cvsAr:     image.Rect(0, 0, 5, 4),
+			wantWidth: 5,
+			wantErr:   true,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			gotWidth := RequiredWidth(tc.yp.Min, tc.yp.Max)
+			gotWidth := RequiredWidth(tc.yp.Min, tc.yp.Max, tc.yp.ValueFormatter)
 			if gotWidth != tc.wantWidth {
 				t.Errorf("RequiredWidth => got %v, want %v", gotWidth, tc.wantWidth)
 			}
@@ -67,7 +67,7 @@ type Label struct {
 // Label value is not trimmed to the provided labelWidth, the label width is
 // only used to align the labels. Alignment is done with the assumption that
 // longer labels will be trimmed.
-func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
+func yLabels(scale *YScale, labelWidth int, vf ValueFormatter) ([]*Label, error) {
 	if min := 2; scale.GraphHeight < min {
 		return nil, fmt.Errorf("cannot place labels on a canvas with height %d, minimum is %d", scale.GraphHeight, min)
 	}
@@ -79,7 +79,7 @@ func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
 	const labelSpacing = 4
 	seen := map[string]bool{}
 	for y := scale.GraphHeight - 1; y >= 0; y -= labelSpacing {
-		label, err := rowLabel(scale, y, labelWidth)
+		label, err := rowLabel(scale, y, labelWidth, vf)
 		if err != nil {
 			return nil, err
 		}
@@ -93,7 +93,7 @@ func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
 	haveData := scale.Min.Rounded != 0 || scale.Max.Rounded != 0
 	if len(labels) < 2 && haveData {
 		const maxRow = 0
-		label, err := rowLabel(scale, maxRow, labelWidth)
+		label, err := rowLabel(scale, maxRow, labelWidth, vf)
 		if err != nil {
 			return nil, err
 		}
@@ -109,11 +109,14 @@ func rowLabelArea(row int, labelWidth int) image.Rectangle {
 }
 
 // rowLabel returns label for the specified row.
-func rowLabel(scale *YScale, y int, labelWidth int) (*Label, error) {
+func rowLabel(scale *YScale, y int, labelWidth int, vf ValueFormatter) (*Label, error) {
 	v, err := scale.CellLabel(y)
 	if err != nil {
 		return nil, fmt.Errorf("unable to determine label value for row %d: %v", y, err)
 	}
+	if vf != nil {
+		v = NewFormattedValue(v.Value, v.NonZeroDecimals, vf)
+	}
 
 	ar := rowLabelArea(y, labelWidth)
 	pos, err := alignfor.Text(ar, v.Text(), align.HorizontalRight, align.VerticalMiddle)
@@ -182,6 +185,10 @@ func (xs *xSpace) Sub(size int) error {
 	return nil
 }
 
+// xLabelSpacing is the minimum number of cells between two labels on the X
+// axis.
+const xLabelSpacing = 3
+
 // xLabels returns labels that should be placed under the X axis.
 // The graphZero is the (0, 0) point of the graph area on the canvas.
 // Labels are returned in an increasing value order.
@@ -191,7 +198,6 @@ func (xs *xSpace) Sub(size int) error {
 // label. These are preferred if present.
 func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string, lo LabelOrientation) ([]*Label, error) {
 	space := newXSpace(graphZero, scale.GraphWidth)
-	const minSpacing = 3
 	var res []*Label
 
 	next := int(scale.Min.Value)
@@ -215,8 +221,8 @@ func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string,
 		}
 
 		skip := nextCell - space.Relative().X
-		if skip < minSpacing {
-			skip = minSpacing
+		if skip < xLabelSpacing {
+			skip = xLabelSpacing
 		}
 
 		if space.Remaining() <= skip {
@@ -229,6 +235,97 @@ func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string,
 	return res, nil
 }
 
+// xLabelsLimited returns at most maxLabels labels that should be placed under
+// the X axis. The labels are placed on values that are multiples of the
+// smallest step from the 1, 2, 5, 10, 20, 50, ... sequence for which the
+// labels fit under the axis without overlapping.
+// Otherwise behaves like xLabels.
+func xLabelsLimited(scale *XScale, graphZero image.Point, customLabels map[int]string, lo LabelOrientation, maxLabels int) ([]*Label, error) {
+	min := int(scale.Min.Value)
+	max := int(scale.Max.Value)
+	for step := 1; step <= max-min; step = nextNiceStep(step) {
+		labels, fit, err := stepLabels(scale, graphZero, customLabels, lo, step)
+		if err != nil {
+			return nil, err
+		}
+		if fit && len(labels) <= maxLabels {
+			return labels, nil
+		}
+	}
+
+	// Fall back to a single label on the first value.
+	labels, _, err := stepLabels(scale, graphZero, customLabels, lo, max-min+1)
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// nextNiceStep returns the step that follows the provided one in the 1, 2, 5,
+// 10, 20, 50, ... sequence.
+func nextNiceStep(step int) int {
+	magnitude := 1
+	for step >= 10*magnitude {
+		magnitude *= 10
+	}
+	switch step / magnitude {
+	case 1:
+		return 2 * magnitude
+	case 2:
+		return 5 * magnitude
+	default:
+		return 10 * magnitude
+	}
+}
+
+// stepLabels returns labels for all the values on the scale that are
+// multiples of the step. Labels that would extend past the end of the axis
+// are dropped. The returned bool is false if any two labels are closer than
+// xLabelSpacing.
+func stepLabels(scale *XScale, graphZero image.Point, customLabels map[int]string, lo LabelOrientation, step int) ([]*Label, bool, error) {
+	min := int(scale.Min.Value)
+	max := int(scale.Max.Value)
+	first := (min + step - 1) / step * step
+	if step > max-min {
+		first = min
+	}
+
+	var res []*Label
+	prevEnd := -1
+	for v := first; v <= max; v += step {
+		x, err := scale.ValueToCell(v)
+		if err != nil {
+			return nil, false, err
+		}
+
+		label := NewValue(float64(v), nonZeroDecimals)
+		if custom, ok := customLabels[v]; ok {
+			label = NewTextValue(custom)
+		}
+
+		var labelLen int
+		switch lo {
+		case LabelOrientationHorizontal:
+			labelLen = len(label.Text())
+		case LabelOrientationVertical:
+			labelLen = 1
+		}
+		if x+labelLen > scale.GraphWidth {
+			break
+		}
+		if prevEnd >= 0 && x-prevEnd < xLabelSpacing {
+			return nil, false, nil
+		}
+
+		res = append(res, &Label{
+			Value: label,
+			Pos:   image.Point{graphZero.X + x, graphZero.Y + 2}, // First down is the axis, second the label.
+		})
+		prevEnd = x + labelLen
+	}
+	return res, true, nil
+}
+
 // colLabel returns a label placed at the beginning of the space.
 // The space is adjusted according to how much space was taken by the label.
 // Returns nil, nil if the label doesn't fit in the space.
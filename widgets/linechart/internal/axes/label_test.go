@@ -15,6 +15,7 @@
 package axes
 
 import (
+	"fmt"
 	"image"
 	"testing"
 
@@ -23,12 +24,16 @@ import (
 
 func TestYLabels(t *testing.T) {
 	const nonZeroDecimals = 2
+	seconds := func(v float64) string {
+		return fmt.Sprintf("%.1fs", v)
+	}
 	tests := []struct {
 		desc        string
 		min         float64
 		max         float64
 		graphHeight int
 		labelWidth  int
+		vf          ValueFormatter
 		want        []*Label
 		wantErr     bool
 	}{
@@ -125,6 +130,18 @@ func TestYLabels(t *testing.T) {
 				{NewValue(4.16, nonZeroDecimals), image.Point{0, 1}},
 			},
 		},
+		{
+			desc:        "formats the labels",
+			min:         0,
+			max:         5,
+			graphHeight: 2,
+			labelWidth:  4,
+			vf:          seconds,
+			want: []*Label{
+				{NewFormattedValue(0, nonZeroDecimals, seconds), image.Point{0, 1}},
+				{NewFormattedValue(2.88, nonZeroDecimals, seconds), image.Point{0, 0}},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -134,7 +151,7 @@ func TestYLabels(t *testing.T) {
 				t.Fatalf("NewYScale => unexpected error: %v", err)
 			}
 			t.Logf("scale step: %v", scale.Step.Rounded)
-			got, err := yLabels(scale, tc.labelWidth)
+			got, err := yLabels(scale, tc.labelWidth, tc.vf)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("yLabels => unexpected error: %v, wantErr: %v", err, tc.wantErr)
 			}
@@ -472,6 +489,151 @@ func TestXLabels(t *testing.T) {
 	}
 }
 
+func TestXLabelsLimited(t *testing.T) {
+	const nonZeroDecimals = 2
+	tests := []struct {
+		desc             string
+		min              int
+		max              int
+		graphWidth       int
+		graphZero        image.Point
+		customLabels     map[int]string
+		labelOrientation LabelOrientation
+		maxLabels        int
+		want             []*Label
+	}{
+		{
+			desc:       "only one point",
+			min:        0,
+			max:        0,
+			graphWidth: 1,
+			graphZero:  image.Point{0, 1},
+			maxLabels:  1,
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+			},
+		},
+		{
+			desc:       "all labels within the limit",
+			min:        0,
+			max:        2,
+			graphWidth: 10,
+			graphZero:  image.Point{0, 1},
+			maxLabels:  5,
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(1, nonZeroDecimals), image.Point{4, 3}},
+				{NewValue(2, nonZeroDecimals), image.Point{9, 3}},
+			},
+		},
+		{
+			desc:       "limits the labels to nice values",
+			min:        0,
+			max:        100,
+			graphWidth: 40,
+			graphZero:  image.Point{2, 1},
+			maxLabels:  3,
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{2, 3}},
+				{NewValue(50, nonZeroDecimals), image.Point{21, 3}},
+			},
+		},
+		{
+			desc:       "never draws more labels than fit",
+			min:        0,
+			max:        100,
+			graphWidth: 10,
+			graphZero:  image.Point{0, 1},
+			maxLabels:  10,
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(50, nonZeroDecimals), image.Point{4, 3}},
+			},
+		},
+		{
+			desc:       "starts at the first nice value after a non-zero min",
+			min:        3,
+			max:        23,
+			graphWidth: 20,
+			graphZero:  image.Point{0, 1},
+			maxLabels:  3,
+			want: []*Label{
+				{NewValue(10, nonZeroDecimals), image.Point{6, 3}},
+				{NewValue(20, nonZeroDecimals), image.Point{16, 3}},
+			},
+		},
+		{
+			desc:       "uses custom labels on the chosen positions",
+			min:        0,
+			max:        10,
+			graphWidth: 20,
+			graphZero:  image.Point{0, 1},
+			customLabels: map[int]string{
+				0: "start",
+				5: "mid",
+			},
+			maxLabels: 2,
+			want: []*Label{
+				{NewTextValue("start"), image.Point{0, 3}},
+				{NewTextValue("mid"), image.Point{9, 3}},
+			},
+		},
+		{
+			desc:             "vertical labels",
+			min:              0,
+			max:              100,
+			graphWidth:       10,
+			graphZero:        image.Point{0, 1},
+			labelOrientation: LabelOrientationVertical,
+			maxLabels:        3,
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(50, nonZeroDecimals), image.Point{4, 3}},
+				{NewValue(100, nonZeroDecimals), image.Point{9, 3}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			scale, err := NewXScale(tc.min, tc.max, tc.graphWidth, nonZeroDecimals)
+			if err != nil {
+				t.Fatalf("NewXScale => unexpected error: %v", err)
+			}
+			got, err := xLabelsLimited(scale, tc.graphZero, tc.customLabels, tc.labelOrientation, tc.maxLabels)
+			if err != nil {
+				t.Fatalf("xLabelsLimited => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("xLabelsLimited => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNextNiceStep(t *testing.T) {
+	tests := []struct {
+		step int
+		want int
+	}{
+		{1, 2},
+		{2, 5},
+		{5, 10},
+		{10, 20},
+		{20, 50},
+		{50, 100},
+		{100, 200},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.step), func(t *testing.T) {
+			if got := nextNiceStep(tc.step); got != tc.want {
+				t.Errorf("nextNiceStep(%d) => %d, want %d", tc.step, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestXSpace(t *testing.T) {
 	tests := []struct {
 		desc          string
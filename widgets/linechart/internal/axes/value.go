@@ -58,6 +58,20 @@ func NewValue(v float64, nonZeroDecimals int) *Value {
 	}
 }
 
+// ValueFormatter formats a value into its textual representation.
+type ValueFormatter func(float64) string
+
+// NewFormattedValue is like NewValue, but the textual representation of the
+// value is determined by the provided formatter. Behaves like NewValue if the
+// formatter is nil or returns an empty string.
+func NewFormattedValue(v float64, nonZeroDecimals int, vf ValueFormatter) *Value {
+	val := NewValue(v, nonZeroDecimals)
+	if vf != nil {
+		val.text = vf(v)
+	}
+	return val
+}
+
 // NewTextValue constructs a value out of the provided text.
 func NewTextValue(text string) *Value {
 	return &Value{
@@ -48,6 +48,14 @@ type seriesValues struct {
 	// the labels were provided. This allows resetting them to nil.
 	xLabelsSet bool
 	xLabels    map[int]string
+
+	lineStyle LineStyle
+	marker    Marker
+
+	// fillArea indicates whether the area between the line and the zero line
+	// should be filled using the fillCellOpts.
+	fillArea     bool
+	fillCellOpts []cell.Option
 }
 
 // newSeriesValues returns a new seriesValues instance.
@@ -97,8 +105,23 @@ type LineChart struct {
 	// xLabels that were provided on a call to Series.
 	xLabels map[int]string
 
+	// yRefs are the horizontal reference lines, in the order they were
+	// added.
+	yRefs []*reference
+	// xRefs are the vertical reference lines, in the order they were added.
+	xRefs []*reference
+
 	// zoom tracks the zooming of the X axis.
 	zoom *zoom.Tracker
+
+	// crosshairAt is the position of the last mouse event that occurred
+	// within the graph area or nil if the last event occurred outside of it.
+	crosshairAt *image.Point
+	// lastGraphAr is the area of the graph as of the last call to Draw.
+	lastGraphAr image.Rectangle
+	// lastXD are the details of the X axis as of the last call to Draw, nil
+	// if Draw wasn't called yet.
+	lastXD *axes.XDetails
 }
 
 // New returns a new line chart widget.
@@ -150,6 +173,119 @@ func SeriesXLabels(labels map[int]string) SeriesOption {
 	})
 }
 
+// LineStyle determines how are the points of a series connected.
+type LineStyle int
+
+// String implements fmt.Stringer()
+func (ls LineStyle) String() string {
+	if n, ok := lineStyleNames[ls]; ok {
+		return n
+	}
+	return "LineStyleUnknown"
+}
+
+// lineStyleNames maps LineStyle values to human readable names.
+var lineStyleNames = map[LineStyle]string{
+	LineStyleSolid:  "LineStyleSolid",
+	LineStyleDashed: "LineStyleDashed",
+	LineStyleNone:   "LineStyleNone",
+}
+
+const (
+	// LineStyleSolid connects the points with a solid line.
+	LineStyleSolid LineStyle = iota
+	// LineStyleDashed connects the points with a dashed line.
+	LineStyleDashed
+	// LineStyleNone doesn't connect the points, use with markers to draw a
+	// scatter plot.
+	LineStyleNone
+)
+
+// dashLength is the length of dashes and gaps in braille pixels when drawing
+// lines in the LineStyleDashed style.
+const dashLength = 2
+
+// SeriesLineStyle sets the style of the line that connects the points of this
+// series. Defaults to LineStyleSolid.
+func SeriesLineStyle(ls LineStyle) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.lineStyle = ls
+	})
+}
+
+// Marker is a shape drawn on the points of a series.
+type Marker int
+
+// String implements fmt.Stringer()
+func (m Marker) String() string {
+	if n, ok := markerNames[m]; ok {
+		return n
+	}
+	return "MarkerUnknown"
+}
+
+// markerNames maps Marker values to human readable names.
+var markerNames = map[Marker]string{
+	MarkerNone:   "MarkerNone",
+	MarkerDot:    "MarkerDot",
+	MarkerCross:  "MarkerCross",
+	MarkerSquare: "MarkerSquare",
+}
+
+const (
+	// MarkerNone doesn't mark the points.
+	MarkerNone Marker = iota
+	// MarkerDot marks each point with a single braille pixel.
+	MarkerDot
+	// MarkerCross marks each point with a diagonal cross three pixels wide.
+	MarkerCross
+	// MarkerSquare marks each point with an outline of a square three pixels
+	// wide.
+	MarkerSquare
+)
+
+// markerPixels are the pixels set when drawing a marker, relative to the
+// marked point.
+var markerPixels = map[Marker][]image.Point{
+	MarkerDot: {
+		{0, 0},
+	},
+	MarkerCross: {
+		{-1, -1}, {1, -1},
+		{0, 0},
+		{-1, 1}, {1, 1},
+	},
+	MarkerSquare: {
+		{-1, -1}, {0, -1}, {1, -1},
+		{-1, 0}, {1, 0},
+		{-1, 1}, {0, 1}, {1, 1},
+	},
+}
+
+// SeriesMarker sets the marker drawn on each point of this series.
+// Markers that don't fully fit the graph are only partially drawn.
+// Defaults to MarkerNone.
+func SeriesMarker(m Marker) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.marker = m
+	})
+}
+
+// FillArea fills the area between the line of this series and the zero line
+// of the Y axis. Values above zero fill down to the zero line, negative values
+// fill up to it. If the Y axis doesn't include the zero value, the area is
+// filled up to the edge of the graph closest to zero.
+// The provided cell options are used for the filled area, provide for example
+// a darker shade of the series color. Defaults to the cell options of the
+// series. Where filled series overlap, the last drawn series sets the cell
+// options, see SeriesCellOpts for the order in which series are drawn.
+func FillArea(co ...cell.Option) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.fillArea = true
+		opts.fillCellOpts = co
+	})
+}
+
 // yMinMax determines the min and max values for the Y axis.
 func (lc *LineChart) yMinMax() (float64, float64) {
 	var (
@@ -165,6 +301,10 @@ func (lc *LineChart) yMinMax() (float64, float64) {
 		minimums = append(minimums, lc.opts.yAxisCustomScale.min)
 		maximums = append(maximums, lc.opts.yAxisCustomScale.max)
 	}
+	for _, ref := range lc.yRefs {
+		minimums = append(minimums, ref.value)
+		maximums = append(maximums, ref.value)
+	}
 
 	min, _ := numbers.MinMax(minimums)
 	_, max := numbers.MinMax(maximums)
@@ -200,6 +340,12 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 	for _, opt := range opts {
 		opt.set(series)
 	}
+	if _, ok := lineStyleNames[series.lineStyle]; !ok {
+		return fmt.Errorf("unsupported line style %v provided in SeriesLineStyle", series.lineStyle)
+	}
+	if _, ok := markerNames[series.marker]; !ok {
+		return fmt.Errorf("unsupported marker %v provided in SeriesMarker", series.marker)
+	}
 	if series.xLabelsSet {
 		for i, t := range series.xLabels {
 			if i < 0 {
@@ -228,6 +374,7 @@ func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axe
 		ReqYWidth:    reqYWidth,
 		CustomLabels: lc.xLabels,
 		LO:           lc.opts.xLabelOrientation,
+		MaxLabels:    lc.opts.xAxisMaxLabels,
 	}
 	xd, err := axes.NewXDetails(cvs.Area(), xp)
 	if err != nil {
@@ -266,6 +413,8 @@ func (lc *LineChart) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDet
 		Max:        lc.yMax,
 		ReqXHeight: reqXHeight,
 		ScaleMode:  lc.opts.yAxisMode,
+
+		ValueFormatter: lc.opts.yAxisFormatter,
 	}
 	yd, err := axes.NewYDetails(cvs.Area(), yp)
 	if err != nil {
@@ -304,7 +453,16 @@ func (lc *LineChart) Draw(cvs *canvas.Canvas) error {
 	if err != nil {
 		return err
 	}
-	return lc.drawAxes(cvs, adjXD, yd)
+	lc.lastGraphAr = lc.graphAr(cvs, xd, yd)
+	lc.lastXD = adjXD
+	if err := lc.drawReferences(cvs, lc.lastGraphAr, adjXD, yd); err != nil {
+		return err
+	}
+	if err := lc.drawAxes(cvs, adjXD, yd); err != nil {
+		return err
+	}
+
+	return lc.drawCrosshair(cvs, lc.lastGraphAr, adjXD, yd)
 }
 
 // drawAxes draws the X,Y axes and their labels.
@@ -318,7 +476,7 @@ func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YD
 	}
 
 	for _, l := range yd.Labels {
-		if err := draw.Text(cvs, l.Value.Text(), l.Pos,
+		if _, err := draw.Text(cvs, l.Value.Text(), l.Pos,
 			draw.TextMaxX(yd.Start.X),
 			draw.TextOverrunMode(draw.OverrunModeThreeDot),
 			draw.TextCellOpts(lc.opts.yLabelCellOpts...),
@@ -330,7 +488,7 @@ func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YD
 	for _, l := range xd.Labels {
 		switch lc.opts.xLabelOrientation {
 		case axes.LabelOrientationHorizontal:
-			if err := draw.Text(cvs, l.Value.Text(), l.Pos, draw.TextCellOpts(lc.opts.xLabelCellOpts...)); err != nil {
+			if _, err := draw.Text(cvs, l.Value.Text(), l.Pos, draw.TextCellOpts(lc.opts.xLabelCellOpts...)); err != nil {
 				return fmt.Errorf("failed to draw the X horizontal labels: %v", err)
 			}
 
@@ -388,51 +546,14 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 
 	for _, name := range names {
 		sv := lc.series[name]
-		// Skip over series that don't have at least two points since we can't
-		// draw a line for just one point.
-		// Skip over series that fall under the minimum value on the X axis.
-		if got := len(sv.values); got <= 1 {
-			continue
+		if err := lc.drawFill(bc, xdZoomed, yd, name, sv); err != nil {
+			return nil, err
 		}
-
-		var prev float64
-		for i := 1; i < len(sv.values); i++ {
-			prev = sv.values[i-1]
-			if i < int(xdZoomed.Scale.Min.Value)+1 || i > int(xdZoomed.Scale.Max.Value) {
-				// Don't draw lines for values that aren't supposed to be visible.
-				// These are either values outside of the current zoom or
-				// values at the beginning of a series that falls before athe
-				// start of an unscaled X axis when the XAxisUnscaled option is
-				// provided.
-				continue
-			}
-
-			startX, err := xdZoomed.Scale.ValueToPixel(i - 1)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d], xdZoomed.Scale.ValueToPixel => %v", name, i-1, err)
-			}
-			endX, err := xdZoomed.Scale.ValueToPixel(i)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d], xdZoomed.Scale.ValueToPixel => %v", name, i, err)
-			}
-
-			startY, err := yd.Scale.ValueToPixel(prev)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d], yd.Scale.ValueToPixel => %v", name, i-1, err)
-			}
-			v := sv.values[i]
-			endY, err := yd.Scale.ValueToPixel(v)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d], yd.Scale.ValueToPixel => %v", name, i, err)
-			}
-
-			if err := draw.BrailleLine(bc,
-				image.Point{startX, startY},
-				image.Point{endX, endY},
-				draw.BrailleLineCellOpts(sv.seriesCellOpts...),
-			); err != nil {
-				return nil, fmt.Errorf("draw.BrailleLine => %v", err)
-			}
+		if err := lc.drawLine(bc, xdZoomed, yd, name, sv); err != nil {
+			return nil, err
+		}
+		if err := lc.drawMarkers(bc, xdZoomed, yd, name, sv); err != nil {
+			return nil, err
 		}
 	}
 
@@ -448,6 +569,168 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 	return xdZoomed, nil
 }
 
+// pointPixel returns the braille pixel that represents the i-th value of the
+// series.
+func pointPixel(xd *axes.XDetails, yd *axes.YDetails, name string, sv *seriesValues, i int) (image.Point, error) {
+	x, err := xd.Scale.ValueToPixel(i)
+	if err != nil {
+		return image.Point{}, fmt.Errorf("failure for series %v[%d], xd.Scale.ValueToPixel => %v", name, i, err)
+	}
+	y, err := yd.Scale.ValueToPixel(sv.values[i])
+	if err != nil {
+		return image.Point{}, fmt.Errorf("failure for series %v[%d], yd.Scale.ValueToPixel => %v", name, i, err)
+	}
+	return image.Point{x, y}, nil
+}
+
+// visiblePixels returns the braille pixels that represent the values of the
+// series that are visible on the X axis. I.e. values that are within the
+// current zoom and not hidden due to the XAxisUnscaled option.
+func visiblePixels(xd *axes.XDetails, yd *axes.YDetails, name string, sv *seriesValues) ([]image.Point, error) {
+	var points []image.Point
+	for i := range sv.values {
+		if i < int(xd.Scale.Min.Value) || i > int(xd.Scale.Max.Value) {
+			continue // Not visible.
+		}
+
+		p, err := pointPixel(xd, yd, name, sv, i)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+// drawLine draws the line connecting the points of the series.
+func (lc *LineChart) drawLine(bc *braille.Canvas, xd *axes.XDetails, yd *axes.YDetails, name string, sv *seriesValues) error {
+	// Skip over series that don't have at least two points since we can't
+	// draw a line for just one point.
+	if got := len(sv.values); got <= 1 || sv.lineStyle == LineStyleNone {
+		return nil
+	}
+
+	opts := []draw.BrailleLineOption{
+		draw.BrailleLineCellOpts(sv.seriesCellOpts...),
+	}
+	if sv.lineStyle == LineStyleDashed {
+		opts = append(opts, draw.BrailleLineDashed(dashLength))
+	}
+
+	// Don't draw lines for values that aren't supposed to be visible.
+	// These are either values outside of the current zoom or values at the
+	// beginning of a series that falls before the start of an unscaled X axis
+	// when the XAxisUnscaled option is provided.
+	points, err := visiblePixels(xd, yd, name, sv)
+	if err != nil {
+		return err
+	}
+	if sv.lineStyle == LineStyleSolid {
+		// Dashes are measured along each line, decimating the points would
+		// change the pattern.
+		points = decimate(lc.opts.decimation, points)
+	}
+	for i := 1; i < len(points); i++ {
+		start, end := points[i-1], points[i]
+		if err := draw.BrailleLine(bc, start, end, opts...); err != nil {
+			return fmt.Errorf("draw.BrailleLine => %v", err)
+		}
+	}
+	return nil
+}
+
+// drawFill fills the area between the line of the series and the zero line.
+func (lc *LineChart) drawFill(bc *braille.Canvas, xd *axes.XDetails, yd *axes.YDetails, name string, sv *seriesValues) error {
+	if !sv.fillArea {
+		return nil
+	}
+
+	zero := 0.0
+	if min := yd.Scale.Min.Value; zero < min {
+		zero = min
+	}
+	if max := yd.Scale.Max.Value; zero > max {
+		zero = max
+	}
+	zeroY, err := yd.Scale.ValueToPixel(zero)
+	if err != nil {
+		return fmt.Errorf("failure for series %v, yd.Scale.ValueToPixel => %v", name, err)
+	}
+
+	opts := sv.fillCellOpts
+	if len(opts) == 0 {
+		opts = sv.seriesCellOpts
+	}
+
+	points, err := visiblePixels(xd, yd, name, sv)
+	if err != nil {
+		return err
+	}
+	points = decimate(lc.opts.decimation, points)
+	for i, p := range points {
+		if i == 0 {
+			if err := fillColumn(bc, p.X, p.Y, zeroY, opts); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Interpolate the line between the previous and this point.
+		prev := points[i-1]
+		width := p.X - prev.X
+		for x := prev.X + 1; x <= p.X; x++ {
+			offset := numbers.Round(float64((p.Y-prev.Y)*(x-prev.X)) / float64(width))
+			if err := fillColumn(bc, x, prev.Y+int(offset), zeroY, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillColumn sets all the pixels in the column x on the braille canvas between
+// the two Y coordinates, inclusive.
+func fillColumn(bc *braille.Canvas, x, y1, y2 int, opts []cell.Option) error {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	for y := y1; y <= y2; y++ {
+		p := image.Point{x, y}
+		if !p.In(bc.Area()) {
+			continue
+		}
+		if err := bc.SetPixel(p, opts...); err != nil {
+			return fmt.Errorf("bc.SetPixel(%v) => %v", p, err)
+		}
+	}
+	return nil
+}
+
+// drawMarkers draws the markers on the points of the series.
+func (lc *LineChart) drawMarkers(bc *braille.Canvas, xd *axes.XDetails, yd *axes.YDetails, name string, sv *seriesValues) error {
+	pixels := markerPixels[sv.marker]
+	if len(pixels) == 0 {
+		return nil
+	}
+
+	points, err := visiblePixels(xd, yd, name, sv)
+	if err != nil {
+		return err
+	}
+	for _, p := range points {
+		for _, mp := range pixels {
+			px := p.Add(mp)
+			if !px.In(bc.Area()) {
+				continue
+			}
+			if err := bc.SetPixel(px, sv.seriesCellOpts...); err != nil {
+				return fmt.Errorf("bc.SetPixel(%v) => %v", px, err)
+			}
+		}
+	}
+	return nil
+}
+
 // highlightRange highlights the range of X columns on the braille canvas.
 func (lc *LineChart) highlightRange(bc *braille.Canvas, hRange *zoom.Range) error {
 	cellAr := bc.CellArea()
@@ -465,6 +748,15 @@ func (lc *LineChart) Mouse(m *terminalapi.Mouse) error {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
 
+	if lc.opts.crosshair {
+		if m.Position.In(lc.lastGraphAr) {
+			p := m.Position
+			lc.crosshairAt = &p
+		} else {
+			lc.crosshairAt = nil
+		}
+	}
+
 	if lc.zoom == nil {
 		return nil
 	}
@@ -476,7 +768,7 @@ func (lc *LineChart) minSize() image.Point {
 	// At the very least we need:
 	// - n cells width for the Y axis and its labels as reported by it.
 	// - at least 1 cell width for the graph.
-	reqWidth := axes.RequiredWidth(lc.yMin, lc.yMax) + 1
+	reqWidth := axes.RequiredWidth(lc.yMin, lc.yMax, lc.opts.yAxisFormatter) + 1
 
 	// And for the height:
 	// - n cells width for the X axis and its labels as reported by it.
@@ -15,6 +15,7 @@
 package linechart
 
 import (
+	"fmt"
 	"image"
 	"math"
 	"testing"
@@ -44,6 +45,22 @@ func TestLineChartDraws(t *testing.T) {
 		wantWriteErr bool
 		wantDrawErr  bool
 	}{
+		{
+			desc:   "fails with negative maximum of X axis labels",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				XAxisLabels(-1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails with unsupported decimation mode",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				Decimation(DecimationMode(-1)),
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "fails with scroll step too low",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -1479,6 +1496,252 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "series fails on unsupported line style",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesLineStyle(LineStyle(-1)))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "series fails on unsupported marker",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesMarker(Marker(-1)))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "draws a dashed line",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesLineStyle(LineStyleDashed))
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0}, draw.BrailleLineDashed(2))
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws only markers in a scatter series",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100},
+					SeriesLineStyle(LineStyleNone),
+					SeriesMarker(MarkerCross),
+					SeriesCellOpts(cell.FgColor(cell.ColorGreen)),
+				)
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Markers, partially outside of the graph.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				opts := []cell.Option{cell.FgColor(cell.ColorGreen)}
+				testbraille.MustSetPixel(bc, image.Point{0, 31}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{1, 30}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{26, 0}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{25, 1}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{27, 1}, opts...)
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws a marker for a series with just one point",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{1}, SeriesMarker(MarkerDot))
+			},
+			wantCapacity: 2,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{1, 0}, End: image.Point{1, 2}},
+					{Start: image.Point{1, 2}, End: image.Point{2, 2}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "…", image.Point{0, 0})
+				testdraw.MustText(c, "0", image.Point{0, 1})
+				testdraw.MustText(c, "0", image.Point{2, 3})
+
+				// Marker.
+				bc := testbraille.MustNew(image.Rect(2, 0, 3, 2))
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "formats the Y axis labels",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YAxisFormatter(func(v float64) string {
+					return fmt.Sprintf("%.0f%%", v)
+				}),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 32,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{3, 0}, End: image.Point{3, 8}},
+					{Start: image.Point{3, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0%", image.Point{1, 7})
+				testdraw.MustText(c, "52%", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{4, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(4, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{30, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "fills the area under the line",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{100, 100},
+					SeriesLineStyle(LineStyleNone),
+					FillArea(),
+				)
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Filled area.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				for x := 0; x <= 26; x++ {
+					for y := 0; y <= 31; y++ {
+						testbraille.MustSetPixel(bc, image.Point{x, y})
+					}
+				}
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "fills the area above negative values up to the zero line",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{-100, -100},
+					SeriesLineStyle(LineStyleNone),
+					FillArea(cell.FgColor(cell.ColorRed)),
+				)
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-100", image.Point{2, 7})
+				testdraw.MustText(c, "-48.32", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Filled area, the zero line is at the top of the graph.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				for x := 0; x <= 25; x++ {
+					for y := 0; y <= 31; y++ {
+						testbraille.MustSetPixel(bc, image.Point{x, y}, cell.FgColor(cell.ColorRed))
+					}
+				}
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -38,10 +38,15 @@ type options struct {
 	xLabelOrientation   axes.LabelOrientation
 	yLabelCellOpts      []cell.Option
 	xAxisUnscaled       bool
+	xAxisMaxLabels      int
 	yAxisMode           axes.YScaleMode
 	yAxisCustomScale    *customScale
+	yAxisFormatter      func(float64) string
 	zoomHightlightColor cell.Color
 	zoomStepPercent     int
+	crosshair           bool
+	crosshairCellOpts   []cell.Option
+	decimation          DecimationMode
 }
 
 // validate validates the provided options.
@@ -54,6 +59,12 @@ func (o *options) validate() error {
 			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as custom Y scale", o.yAxisCustomScale.min, o.yAxisCustomScale.max)
 		}
 	}
+	if _, ok := decimationModeNames[o.decimation]; !ok {
+		return fmt.Errorf("unsupported decimation mode %v(%d)", o.decimation, o.decimation)
+	}
+	if got := o.xAxisMaxLabels; got < 0 {
+		return fmt.Errorf("invalid XAxisLabels %d, cannot be negative", got)
+	}
 	if got, min, max := o.zoomStepPercent, 1, 100; got < min || got > max {
 		return fmt.Errorf("invalid ZoomStepPercent %d, must be in range %d <= value <= %d", got, min, max)
 	}
@@ -159,6 +170,18 @@ func YAxisCustomScale(min, max float64) Option {
 	})
 }
 
+// YAxisFormatter sets a function that formats the values of the labels on the
+// Y axis, e.g. to display units like "1.5 GiB" or "2m30s". The function
+// receives the value represented by the row of the label. Defaults to
+// displaying the values as plain numbers.
+// The Y axis is made wide enough to fit the formatted labels of the smallest
+// and the largest value. Labels that still don't fit are trimmed.
+func YAxisFormatter(fn func(float64) string) Option {
+	return option(func(opts *options) {
+		opts.yAxisFormatter = fn
+	})
+}
+
 // XAxisUnscaled when provided, stops the LineChart from rescaling the X axis
 // when it can't fit all the values in the series, instead the LineCharts only
 // displays the last n values that fit into its width. This is useful to create
@@ -176,6 +199,19 @@ func XAxisUnscaled() Option {
 	})
 }
 
+// XAxisLabels limits the number of labels drawn under the X axis to at most
+// the provided maximum. The labels are spaced evenly and placed on "nice"
+// positions in the series, i.e. multiples of 1, 2, 5, 10, 20, 50 and so on.
+// Labels that don't fit under the axis are never drawn, so fewer labels than
+// the maximum might be displayed. Custom labels provided via SeriesXLabels are
+// used on the chosen positions.
+// The default behavior is to draw as many labels as fit under the X axis.
+func XAxisLabels(max int) Option {
+	return option(func(opts *options) {
+		opts.xAxisMaxLabels = max
+	})
+}
+
 // ZoomHightlightColor sets the background color of the area that is selected
 // with mouse in order to zoom the linechart.
 // Defaults to color number 235.
@@ -194,3 +230,29 @@ func ZoomStepPercent(perc int) Option {
 		opts.zoomStepPercent = perc
 	})
 }
+
+// Crosshair configures the LineChart to draw a vertical line at the column of
+// the graph where the last mouse event occurred along with a readout of the
+// value on the X axis and the values of all the series at that column. The
+// readout is drawn in the top right corner of the graph.
+// Terminals only report mouse events when a button is pressed or released, so
+// the crosshair follows mouse clicks and drags. It disappears when a mouse
+// event occurs outside of the graph.
+// The provided cell options are applied to the line and the readout.
+func Crosshair(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.crosshair = true
+		opts.crosshairCellOpts = cOpts
+	})
+}
+
+// Decimation sets the mode used to reduce the number of points of the series
+// before they are connected with lines. Decimation speeds up drawing of series
+// that have many more values than there are pixels on the X axis. Dashed
+// lines and markers are always drawn using all the points.
+// Defaults to DecimationMinMax, use DecimationNone to disable decimation.
+func Decimation(dm DecimationMode) Option {
+	return option(func(opts *options) {
+		opts.decimation = dm
+	})
+}
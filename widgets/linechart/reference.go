@@ -0,0 +1,229 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// reference.go contains code that draws the horizontal and vertical reference
+// lines.
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/braille"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+const (
+	// yReferenceRune is the rune used to draw the horizontal reference lines.
+	yReferenceRune = '─'
+	// xReferenceRune is the rune used to draw the vertical reference lines.
+	xReferenceRune = '│'
+)
+
+// reference is a reference line.
+type reference struct {
+	// value is the value on the Y axis for horizontal lines or the position
+	// on the X axis for vertical lines.
+	value float64
+	// label is the optional label of the line.
+	label string
+	// cellOpts are the cell options of the line and its label.
+	cellOpts []cell.Option
+}
+
+// ReferenceOption is used to provide options to AddYReference and
+// AddXReference.
+type ReferenceOption interface {
+	// set sets the provided option.
+	set(*reference)
+}
+
+// referenceOption implements ReferenceOption.
+type referenceOption func(*reference)
+
+// set implements ReferenceOption.set.
+func (ro referenceOption) set(r *reference) {
+	ro(r)
+}
+
+// ReferenceCellOpts sets the cell options of the reference line and its
+// label, e.g. its color.
+func ReferenceCellOpts(co ...cell.Option) ReferenceOption {
+	return referenceOption(func(r *reference) {
+		r.cellOpts = co
+	})
+}
+
+// ReferenceLabel sets a label drawn at the edge of the reference line away
+// from the axis labels. Horizontal lines have their label at the right edge of
+// the graph, vertical lines at the top, next to the line.
+func ReferenceLabel(label string) ReferenceOption {
+	return referenceOption(func(r *reference) {
+		r.label = label
+	})
+}
+
+// newReference returns a new reference line at the value.
+func newReference(value float64, opts []ReferenceOption) *reference {
+	r := &reference{value: value}
+	for _, opt := range opts {
+		opt.set(r)
+	}
+	return r
+}
+
+// AddYReference adds a horizontal reference line at the provided value on
+// the Y axis, e.g. to mark a threshold. The Y axis scales so that the line is
+// always visible.
+// The line is drawn across the graph in the cells that don't contain any part
+// of the series.
+func (lc *LineChart) AddYReference(value float64, opts ...ReferenceOption) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("invalid Y reference value %v, must be a finite number", value)
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.yRefs = append(lc.yRefs, newReference(value, opts))
+	lc.yMin, lc.yMax = lc.yMinMax()
+	return nil
+}
+
+// AddXReference adds a vertical reference line at the provided position on
+// the X axis, i.e. at the index of a value in the series.
+// The line is only drawn when the position is visible on the X axis and only
+// in the cells that don't contain any part of the series.
+func (lc *LineChart) AddXReference(x int, opts ...ReferenceOption) error {
+	if x < 0 {
+		return fmt.Errorf("invalid X reference position %d, must be a zero or positive integer", x)
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.xRefs = append(lc.xRefs, newReference(float64(x), opts))
+	return nil
+}
+
+// ClearReferences removes all the reference lines.
+func (lc *LineChart) ClearReferences() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.yRefs = nil
+	lc.xRefs = nil
+	lc.yMin, lc.yMax = lc.yMinMax()
+}
+
+// drawReferenceLine sets the rune in the cells between the two points that
+// don't contain any part of the graph.
+func drawReferenceLine(cvs *canvas.Canvas, start, end image.Point, r rune, opts []cell.Option) error {
+	for y := start.Y; y <= end.Y; y++ {
+		for x := start.X; x <= end.X; x++ {
+			p := image.Point{x, y}
+			got, err := cvs.RuneAt(p)
+			if err != nil {
+				return err
+			}
+			if !isEmptyCell(got) {
+				continue
+			}
+			if _, err := cvs.SetCell(p, r, opts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// drawReferenceLabel draws the label starting at the point, trimming it to
+// the provided width.
+func drawReferenceLabel(cvs *canvas.Canvas, ref *reference, start image.Point, width int) error {
+	if ref.label == "" || width <= 0 {
+		return nil
+	}
+	_, err := draw.Text(cvs, ref.label, start,
+		draw.TextMaxX(start.X+width),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(ref.cellOpts...),
+	)
+	return err
+}
+
+// drawReferences draws the reference lines and their labels onto the graph.
+func (lc *LineChart) drawReferences(cvs *canvas.Canvas, graphAr image.Rectangle, xd *axes.XDetails, yd *axes.YDetails) error {
+	for _, ref := range lc.yRefs {
+		py, err := yd.Scale.ValueToPixel(ref.value)
+		if err != nil {
+			return fmt.Errorf("failure for Y reference %v, yd.Scale.ValueToPixel => %v", ref.value, err)
+		}
+		row := graphAr.Min.Y + py/braille.RowMult
+		if err := drawReferenceLine(cvs,
+			image.Point{graphAr.Min.X, row},
+			image.Point{graphAr.Max.X - 1, row},
+			yReferenceRune, ref.cellOpts,
+		); err != nil {
+			return err
+		}
+
+		width := runewidth.StringWidth(ref.label)
+		if width > graphAr.Dx() {
+			width = graphAr.Dx()
+		}
+		if err := drawReferenceLabel(cvs, ref, image.Point{graphAr.Max.X - width, row}, width); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range lc.xRefs {
+		cellX, err := xd.Scale.ValueToCell(int(ref.value))
+		if err != nil {
+			continue // The position isn't visible on the X axis.
+		}
+		col := graphAr.Min.X + cellX
+		if err := drawReferenceLine(cvs,
+			image.Point{col, graphAr.Min.Y},
+			image.Point{col, graphAr.Max.Y - 1},
+			xReferenceRune, ref.cellOpts,
+		); err != nil {
+			return err
+		}
+
+		// Prefer the right side of the line, unless the label only fits on
+		// the left.
+		width := runewidth.StringWidth(ref.label)
+		right := graphAr.Max.X - col - 1
+		left := col - graphAr.Min.X
+		if width <= right || right >= left {
+			if width > right {
+				width = right
+			}
+			if err := drawReferenceLabel(cvs, ref, image.Point{col + 1, graphAr.Min.Y}, width); err != nil {
+				return err
+			}
+			continue
+		}
+		if width > left {
+			width = left
+		}
+		if err := drawReferenceLabel(cvs, ref, image.Point{col - width, graphAr.Min.Y}, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
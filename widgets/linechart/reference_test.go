@@ -0,0 +1,218 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/faketerm"
+)
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		desc    string
+		refs    func(lc *LineChart) error
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "no reference lines",
+			refs: func(lc *LineChart) error {
+				return nil
+			},
+			want: []string{
+				"     │         ⡰⠑⠤⡀        ",
+				"     │       ⢀⠜   ⠈⠒⢄      ",
+				"     │      ⢀⠎       ⠉⠢⣀   ",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │    ⡔⠁              ⠈",
+				"     │  ⢀⠎                 ",
+				"     │ ⢠⠊                  ",
+				"    0│⡰⠁                   ",
+				"     └─────────────────────",
+				"      0         1         2",
+			},
+		},
+		{
+			desc: "horizontal reference line with a label",
+			refs: func(lc *LineChart) error {
+				return lc.AddYReference(50, ReferenceLabel("SLA"), ReferenceCellOpts(cell.FgColor(cell.ColorRed)))
+			},
+			want: []string{
+				"     │         ⡰⠑⠤⡀        ",
+				"     │       ⢀⠜   ⠈⠒⢄      ",
+				"     │      ⢀⠎       ⠉⠢⣀   ",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │────⡔⠁────────────SLA",
+				"     │  ⢀⠎                 ",
+				"     │ ⢠⠊                  ",
+				"    0│⡰⠁                   ",
+				"     └─────────────────────",
+				"      0         1         2",
+			},
+		},
+		{
+			desc: "horizontal reference line outside of the values rescales the Y axis",
+			refs: func(lc *LineChart) error {
+				return lc.AddYReference(200)
+			},
+			want: []string{
+				"      │────────────────────",
+				"      │                    ",
+				"      │                    ",
+				"103.36│                    ",
+				"      │       ⢀⠤⠊⠑⠒⠤⣀⡀     ",
+				"      │     ⡠⠔⠁      ⠈⠉⠒⠤⢄⡀",
+				"      │  ⢀⠔⠊               ",
+				"     0│⡠⠒⠁                 ",
+				"      └────────────────────",
+				"       0        1         2",
+			},
+		},
+		{
+			desc: "vertical reference line with a label",
+			refs: func(lc *LineChart) error {
+				return lc.AddXReference(1, ReferenceLabel("deploy"))
+			},
+			want: []string{
+				"     │         ⡰⠑deploy    ",
+				"     │       ⢀⠜ │ ⠈⠒⢄      ",
+				"     │      ⢀⠎  │    ⠉⠢⣀   ",
+				"51.68│     ⡠⠃   │       ⠑⠤⡀",
+				"     │    ⡔⠁    │         ⠈",
+				"     │  ⢀⠎      │          ",
+				"     │ ⢠⠊       │          ",
+				"    0│⡰⠁        │          ",
+				"     └─────────────────────",
+				"      0         1         2",
+			},
+		},
+		{
+			desc: "vertical reference line label is drawn on the left when it doesn't fit on the right",
+			refs: func(lc *LineChart) error {
+				return lc.AddXReference(2, ReferenceLabel("end"))
+			},
+			want: []string{
+				"     │         ⡰⠑⠤⡀    end│",
+				"     │       ⢀⠜   ⠈⠒⢄     │",
+				"     │      ⢀⠎       ⠉⠢⣀  │",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │    ⡔⠁              ⠈",
+				"     │  ⢀⠎                │",
+				"     │ ⢠⠊                 │",
+				"    0│⡰⠁                  │",
+				"     └─────────────────────",
+				"      0         1         2",
+			},
+		},
+		{
+			desc: "vertical reference line outside of the X axis isn't drawn",
+			refs: func(lc *LineChart) error {
+				return lc.AddXReference(10, ReferenceLabel("later"))
+			},
+			want: []string{
+				"     │         ⡰⠑⠤⡀        ",
+				"     │       ⢀⠜   ⠈⠒⢄      ",
+				"     │      ⢀⠎       ⠉⠢⣀   ",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │    ⡔⠁              ⠈",
+				"     │  ⢀⠎                 ",
+				"     │ ⢠⠊                  ",
+				"    0│⡰⠁                   ",
+				"     └─────────────────────",
+				"      0         1         2",
+			},
+		},
+		{
+			desc: "cleared reference lines aren't drawn",
+			refs: func(lc *LineChart) error {
+				if err := lc.AddYReference(200); err != nil {
+					return err
+				}
+				if err := lc.AddXReference(1); err != nil {
+					return err
+				}
+				lc.ClearReferences()
+				return nil
+			},
+			want: []string{
+				"     │         ⡰⠑⠤⡀        ",
+				"     │       ⢀⠜   ⠈⠒⢄      ",
+				"     │      ⢀⠎       ⠉⠢⣀   ",
+				"51.68│     ⡠⠃           ⠑⠤⡀",
+				"     │    ⡔⠁              ⠈",
+				"     │  ⢀⠎                 ",
+				"     │ ⢠⠊                  ",
+				"    0│⡰⠁                   ",
+				"     └─────────────────────",
+				"      0         1         2",
+			},
+		},
+		{
+			desc: "fails on a Y reference that isn't a finite number",
+			refs: func(lc *LineChart) error {
+				return lc.AddYReference(math.NaN())
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on a negative X reference",
+			refs: func(lc *LineChart) error {
+				return lc.AddXReference(-1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := lc.Series("first", []float64{0, 100, 50}); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			err = tc.refs(lc)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("refs => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			ar := image.Rect(0, 0, 27, 10)
+			c := testcanvas.MustNew(ar)
+			if err := lc.Draw(c); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			ft := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(c, ft)
+
+			var got []string
+			for y := 0; y < ar.Dy(); y++ {
+				got = append(got, ft.CellsAsString(image.Rect(0, y, ar.Dx(), y+1)))
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Draw => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
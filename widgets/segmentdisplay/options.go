@@ -16,8 +16,11 @@ package segmentdisplay
 
 import (
 	"fmt"
+	"image"
 
 	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/segdisp/sixteen"
 )
 
 // options.go contains configurable options for SegmentDisplay.
@@ -38,10 +41,18 @@ func (o option) set(opts *options) {
 
 // options holds the provided options.
 type options struct {
-	hAlign          align.Horizontal
-	vAlign          align.Vertical
-	maximizeSegSize bool
-	gapPercent      int
+	hAlign           align.Horizontal
+	vAlign           align.Vertical
+	maximizeSegSize  bool
+	gapPercent       int
+	minimizeGaps     bool
+	fillFromRight    bool
+	thicknessPerc    int
+	showUnlit        bool
+	unlitCellOpts    []cell.Option
+	segmentSize      image.Point
+	clampSegmentSize bool
+	placeholder      rune
 }
 
 // validate validates the provided options.
@@ -49,15 +60,22 @@ func (o *options) validate() error {
 	if min, max := 0, 100; o.gapPercent < min || o.gapPercent > max {
 		return fmt.Errorf("invalid GapPercent %d, must be %d <= value <= %d", o.gapPercent, min, max)
 	}
+	if min := 1; o.thicknessPerc < min {
+		return fmt.Errorf("invalid ThicknessPercent %d, must be %d <= value", o.thicknessPerc, min)
+	}
+	if s := o.segmentSize; s != image.ZP && (s.X < sixteen.MinCols || s.Y < sixteen.MinRows) {
+		return fmt.Errorf("invalid SegmentSize %dx%d, must be at least %dx%d", s.X, s.Y, sixteen.MinCols, sixteen.MinRows)
+	}
 	return nil
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
-		hAlign:     align.HorizontalCenter,
-		vAlign:     align.VerticalMiddle,
-		gapPercent: DefaultGapPercent,
+		hAlign:        align.HorizontalCenter,
+		vAlign:        align.VerticalMiddle,
+		gapPercent:    DefaultGapPercent,
+		thicknessPerc: DefaultThicknessPercent,
 	}
 }
 
@@ -110,3 +128,105 @@ func GapPercent(perc int) Option {
 		opts.gapPercent = perc
 	})
 }
+
+// DefaultThicknessPercent is the default value for the ThicknessPercent
+// option.
+const DefaultThicknessPercent = sixteen.DefaultThicknessPercent
+
+// ThicknessPercent sets the thickness of the segments expressed as a
+// percentage of their default thickness, which is proportional to the size of
+// the characters. E.g. 150 makes the segments one and a half times as thick.
+// Segments thicker than the default are made thinner as needed so that they
+// don't overlap, down to the default thickness.
+// The value must be a positive integer.
+func ThicknessPercent(perc int) Option {
+	return option(func(opts *options) {
+		opts.thicknessPerc = perc
+	})
+}
+
+// MinimizeGaps tells the widget to reduce the gaps between individual segments
+// when the user has provided more text than we can fit on the canvas.
+// The gaps are reduced, down to no gaps at all, before the widget decreases
+// the height of the segments or trims the text. The size of the gaps never
+// exceeds the one set by GapPercent.
+// The default behavior is to keep the gaps at the size set by GapPercent.
+func MinimizeGaps() Option {
+	return option(func(opts *options) {
+		opts.minimizeGaps = true
+	})
+}
+
+// FillFromRight tells the widget to fill the segments starting with the last
+// character of the text, placing it at the right edge of the canvas. This
+// keeps the last character anchored in place as the text grows, e.g. for
+// counters. When the user has provided more text than we can fit on the
+// canvas, the leading characters are trimmed instead of the trailing ones.
+// The AlignHorizontal option has no effect when this option is provided.
+// The default behavior is to fill from the left and trim the trailing
+// characters.
+func FillFromRight() Option {
+	return option(func(opts *options) {
+		opts.fillFromRight = true
+	})
+}
+
+// DefaultUnlitColor is the default color of the unlit segments, see ShowUnlit.
+var DefaultUnlitColor = cell.ColorNumber(8)
+
+// ShowUnlit tells the widget to draw all the segments of each character,
+// including the ones that are off, which resembles a real segment display.
+// The segments that are off are drawn with the provided cell options which
+// default to the DefaultUnlitColor foreground color, the segments that are on
+// are drawn over them with the options provided via WriteCellOpts.
+// The default behavior is to only draw the segments that are on.
+func ShowUnlit(opts ...cell.Option) Option {
+	return option(func(o *options) {
+		o.showUnlit = true
+		if len(opts) == 0 {
+			opts = []cell.Option{cell.FgColor(DefaultUnlitColor)}
+		}
+		o.unlitCellOpts = opts
+	})
+}
+
+// SegmentSize sets a fixed size of the individual display segments
+// (characters) in cells, so that their size doesn't change with the size of
+// the canvas or the length of the text. The widget draws as many characters
+// of the text as fit the canvas at this size and aligns them according to
+// AlignHorizontal and AlignVertical. Since the segments keep their aspect
+// ratio, the drawn segments can be smaller than the requested size in one of
+// the dimensions. The size must be at least the smallest supported size of a
+// segment, i.e. 6x5 cells.
+// Draw returns an error if the segment doesn't fit the canvas, unless the
+// ClampSegmentSize option is provided.
+// The default behavior is to size the segments according to the canvas.
+func SegmentSize(cols, rows int) Option {
+	return option(func(opts *options) {
+		opts.segmentSize = image.Point{cols, rows}
+	})
+}
+
+// ClampSegmentSize tells the widget to reduce the size set by SegmentSize to
+// the size of the canvas when the segment doesn't fit it instead of returning
+// an error.
+func ClampSegmentSize() Option {
+	return option(func(opts *options) {
+		opts.clampSegmentSize = true
+	})
+}
+
+// Placeholder sets a character that is displayed with all the segments off,
+// the same way as the space character. It still occupies the position of a
+// character, so it can be used to pad the text to a fixed width without
+// shifting the other characters, e.g. to suppress leading zeros or to reserve
+// space for digits of a clock before the time is known.
+// The placeholder can be any character, including one that the display
+// supports, which then no longer lights any segments.
+// With ShowUnlit, the placeholder is drawn as a character with all the
+// segments unlit.
+func Placeholder(r rune) Option {
+	return option(func(opts *options) {
+		opts.placeholder = r
+	})
+}
@@ -34,6 +34,9 @@ type segArea struct {
 	gapPixels int
 	// gaps is the number of gaps that will be drawn.
 	gaps int
+	// spaces is the total number of additional cells inserted between the
+	// segments that we can fit, see WriteSpaceAfter.
+	spaces int
 }
 
 // needArea returns the complete area required for all the segments that we can
@@ -42,24 +45,58 @@ func (sa *segArea) needArea() image.Rectangle {
 	return image.Rect(
 		0,
 		0,
-		sa.segment.Dx()*sa.canFit+sa.gaps*sa.gapPixels,
+		sa.segment.Dx()*sa.canFit+sa.gaps*sa.gapPixels+sa.spaces,
 		sa.segment.Dy(),
 	)
 }
 
 // newSegArea calculates the area for segments given available canvas area,
-// length of the text to be displayed and the size of gap between segments
-func newSegArea(cvsAr image.Rectangle, textLen, gapPercent int) (*segArea, error) {
+// length of the text to be displayed and the size of gap between segments.
+// The spaces are the additional cells inserted after each character of the
+// text, see WriteSpaceAfter. Can be nil if there are none.
+func newSegArea(cvsAr image.Rectangle, textLen int, spaces []int, gapPercent int) (*segArea, error) {
 	segAr, err := sixteen.Required(cvsAr)
 	if err != nil {
 		return nil, fmt.Errorf("sixteen.Required => %v", err)
 	}
+	return layoutSegments(cvsAr, segAr, textLen, spaces, gapPercent), nil
+}
+
+// fixedSegArea calculates the area for segments of the provided size in cells
+// given available canvas area, length of the text to be displayed and the
+// size of gap between segments.
+// If the segment doesn't fit the canvas, returns an error or reduces the size
+// of the segment to the size of the canvas when clamp is true.
+func fixedSegArea(cvsAr image.Rectangle, size image.Point, textLen int, spaces []int, gapPercent int, clamp bool) (*segArea, error) {
+	if size.X > cvsAr.Dx() || size.Y > cvsAr.Dy() {
+		if !clamp {
+			return nil, fmt.Errorf("the segment size %v doesn't fit the canvas area %v, provide the ClampSegmentSize option to reduce it", size, cvsAr)
+		}
+		if size.X > cvsAr.Dx() {
+			size.X = cvsAr.Dx()
+		}
+		if size.Y > cvsAr.Dy() {
+			size.Y = cvsAr.Dy()
+		}
+	}
+
+	segAr, err := sixteen.Required(image.Rectangle{cvsAr.Min, cvsAr.Min.Add(size)})
+	if err != nil {
+		return nil, fmt.Errorf("sixteen.Required => %v", err)
+	}
+	return layoutSegments(cvsAr, segAr, textLen, spaces, gapPercent), nil
+}
+
+// layoutSegments determines how many segments of the provided area and the
+// gaps and spaces between them fit the width of the canvas.
+func layoutSegments(cvsAr, segAr image.Rectangle, textLen int, spaces []int, gapPercent int) *segArea {
 	gapPixels := segAr.Dy() * gapPercent / 100
 
 	var (
-		gaps   int
-		canFit int
-		taken  int
+		gaps      int
+		canFit    int
+		taken     int
+		spacesSum int
 	)
 	for i := 0; i < textLen; i++ {
 		taken += segAr.Dx()
@@ -71,15 +108,25 @@ func newSegArea(cvsAr image.Rectangle, textLen, gapPercent int) (*segArea, error
 
 		// Don't insert gaps after the last segment in the text or the last
 		// segment we can fit.
-		if gapPixels == 0 || i == textLen-1 {
+		if i == textLen-1 {
+			continue
+		}
+		var space int
+		if i < len(spaces) {
+			space = spaces[i]
+		}
+		if gapPixels == 0 && space == 0 {
 			continue
 		}
 
 		remaining := cvsAr.Dx() - taken
 		// Only insert gaps if we can still fit one more segment with the gap.
-		if remaining >= gapPixels+segAr.Dx() {
-			taken += gapPixels
-			gaps++
+		if remaining >= gapPixels+space+segAr.Dx() {
+			taken += gapPixels + space
+			if gapPixels > 0 {
+				gaps++
+			}
+			spacesSum += space
 		} else {
 			// Gap is needed but doesn't fit together with the next segment.
 			// So insert neither.
@@ -91,25 +138,63 @@ func newSegArea(cvsAr image.Rectangle, textLen, gapPercent int) (*segArea, error
 		canFit:    canFit,
 		gapPixels: gapPixels,
 		gaps:      gaps,
-	}, nil
+		spaces:    spacesSum,
+	}
+}
+
+// minimizeGaps finds the largest gap between segments, not exceeding the
+// provided gap percentage, that enables us to fit all the characters onto a
+// canvas with the provided area. If all the characters don't fit even without
+// gaps, returns the area that fits the most characters.
+func minimizeGaps(cvsAr image.Rectangle, textLen int, spaces []int, gapPercent int) (*segArea, error) {
+	var bestSegAr *segArea
+	for perc := gapPercent; perc >= 0; perc-- {
+		segAr, err := newSegArea(cvsAr, textLen, spaces, perc)
+		if err != nil {
+			return nil, err
+		}
+
+		if segAr.canFit >= textLen {
+			return segAr, nil
+		}
+		if bestSegAr == nil || segAr.canFit > bestSegAr.canFit {
+			bestSegAr = segAr
+		}
+	}
+	return bestSegAr, nil
 }
 
 // maximizeFit finds the largest individual segment size that enables us to fit
 // the most characters onto a canvas with the provided area. Returns the area
 // required for a single segment and the number of segments we can fit.
-func maximizeFit(cvsAr image.Rectangle, textLen, gapPercent int) (*segArea, error) {
-	var bestSegAr *segArea
+// If minGaps is true, the gaps between segments are reduced before the size of
+// the segments is.
+func maximizeFit(cvsAr image.Rectangle, textLen int, spaces []int, gapPercent int, minGaps bool) (*segArea, error) {
+	fitPercent := gapPercent
+	if minGaps {
+		// Segments fit best without any gaps, the gaps are minimized only once
+		// the size of the segments is chosen.
+		fitPercent = 0
+	}
+
+	var (
+		bestSegAr *segArea
+		bestAr    image.Rectangle
+	)
 	for height := cvsAr.Dy(); height >= sixteen.MinRows; height-- {
 		cvsAr := image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Min.Y+height)
-		segAr, err := newSegArea(cvsAr, textLen, gapPercent)
+		segAr, err := newSegArea(cvsAr, textLen, spaces, fitPercent)
 		if err != nil {
 			return nil, err
 		}
 
+		bestSegAr, bestAr = segAr, cvsAr
 		if segAr.canFit >= textLen {
-			return segAr, nil
+			break
 		}
-		bestSegAr = segAr
+	}
+	if minGaps && bestSegAr != nil {
+		return minimizeGaps(bestAr, textLen, spaces, gapPercent)
 	}
 	return bestSegAr, nil
 }
@@ -21,8 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"strings"
 	"sync"
 
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/alignfor"
 	"github.com/mum4k/termdash/internal/attrrange"
 	"github.com/mum4k/termdash/internal/canvas"
@@ -49,6 +52,15 @@ type SegmentDisplay struct {
 	givenWOpts []*writeOptions
 	// wOptsTracker tracks the positions in a buff to which the givenWOpts apply.
 	wOptsTracker *attrrange.Tracker
+	// spaces are the additional cells inserted after each character in buff,
+	// see WriteSpaceAfter. Nil if there are none.
+	spaces []int
+	// unsupported are the positions in buff of the characters drawn as the
+	// error glyph, see WriteUnsupportedGlyph. Nil if there are none.
+	unsupported map[int]bool
+
+	// notify requests a redraw of the terminal, nil until SetNotify is called.
+	notify func()
 
 	// mu protects the widget.
 	mu sync.Mutex
@@ -118,27 +130,93 @@ func (sd *SegmentDisplay) Write(chunks []*TextChunk, opts ...Option) error {
 		if tc.text == "" {
 			return fmt.Errorf("text chunk[%d] is empty, all chunks must contains some text", i)
 		}
-		if ok, badRunes := sixteen.SupportsChars(tc.text); !ok && tc.wOpts.errOnUnsupported {
+		if tc.wOpts.spaceAfter < 0 {
+			return fmt.Errorf("text chunk[%d] has invalid WriteSpaceAfter(%d), must be zero or a positive number", i, tc.wOpts.spaceAfter)
+		}
+		text := sd.replacePlaceholder(tc.text)
+		if ok, badRunes := sixteen.SupportsChars(text); !ok && tc.wOpts.errOnUnsupported {
 			return fmt.Errorf("text chunk[%d] contains unsupported characters %v, clean the text or provide the WriteSanitize option", i, badRunes)
 		}
-		text := sixteen.Sanitize(tc.text)
-
 		pos := sd.buff.Len()
+		if tc.wOpts.unsupportedGlyph {
+			sd.markUnsupported(pos, text)
+		}
+		text = sixteen.Sanitize(text)
+
 		sd.givenWOpts = append(sd.givenWOpts, tc.wOpts)
 		wOptsIdx := len(sd.givenWOpts) - 1
 		if err := sd.wOptsTracker.Add(pos, pos+len(text), wOptsIdx); err != nil {
 			return err
 		}
 		sd.buff.WriteString(text)
+
+		if space := tc.wOpts.spaceAfter; space > 0 {
+			sd.spaces = append(sd.spaces, make([]int, sd.buff.Len()-len(sd.spaces))...)
+			sd.spaces[sd.buff.Len()-1] = space
+		}
 	}
+	sd.notifyChanged()
 	return nil
 }
 
+// markUnsupported records the positions of the characters in the text that
+// the display doesn't support, so they are drawn as the error glyph. The pos
+// is the position in buff where the sanitized text starts.
+// Caller must hold sd.mu.
+func (sd *SegmentDisplay) markUnsupported(pos int, text string) {
+	var i int
+	for _, r := range text {
+		if _, ok := sixteen.CharacterMask(r); !ok {
+			if sd.unsupported == nil {
+				sd.unsupported = map[int]bool{}
+			}
+			sd.unsupported[pos+i] = true
+		}
+		// Sanitize replaces unsupported characters with a space and the
+		// supported characters are all ASCII, so each rune occupies one byte
+		// in buff.
+		i++
+	}
+}
+
+// replacePlaceholder returns a copy of the text with all the occurrences of
+// the character set via the Placeholder option replaced with a space.
+// Caller must hold sd.mu.
+func (sd *SegmentDisplay) replacePlaceholder(text string) string {
+	p := sd.opts.placeholder
+	if p == 0 {
+		return text
+	}
+	return strings.Map(func(r rune) rune {
+		if r == p {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
 // Reset resets the widget back to empty content.
 func (sd *SegmentDisplay) Reset() {
 	sd.mu.Lock()
 	defer sd.mu.Unlock()
 	sd.reset()
+	sd.notifyChanged()
+}
+
+// SetNotify implements widgetapi.Notifier.SetNotify.
+// The widget requests a redraw each time its text changes.
+func (sd *SegmentDisplay) SetNotify(fn func()) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.notify = fn
+}
+
+// notifyChanged requests a redraw of the terminal if SetNotify was called.
+// Caller must hold sd.mu.
+func (sd *SegmentDisplay) notifyChanged() {
+	if sd.notify != nil {
+		sd.notify()
+	}
 }
 
 // reset is the implementation of Reset.
@@ -147,6 +225,36 @@ func (sd *SegmentDisplay) reset() {
 	sd.buff.Reset()
 	sd.givenWOpts = nil
 	sd.wOptsTracker = attrrange.NewTracker()
+	sd.spaces = nil
+	sd.unsupported = nil
+}
+
+// layoutSpaces returns the additional cells inserted after each character in
+// the order in which the characters are laid out on the canvas. When filling
+// from the right, the characters are laid out starting with the last one, so
+// the space after character i becomes the space after its mirror image.
+// Caller must hold sd.mu.
+func (sd *SegmentDisplay) layoutSpaces() []int {
+	if sd.spaces == nil || !sd.opts.fillFromRight {
+		return sd.spaces
+	}
+
+	textLen := sd.buff.Len()
+	res := make([]int, textLen)
+	for i := 0; i < textLen-1 && i < len(sd.spaces); i++ {
+		res[textLen-2-i] = sd.spaces[i]
+	}
+	return res
+}
+
+// spaceAfter returns the additional cells inserted after the character at the
+// provided position in buff.
+// Caller must hold sd.mu.
+func (sd *SegmentDisplay) spaceAfter(i int) int {
+	if i < len(sd.spaces) {
+		return sd.spaces[i]
+	}
+	return 0
 }
 
 // preprocess determines the size of individual segments maximizing their
@@ -155,23 +263,55 @@ func (sd *SegmentDisplay) reset() {
 // size of gaps between segments in cells.
 func (sd *SegmentDisplay) preprocess(cvsAr image.Rectangle) (*segArea, error) {
 	textLen := sd.buff.Len() // We're guaranteed by Write to only have ASCII characters.
-	segAr, err := newSegArea(cvsAr, textLen, sd.opts.gapPercent)
+	spaces := sd.layoutSpaces()
+	if size := sd.opts.segmentSize; size != image.ZP {
+		return fixedSegArea(cvsAr, size, textLen, spaces, sd.opts.gapPercent, sd.opts.clampSegmentSize)
+	}
+
+	segAr, err := newSegArea(cvsAr, textLen, spaces, sd.opts.gapPercent)
 	if err != nil {
 		return nil, err
 	}
 
 	need := sd.buff.Len()
-	if need <= segAr.canFit || sd.opts.maximizeSegSize {
+	if need <= segAr.canFit {
 		return segAr, nil
 	}
 
-	bestAr, err := maximizeFit(cvsAr, textLen, sd.opts.gapPercent)
+	if sd.opts.minimizeGaps {
+		segAr, err = minimizeGaps(cvsAr, textLen, spaces, sd.opts.gapPercent)
+		if err != nil {
+			return nil, err
+		}
+		if need <= segAr.canFit {
+			return segAr, nil
+		}
+	}
+	if sd.opts.maximizeSegSize {
+		return segAr, nil
+	}
+
+	bestAr, err := maximizeFit(cvsAr, textLen, spaces, sd.opts.gapPercent, sd.opts.minimizeGaps)
 	if err != nil {
 		return nil, err
 	}
 	return bestAr, nil
 }
 
+// SegmentSize returns the size of an individual display segment in cells that
+// the widget would use when drawing its current text on a canvas with the
+// provided area. Doesn't draw anything.
+func (sd *SegmentDisplay) SegmentSize(ar image.Rectangle) (image.Point, error) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	segAr, err := sd.preprocess(ar)
+	if err != nil {
+		return image.ZP, err
+	}
+	return segAr.segment.Size(), nil
+}
+
 // Draw draws the SegmentDisplay widget onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas) error {
@@ -188,12 +328,23 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas) error {
 	}
 
 	text := sd.buff.String()
-	aligned, err := alignfor.Rectangle(cvs.Area(), segAr.needArea(), sd.opts.hAlign, sd.opts.vAlign)
+	hAlign := sd.opts.hAlign
+	if sd.opts.fillFromRight {
+		hAlign = align.HorizontalRight
+	}
+	aligned, err := alignfor.Rectangle(cvs.Area(), segAr.needArea(), hAlign, sd.opts.vAlign)
 	if err != nil {
 		return fmt.Errorf("alignfor.Rectangle => %v", err)
 	}
 
-	optRange, err := sd.wOptsTracker.ForPosition(0) // Text options for the current byte.
+	// When filling from the right, the leading characters that don't fit are
+	// dropped so that the last character ends at aligned.Max.X.
+	var skip int
+	if sd.opts.fillFromRight && len(text) > segAr.canFit {
+		skip = len(text) - segAr.canFit
+	}
+
+	optRange, err := sd.wOptsTracker.ForPosition(skip) // Text options for the current byte.
 	if err != nil {
 		return err
 	}
@@ -201,12 +352,32 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas) error {
 	gaps := segAr.gaps
 	startX := aligned.Min.X
 	for i, c := range text {
-		if i >= segAr.canFit {
+		if i < skip {
+			continue
+		}
+		if i >= skip+segAr.canFit {
 			break
 		}
 
-		disp := sixteen.New()
-		if err := disp.SetCharacter(c); err != nil {
+		if i >= optRange.High { // Get the next write options.
+			or, err := sd.wOptsTracker.ForPosition(i)
+			if err != nil {
+				return err
+			}
+			optRange = or
+		}
+		wOpts := sd.givenWOpts[optRange.AttrIdx]
+
+		disp := sixteen.New(sixteen.ThicknessPercent(sd.opts.thicknessPerc))
+		cellOpts := wOpts.cellOpts
+		if sd.unsupported[i] {
+			for _, s := range sixteen.AllSegments() {
+				if err := disp.SetSegment(s); err != nil {
+					return fmt.Errorf("disp.SetSegment => %v", err)
+				}
+			}
+			cellOpts = wOpts.glyphCellOpts
+		} else if err := disp.SetCharacter(c); err != nil {
 			return fmt.Errorf("disp.SetCharacter => %v", err)
 		}
 
@@ -216,6 +387,16 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas) error {
 		if gaps > 0 {
 			startX += segAr.gapPixels
 			gaps--
+
+			if wOpts.gapColor != nil {
+				gapAr := image.Rect(endX, aligned.Min.Y, startX, aligned.Max.Y)
+				if err := cvs.SetAreaCells(gapAr, ' ', cell.BgColor(*wOpts.gapColor)); err != nil {
+					return fmt.Errorf("cvs.SetAreaCells => %v", err)
+				}
+			}
+		}
+		if i < skip+segAr.canFit-1 {
+			startX += sd.spaceAfter(i)
 		}
 
 		dCvs, err := canvas.New(ar)
@@ -223,16 +404,11 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas) error {
 			return fmt.Errorf("canvas.New => %v", err)
 		}
 
-		if i >= optRange.High { // Get the next write options.
-			or, err := sd.wOptsTracker.ForPosition(i)
-			if err != nil {
-				return err
-			}
-			optRange = or
+		dOpts := []sixteen.Option{sixteen.CellOpts(cellOpts...)}
+		if sd.opts.showUnlit {
+			dOpts = append(dOpts, sixteen.UnlitCellOpts(sd.opts.unlitCellOpts...))
 		}
-		wOpts := sd.givenWOpts[optRange.AttrIdx]
-
-		if err := disp.Draw(dCvs, sixteen.CellOpts(wOpts.cellOpts...)); err != nil {
+		if err := disp.Draw(dCvs, dOpts...); err != nil {
 			return fmt.Errorf("disp.Draw => %v", err)
 		}
 
@@ -255,9 +431,16 @@ func (*SegmentDisplay) Mouse(m *terminalapi.Mouse) error {
 
 // Options implements widgetapi.Widget.Options.
 func (sd *SegmentDisplay) Options() widgetapi.Options {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	// The smallest supported size of a display segment.
+	minSize := image.Point{sixteen.MinCols, sixteen.MinRows}
+	if size := sd.opts.segmentSize; size != image.ZP && !sd.opts.clampSegmentSize {
+		minSize = size
+	}
 	return widgetapi.Options{
-		// The smallest supported size of a display segment.
-		MinimumSize:  image.Point{sixteen.MinCols, sixteen.MinRows},
+		MinimumSize:  minSize,
 		WantKeyboard: widgetapi.KeyScopeNone,
 		WantMouse:    widgetapi.MouseScopeNone,
 	}
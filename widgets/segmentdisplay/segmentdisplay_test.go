@@ -39,6 +39,19 @@ func mustDrawChar(cvs *canvas.Canvas, char rune, ar image.Rectangle, opts ...six
 	testcanvas.MustCopyTo(c, cvs)
 }
 
+// mustDrawGlyph draws the error glyph with all the segments lit.
+func mustDrawGlyph(cvs *canvas.Canvas, ar image.Rectangle, opts ...sixteen.Option) {
+	d := sixteen.New()
+	for _, s := range sixteen.AllSegments() {
+		if err := d.SetSegment(s); err != nil {
+			panic(err)
+		}
+	}
+	c := testcanvas.MustNew(ar)
+	testsixteen.MustDraw(d, c, opts...)
+	testcanvas.MustCopyTo(c, cvs)
+}
+
 func TestSegmentDisplay(t *testing.T) {
 	tests := []struct {
 		desc          string
@@ -66,6 +79,14 @@ func TestSegmentDisplay(t *testing.T) {
 			canvas:     image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
 			wantNewErr: true,
 		},
+		{
+			desc: "New fails on invalid ThicknessPercent",
+			opts: []Option{
+				ThicknessPercent(0),
+			},
+			canvas:     image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			wantNewErr: true,
+		},
 		{
 			desc:   "write fails on invalid GapPercent (too low)",
 			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
@@ -152,6 +173,25 @@ func TestSegmentDisplay(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "draws thicker segments",
+			opts: []Option{
+				ThicknessPercent(150),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*6, sixteen.MinRows*6),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("8")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '8', ft.Area(), sixteen.ThicknessPercent(150))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc: "write sanitizes text by default",
 			opts: []Option{
@@ -190,6 +230,54 @@ func TestSegmentDisplay(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "write draws the error glyph instead of unsupported characters",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1.2", WriteUnsupportedGlyph())})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows))
+				mustDrawGlyph(cvs, image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows),
+					sixteen.CellOpts(cell.FgColor(DefaultUnsupportedGlyphColor)),
+				)
+				mustDrawChar(cvs, '2', image.Rect(sixteen.MinCols*2, 0, sixteen.MinCols*3, sixteen.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "error glyph uses its cell options and applies only to its chunk",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("。", WriteCellOpts(cell.FgColor(cell.ColorGreen)), WriteUnsupportedGlyph(cell.FgColor(cell.ColorBlue))),
+					NewChunk(".1"),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawGlyph(cvs, image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+					sixteen.CellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				mustDrawChar(cvs, '1', image.Rect(sixteen.MinCols*2, 0, sixteen.MinCols*3, sixteen.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "aligns segment vertical middle by default",
 			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows+2),
@@ -735,97 +823,874 @@ func TestSegmentDisplay(t *testing.T) {
 				return ft
 			},
 		},
-	}
+		{
+			desc: "draws multiple segments with a gap, not all fit, doesn't minimize gaps by default",
+			opts: []Option{
+				GapPercent(20),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3+1, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("888")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
 
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			sd, err := New(tc.opts...)
-			if (err != nil) != tc.wantNewErr {
-				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
-			}
-			if err != nil {
-				return
-			}
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'8', image.Rect(3, 0, 9, 5)},
+					{'8', image.Rect(10, 0, 16, 5)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
 
-			c, err := canvas.New(tc.canvas)
-			if err != nil {
-				t.Fatalf("canvas.New => unexpected error: %v", err)
-			}
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws multiple segments with a gap, not all fit, minimizes gaps with option",
+			opts: []Option{
+				GapPercent(20),
+				MinimizeGaps(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3+1, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("888")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
 
-			if tc.update != nil {
-				err = tc.update(sd)
-				if (err != nil) != tc.wantUpdateErr {
-					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
-				}
-				if err != nil {
-					return
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'8', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'8', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows)},
+					{'8', image.Rect(sixteen.MinCols*2, 0, sixteen.MinCols*3, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
 				}
-			}
 
-			err = sd.Draw(c)
-			if (err != nil) != tc.wantDrawErr {
-				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
-			}
-			if err != nil {
-				return
-			}
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws multiple segments with a gap, not all fit, minimizes gaps before reducing segment height",
+			opts: []Option{
+				GapPercent(20),
+				MinimizeGaps(),
+			},
+			canvas: image.Rect(0, 0, 25, sixteen.MinRows*2),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("88")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
 
-			got, err := faketerm.New(c.Size())
-			if err != nil {
-				t.Fatalf("faketerm.New => unexpected error: %v", err)
-			}
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'8', image.Rect(0, 0, 12, 10)},
+					{'8', image.Rect(13, 0, 25, 10)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
 
-			if err := c.Apply(got); err != nil {
-				t.Fatalf("Apply => unexpected error: %v", err)
-			}
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws multiple segments with a gap, minimizes gaps, not all fit even without gaps, maximizes segment height with option",
+			opts: []Option{
+				MaximizeSegmentHeight(),
+				GapPercent(20),
+				MinimizeGaps(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*5, sixteen.MinRows*2),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("123")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
 
-			var want *faketerm.Terminal
-			if tc.want != nil {
-				want = tc.want(c.Size())
-			} else {
-				want = faketerm.MustNew(c.Size())
-			}
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'1', image.Rect(2, 0, 14, 10)},
+					{'2', image.Rect(16, 0, 28, 10)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
 
-			if diff := faketerm.Diff(want, got); diff != "" {
-				t.Errorf("Draw => %v", diff)
-			}
-		})
-	}
-}
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fills from the right with option, trims leading characters",
+			opts: []Option{
+				MaximizeSegmentHeight(),
+				GapPercent(0),
+				FillFromRight(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("123")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
 
-func TestKeyboard(t *testing.T) {
-	sd, err := New()
-	if err != nil {
-		t.Fatalf("New => unexpected error: %v", err)
-	}
-	if err := sd.Keyboard(&terminalapi.Keyboard{}); err == nil {
-		t.Errorf("Keyboard => got nil err, wanted one")
-	}
-}
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'2', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'3', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
 
-func TestMouse(t *testing.T) {
-	sd, err := New()
-	if err != nil {
-		t.Fatalf("New => unexpected error: %v", err)
-	}
-	if err := sd.Mouse(&terminalapi.Mouse{}); err == nil {
-		t.Errorf("Mouse => got nil err, wanted one")
-	}
-}
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fills from the right with option, anchors text shorter than the canvas on the right",
+			opts: []Option{
+				GapPercent(0),
+				FillFromRight(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
 
-func TestOptions(t *testing.T) {
-	sd, err := New()
-	if err != nil {
-		t.Fatalf("New => unexpected error: %v", err)
-	}
-	got := sd.Options()
-	want := widgetapi.Options{
-		MinimumSize:  image.Point{sixteen.MinCols, sixteen.MinRows},
-		WantKeyboard: widgetapi.KeyScopeNone,
-		WantMouse:    widgetapi.MouseScopeNone,
-	}
-	if diff := pretty.Compare(want, got); diff != "" {
-		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
-	}
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'1', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows)},
+					{'2', image.Rect(sixteen.MinCols*2, 0, sixteen.MinCols*3, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fills from the right with option, uses cell options of the displayed chunks",
+			opts: []Option{
+				MaximizeSegmentHeight(),
+				GapPercent(0),
+				FillFromRight(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteCellOpts(cell.FgColor(cell.ColorRed))),
+					NewChunk("23", WriteCellOpts(cell.FgColor(cell.ColorBlue))),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'2', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'3', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area, sixteen.CellOpts(cell.FgColor(cell.ColorBlue)))
+				}
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "colors gaps between segments with write option",
+			opts: []Option{
+				GapPercent(20),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3+2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("123", WriteGapColor(cell.ColorRed))})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'2', image.Rect(sixteen.MinCols+1, 0, sixteen.MinCols*2+1, sixteen.MinRows)},
+					{'3', image.Rect(sixteen.MinCols*2+2, 0, sixteen.MinCols*3+2, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
+				for _, gap := range []image.Rectangle{
+					image.Rect(sixteen.MinCols, 0, sixteen.MinCols+1, sixteen.MinRows),
+					image.Rect(sixteen.MinCols*2+1, 0, sixteen.MinCols*2+2, sixteen.MinRows),
+				} {
+					testcanvas.MustSetAreaCells(cvs, gap, ' ', cell.BgColor(cell.ColorRed))
+				}
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "colors gaps between segments per text chunk",
+			opts: []Option{
+				GapPercent(20),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3+2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteGapColor(cell.ColorRed)),
+					NewChunk("2", WriteGapColor(cell.ColorBlue)),
+					NewChunk("3", WriteGapColor(cell.ColorGreen)),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'2', image.Rect(sixteen.MinCols+1, 0, sixteen.MinCols*2+1, sixteen.MinRows)},
+					{'3', image.Rect(sixteen.MinCols*2+2, 0, sixteen.MinCols*3+2, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
+				testcanvas.MustSetAreaCells(cvs, image.Rect(sixteen.MinCols, 0, sixteen.MinCols+1, sixteen.MinRows), ' ', cell.BgColor(cell.ColorRed))
+				testcanvas.MustSetAreaCells(cvs, image.Rect(sixteen.MinCols*2+1, 0, sixteen.MinCols*2+2, sixteen.MinRows), ' ', cell.BgColor(cell.ColorBlue))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the placeholder without any segments",
+			opts: []Option{
+				GapPercent(0),
+				Placeholder('#'),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1#2", WriteErrOnUnsupported())})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows))
+				mustDrawChar(cvs, '2', image.Rect(sixteen.MinCols*2, 0, sixteen.MinCols*3, sixteen.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "placeholder overrides a supported character and shows unlit segments",
+			opts: []Option{
+				GapPercent(0),
+				Placeholder('-'),
+				ShowUnlit(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("-1")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				unlit := sixteen.UnlitCellOpts(cell.FgColor(DefaultUnlitColor))
+				mustDrawChar(cvs, ' ', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows), unlit)
+				mustDrawChar(cvs, '1', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows), unlit)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws unlit segments with the default color",
+			opts: []Option{
+				ShowUnlit(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(
+					cvs, '1',
+					image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+					sixteen.UnlitCellOpts(cell.FgColor(DefaultUnlitColor)),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws unlit segments with the provided cell options",
+			opts: []Option{
+				ShowUnlit(cell.FgColor(cell.ColorBlue)),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteCellOpts(cell.FgColor(cell.ColorRed))),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(
+					cvs, '1',
+					image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+					sixteen.CellOpts(cell.FgColor(cell.ColorRed)),
+					sixteen.UnlitCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "New fails on SegmentSize smaller than the smallest segment",
+			opts: []Option{
+				SegmentSize(sixteen.MinCols-1, sixteen.MinRows),
+			},
+			canvas:     image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			wantNewErr: true,
+		},
+		{
+			desc: "draws segments of the fixed size aligned on a larger canvas",
+			opts: []Option{
+				GapPercent(0),
+				SegmentSize(sixteen.MinCols, sixteen.MinRows),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows*2),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(3, 2, 9, 7))
+				mustDrawChar(cvs, '2', image.Rect(9, 2, 15, 7))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws only the segments of the fixed size that fit",
+			opts: []Option{
+				GapPercent(0),
+				SegmentSize(sixteen.MinCols, sixteen.MinRows),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("123")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows))
+				mustDrawChar(cvs, '2', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "Draw fails when the fixed segment size doesn't fit the canvas",
+			opts: []Option{
+				SegmentSize(sixteen.MinCols*2, sixteen.MinRows*2),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1")})
+			},
+			wantDrawErr: true,
+		},
+		{
+			desc: "clamps the fixed segment size to the canvas",
+			opts: []Option{
+				SegmentSize(sixteen.MinCols*2, sixteen.MinRows*2),
+				ClampSegmentSize(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "write fails on negative space after a chunk",
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1", WriteSpaceAfter(-1))})
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "inserts space after a text chunk",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*4+2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("12", WriteSpaceAfter(2)),
+					NewChunk("34", WriteSpaceAfter(3)),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'2', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows)},
+					{'3', image.Rect(sixteen.MinCols*2+2, 0, sixteen.MinCols*3+2, sixteen.MinRows)},
+					{'4', image.Rect(sixteen.MinCols*3+2, 0, sixteen.MinCols*4+2, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "inserts space in addition to the gaps",
+			opts: []Option{
+				GapPercent(20),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3+4, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteSpaceAfter(1)),
+					NewChunk("23"),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'2', image.Rect(sixteen.MinCols+2, 0, sixteen.MinCols*2+2, sixteen.MinRows)},
+					{'3', image.Rect(sixteen.MinCols*2+3, 0, sixteen.MinCols*3+3, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the space counts towards fitting the characters",
+			opts: []Option{
+				GapPercent(0),
+				AlignHorizontal(align.HorizontalLeft),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*3+1, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("12", WriteSpaceAfter(2)),
+					NewChunk("3"),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'2', image.Rect(sixteen.MinCols, 0, sixteen.MinCols*2, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "keeps the space when filling from the right",
+			opts: []Option{
+				GapPercent(0),
+				FillFromRight(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*2+2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("12", WriteSpaceAfter(2)),
+					NewChunk("3"),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				for _, tc := range []struct {
+					char rune
+					area image.Rectangle
+				}{
+					{'2', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows)},
+					{'3', image.Rect(sixteen.MinCols+2, 0, sixteen.MinCols*2+2, sixteen.MinRows)},
+				} {
+					mustDrawChar(cvs, tc.char, tc.area)
+				}
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sd, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+
+			if tc.update != nil {
+				err = tc.update(sd)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			err = sd.Draw(c)
+			if (err != nil) != tc.wantDrawErr {
+				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			var want *faketerm.Terminal
+			if tc.want != nil {
+				want = tc.want(c.Size())
+			} else {
+				want = faketerm.MustNew(c.Size())
+			}
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	sd, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := sd.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	sd, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := sd.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "reports the smallest supported segment size",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{sixteen.MinCols, sixteen.MinRows},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "reports the fixed segment size",
+			opts: []Option{
+				SegmentSize(12, 10),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{12, 10},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "reports the smallest supported segment size when clamping",
+			opts: []Option{
+				SegmentSize(12, 10),
+				ClampSegmentSize(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{sixteen.MinCols, sixteen.MinRows},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sd, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			got := sd.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFillFromRightKeepsLastCharacterAnchored(t *testing.T) {
+	sd, err := New(
+		MaximizeSegmentHeight(),
+		GapPercent(0),
+		FillFromRight(),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	ar := image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows)
+	for _, tc := range []struct {
+		text  string
+		chars []rune
+	}{
+		{"9", []rune{'9'}},
+		{"99", []rune{'9', '9'}},
+		{"999", []rune{'9', '9', '9'}},
+		{"1999", []rune{'9', '9', '9'}},
+	} {
+		t.Run(tc.text, func(t *testing.T) {
+			if err := sd.Write([]*TextChunk{NewChunk(tc.text)}); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+
+			c := testcanvas.MustNew(ar)
+			if err := sd.Draw(c); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			got := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(c, got)
+
+			want := faketerm.MustNew(c.Size())
+			wantCvs := testcanvas.MustNew(want.Area())
+			endX := ar.Max.X
+			for i := len(tc.chars) - 1; i >= 0; i-- {
+				startX := endX - sixteen.MinCols
+				mustDrawChar(wantCvs, tc.chars[i], image.Rect(startX, 0, endX, sixteen.MinRows))
+				endX = startX
+			}
+			testcanvas.MustApply(wantCvs, want)
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
 
+func TestSegmentSize(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		text    string
+		ar      image.Rectangle
+		want    image.Point
+		wantErr bool
+	}{
+		{
+			desc:    "fails on area too small for a segment",
+			text:    "1",
+			ar:      image.Rect(0, 0, sixteen.MinCols-1, sixteen.MinRows),
+			wantErr: true,
+		},
+		{
+			desc: "without text",
+			ar:   image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			want: image.Point{sixteen.MinCols, sixteen.MinRows},
+		},
+		{
+			desc: "all segments fit",
+			opts: []Option{
+				GapPercent(0),
+			},
+			text: "123",
+			ar:   image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows),
+			want: image.Point{sixteen.MinCols, sixteen.MinRows},
+		},
+		{
+			desc: "not enough space, maximizes segment height with option",
+			opts: []Option{
+				MaximizeSegmentHeight(),
+				GapPercent(20),
+			},
+			text: "123",
+			ar:   image.Rect(0, 0, sixteen.MinCols*5, sixteen.MinRows*2),
+			want: image.Point{12, 10},
+		},
+		{
+			desc: "not enough space, maximizes displayed text by default",
+			opts: []Option{
+				GapPercent(0),
+			},
+			text: "123",
+			ar:   image.Rect(0, 0, sixteen.MinCols*3, sixteen.MinRows*2),
+			want: image.Point{sixteen.MinCols, sixteen.MinRows},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sd, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.text != "" {
+				if err := sd.Write([]*TextChunk{NewChunk(tc.text)}); err != nil {
+					t.Fatalf("Write => unexpected error: %v", err)
+				}
+			}
+
+			got, err := sd.SegmentSize(tc.ar)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SegmentSize => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("SegmentSize => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMaximizeFitMinimizesGaps(t *testing.T) {
+	// want is the result of minimizing the gaps for each of the heights
+	// considered by maximizeFit.
+	want := func(cvsAr image.Rectangle, textLen, gapPercent int) *segArea {
+		var best *segArea
+		for height := cvsAr.Dy(); height >= sixteen.MinRows; height-- {
+			ar := image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Min.Y+height)
+			segAr, err := minimizeGaps(ar, textLen, nil, gapPercent)
+			if err != nil {
+				t.Fatalf("minimizeGaps => unexpected error: %v", err)
+			}
+			best = segAr
+			if segAr.canFit >= textLen {
+				break
+			}
+		}
+		return best
+	}
+
+	for width := sixteen.MinCols; width <= 4*sixteen.MinCols; width++ {
+		for height := sixteen.MinRows; height <= 3*sixteen.MinRows; height++ {
+			for _, textLen := range []int{1, 3, 5} {
+				ar := image.Rect(0, 0, width, height)
+				got, err := maximizeFit(ar, textLen, nil, DefaultGapPercent, true)
+				if err != nil {
+					t.Fatalf("maximizeFit => unexpected error: %v", err)
+				}
+				if diff := pretty.Compare(want(ar, textLen, DefaultGapPercent), got); diff != "" {
+					t.Errorf("maximizeFit(%v, %d) => unexpected diff (-want, +got):\n%s", ar, textLen, diff)
+				}
+			}
+		}
+	}
 }
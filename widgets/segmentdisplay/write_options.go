@@ -28,6 +28,10 @@ type WriteOption interface {
 type writeOptions struct {
 	cellOpts         []cell.Option
 	errOnUnsupported bool
+	unsupportedGlyph bool
+	glyphCellOpts    []cell.Option
+	gapColor         *cell.Color
+	spaceAfter       int
 }
 
 // newWriteOptions returns new writeOptions instance.
@@ -54,12 +58,35 @@ func WriteCellOpts(opts ...cell.Option) WriteOption {
 	})
 }
 
+// WriteGapColor sets the background color of the cells in the gaps between
+// the segments. The gap that follows a character uses the color from the
+// options of the text chunk that contains the character.
+// The gaps aren't colored by default.
+func WriteGapColor(color cell.Color) WriteOption {
+	return writeOption(func(wOpts *writeOptions) {
+		wOpts.gapColor = &color
+	})
+}
+
+// WriteSpaceAfter inserts the specified number of additional empty cells after
+// the last character of the text chunk, e.g. to visually separate groups of
+// digits like "12 34 56". The space is inserted in addition to the gap between
+// the segments, it is taken into account when determining how many characters
+// fit the canvas and isn't inserted after the last character of the text.
+// Must be zero or a positive number, defaults to zero.
+func WriteSpaceAfter(cells int) WriteOption {
+	return writeOption(func(wOpts *writeOptions) {
+		wOpts.spaceAfter = cells
+	})
+}
+
 // WriteSanitize instructs Write to sanitize the text, replacing all characters
 // the display doesn't support with a space ' ' character.
 // This is the default behavior.
 func WriteSanitize(opts ...cell.Option) WriteOption {
 	return writeOption(func(wOpts *writeOptions) {
 		wOpts.errOnUnsupported = false
+		wOpts.unsupportedGlyph = false
 	})
 }
 
@@ -69,5 +96,27 @@ func WriteSanitize(opts ...cell.Option) WriteOption {
 func WriteErrOnUnsupported(opts ...cell.Option) WriteOption {
 	return writeOption(func(wOpts *writeOptions) {
 		wOpts.errOnUnsupported = true
+		wOpts.unsupportedGlyph = false
+	})
+}
+
+// DefaultUnsupportedGlyphColor is the default color of the glyph drawn by
+// WriteUnsupportedGlyph.
+const DefaultUnsupportedGlyphColor = cell.ColorRed
+
+// WriteUnsupportedGlyph instructs Write to substitute each character the
+// display doesn't support with an error glyph that has all the segments lit,
+// instead of sanitizing it. The glyph is drawn with the provided cell options
+// instead of the options set by WriteCellOpts, in the
+// DefaultUnsupportedGlyphColor if none are provided.
+// The default behavior is to sanitize the text, see WriteSanitize().
+func WriteUnsupportedGlyph(opts ...cell.Option) WriteOption {
+	return writeOption(func(wOpts *writeOptions) {
+		wOpts.errOnUnsupported = false
+		wOpts.unsupportedGlyph = true
+		if len(opts) == 0 {
+			opts = []cell.Option{cell.FgColor(DefaultUnsupportedGlyphColor)}
+		}
+		wOpts.glyphCellOpts = opts
 	})
 }
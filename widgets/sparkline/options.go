@@ -18,8 +18,10 @@ package sparkline
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/runewidth"
 )
 
 // Option is used to provide options.
@@ -42,12 +44,24 @@ type options struct {
 	labelCellOpts []cell.Option
 	height        int
 	color         cell.Color
+	thresholds    []Threshold
+	runes         []rune
+	hasFixedMax   bool
+	fixedMax      int
+	fixedMin      int
+	aggregation   AggregationMode
+
+	showValues     bool
+	valuesPosition ValuesPosition
+	valuesCellOpts []cell.Option
+	valueFormat    func(int) string
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
 		color: DefaultColor,
+		runes: sparks,
 	}
 }
 
@@ -56,6 +70,31 @@ func (o *options) validate() error {
 	if got, min := o.height, 0; got < min {
 		return fmt.Errorf("invalid Height %d, must be %d <= Height", got, min)
 	}
+	if got, min := o.fixedMin, 0; got < min {
+		return fmt.Errorf("invalid FixedMin %d, must be %d <= FixedMin", got, min)
+	}
+	if _, ok := valuesPositionNames[o.valuesPosition]; !ok {
+		return fmt.Errorf("invalid ValuesPositioning(%v)", o.valuesPosition)
+	}
+	if _, ok := aggregationNames[o.aggregation]; !ok {
+		return fmt.Errorf("invalid Aggregation(%v)", o.aggregation)
+	}
+	if got, min := len(o.runes), 2; got < min {
+		return fmt.Errorf("invalid Runes %q, must provide at least %d runes", string(o.runes), min)
+	}
+	for i, r := range o.runes {
+		if got := runewidth.RuneWidth(r); got != 1 {
+			return fmt.Errorf("invalid Runes %q, all runes must have a width of one cell, rune[%d] %q has width %d", string(o.runes), i, r, got)
+		}
+	}
+	for i := 1; i < len(o.thresholds); i++ {
+		if v := o.thresholds[i].Value; v == o.thresholds[i-1].Value {
+			return fmt.Errorf("invalid Thresholds, the values must be unique, found value %d more than once", v)
+		}
+	}
+	if o.hasFixedMax && o.fixedMax <= o.fixedMin {
+		return fmt.Errorf("invalid FixedMax %d, must be FixedMin(%d) < FixedMax", o.fixedMax, o.fixedMin)
+	}
 	return nil
 }
 
@@ -86,3 +125,175 @@ func Color(c cell.Color) Option {
 		opts.color = c
 	})
 }
+
+// Threshold is a data point value from which the bars of the SparkLine are
+// drawn in a different color, see the Thresholds option.
+type Threshold struct {
+	// Value is the smallest data point drawn in the Color.
+	Value int
+	// Color is the color of the bars of data points equal to or larger than
+	// the Value.
+	Color cell.Color
+}
+
+// Thresholds colors the bars of the SparkLine according to the values of
+// their data points, e.g. green below a warning value, yellow below a
+// critical value and red above it. Each bar is drawn in the color of the
+// threshold with the largest value that is equal to or smaller than the data
+// point. Bars of data points smaller than all the thresholds are drawn in the
+// color set by the Color option.
+// The thresholds can be provided in any order, but their values must be
+// unique. Bars of aggregated data points are colored according to the
+// aggregated value, see Aggregation.
+func Thresholds(thresholds []Threshold) Option {
+	return option(func(opts *options) {
+		ts := append([]Threshold(nil), thresholds...)
+		sort.SliceStable(ts, func(i, j int) bool {
+			return ts[i].Value < ts[j].Value
+		})
+		opts.thresholds = ts
+	})
+}
+
+// Runes sets the characters used to draw the SparkLine, ordered from the
+// one representing the smallest portion of a cell to the one representing a
+// fully populated cell, e.g. []rune(".:|#") on terminals that don't render
+// the block elements well. Values are distributed over as many levels as
+// there are runes.
+// Defaults to the eight block elements '▁' through '█'. At least two runes
+// must be provided and all of them must be half-width.
+func Runes(runes []rune) Option {
+	return option(func(opts *options) {
+		opts.runes = append([]rune(nil), runes...)
+	})
+}
+
+// FixedMax sets a fixed value represented by a bar that takes all the vertical
+// space of the SparkLine. If not provided, the SparkLine scales to the largest
+// visible data point, which changes as new data points are added.
+// Data points larger than the provided value are drawn as full bars.
+// Must be larger than the value of FixedMin.
+func FixedMax(max int) Option {
+	return option(func(opts *options) {
+		opts.hasFixedMax = true
+		opts.fixedMax = max
+	})
+}
+
+// FixedMin sets the value represented by the bottom of the SparkLine, i.e.
+// data points equal to or smaller than the provided value are represented by
+// an empty space. Defaults to zero. Must be a positive or zero integer.
+func FixedMin(min int) Option {
+	return option(func(opts *options) {
+		opts.fixedMin = min
+	})
+}
+
+// AggregationMode determines how the SparkLine reduces the data points when
+// there are more of them than the width of the SparkLine.
+type AggregationMode int
+
+// String implements fmt.Stringer()
+func (a AggregationMode) String() string {
+	if n, ok := aggregationNames[a]; ok {
+		return n
+	}
+	return "AggUnknown"
+}
+
+// aggregationNames maps AggregationMode values to human readable names.
+var aggregationNames = map[AggregationMode]string{
+	AggNone: "AggNone",
+	AggLast: "AggLast",
+	AggMax:  "AggMax",
+	AggMean: "AggMean",
+}
+
+const (
+	// AggNone doesn't aggregate the data points, only the last data points
+	// that fit the width of the SparkLine are visible.
+	AggNone AggregationMode = iota
+
+	// AggLast represents each bucket of data points by its last data point.
+	AggLast
+
+	// AggMax represents each bucket of data points by its largest data point.
+	AggMax
+
+	// AggMean represents each bucket of data points by the mean of its data
+	// points rounded to the nearest integer.
+	AggMean
+)
+
+// Aggregation configures the SparkLine to display an overview of all the data
+// points when there are more data points than fit its width. The data points
+// are split into as many buckets as the SparkLine is wide and each bucket is
+// drawn as one bar representing the bucket's data points reduced as
+// specified. When the number of data points isn't divisible by the width,
+// the data points are distributed proportionally and the buckets differ in
+// size by at most one data point.
+// Defaults to AggNone.
+func Aggregation(a AggregationMode) Option {
+	return option(func(opts *options) {
+		opts.aggregation = a
+	})
+}
+
+// ShowValues tells the SparkLine to display the smallest, the largest and the
+// last of the visible data points in labels next to the graph. Each label is
+// on the row where the bar displaying its value ends, so the largest value is
+// usually at the top and the smallest at the bottom. When labels fall on the
+// same row, the last value takes precedence.
+// The labels take columns on the side of the graph set by ValuesPositioning,
+// which reduces the width available for the bars. The provided cell options
+// are applied to the labels.
+func ShowValues(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.showValues = true
+		opts.valuesCellOpts = cOpts
+	})
+}
+
+// ValuesPosition indicates on which side of the graph the SparkLine displays
+// the labels with values.
+type ValuesPosition int
+
+// String implements fmt.Stringer()
+func (vp ValuesPosition) String() string {
+	if n, ok := valuesPositionNames[vp]; ok {
+		return n
+	}
+	return "ValuesPositionUnknown"
+}
+
+// valuesPositionNames maps ValuesPosition values to human readable names.
+var valuesPositionNames = map[ValuesPosition]string{
+	ValuesPositionRight: "ValuesPositionRight",
+	ValuesPositionLeft:  "ValuesPositionLeft",
+}
+
+const (
+	// ValuesPositionRight displays the labels on the right of the graph.
+	ValuesPositionRight ValuesPosition = iota
+
+	// ValuesPositionLeft displays the labels on the left of the graph.
+	ValuesPositionLeft
+)
+
+// ValuesPositioning sets on which side of the graph the labels displayed by
+// the ShowValues option are.
+// Defaults to ValuesPositionRight.
+func ValuesPositioning(vp ValuesPosition) Option {
+	return option(func(opts *options) {
+		opts.valuesPosition = vp
+	})
+}
+
+// ValueFormatter sets a function that formats the values displayed by the
+// ShowValues option, e.g. to display "1.2k" instead of "1200". Defaults to
+// formatting the values as plain integers.
+func ValueFormatter(fn func(int) string) Option {
+	return option(func(opts *options) {
+		opts.valueFormat = fn
+	})
+}
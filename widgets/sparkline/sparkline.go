@@ -76,7 +76,30 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 	}
 
 	ar := sl.area(cvs)
-	visible, max := visibleMax(sl.data, ar.Dx())
+	var labelsAr image.Rectangle
+	if sl.opts.showValues {
+		// Reserve the width for the labels and one column that separates them
+		// from the graph. The width is determined from the data points visible
+		// on the full width.
+		full, _ := visibleMax(aggregate(sl.data, ar.Dx(), sl.opts.aggregation), ar.Dx())
+		if w := sl.labelsWidth(full); w > 0 {
+			if ar.Dx()-w-1 < 1 {
+				return draw.ResizeNeeded(cvs)
+			}
+			if sl.opts.valuesPosition == ValuesPositionLeft {
+				labelsAr = image.Rect(ar.Min.X, ar.Min.Y, ar.Min.X+w, ar.Max.Y)
+				ar.Min.X += w + 1
+			} else {
+				labelsAr = image.Rect(ar.Max.X-w, ar.Min.Y, ar.Max.X, ar.Max.Y)
+				ar.Max.X -= w + 1
+			}
+		}
+	}
+	visible, max := visibleMax(aggregate(sl.data, ar.Dx(), sl.opts.aggregation), ar.Dx())
+	if sl.opts.hasFixedMax {
+		max = sl.opts.fixedMax
+	}
+	min := sl.opts.fixedMin
 	var curX int
 	if len(visible) < ar.Dx() {
 		curX = ar.Max.X - len(visible)
@@ -85,13 +108,14 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 	}
 
 	for _, v := range visible {
-		blocks := toBlocks(v, max, ar.Dy())
+		color := barColor(v, sl.opts)
+		blocks := toBlocks(clampToRange(v, min, max)-min, max-min, ar.Dy(), sl.opts.runes)
 		curY := ar.Max.Y - 1
 		for i := 0; i < blocks.full; i++ {
 			if _, err := cvs.SetCell(
 				image.Point{curX, curY},
-				sparks[len(sparks)-1], // Last spark represents full cell.
-				cell.FgColor(sl.opts.color),
+				sl.opts.runes[len(sl.opts.runes)-1], // Last spark represents full cell.
+				cell.FgColor(color),
 			); err != nil {
 				return err
 			}
@@ -103,7 +127,7 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 			if _, err := cvs.SetCell(
 				image.Point{curX, curY},
 				blocks.partSpark,
-				cell.FgColor(sl.opts.color),
+				cell.FgColor(color),
 			); err != nil {
 				return err
 			}
@@ -112,10 +136,17 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 		curX++
 	}
 
+	if !labelsAr.Empty() {
+		labels := sl.valueLabels(visible, min, max, ar)
+		if err := sl.drawValueLabels(cvs, labels, labelsAr); err != nil {
+			return err
+		}
+	}
+
 	if sl.opts.label != "" {
 		// Label is placed immediately above the SparkLine.
-		lStart := image.Point{ar.Min.X, ar.Min.Y - 1}
-		if err := draw.Text(cvs, sl.opts.label, lStart,
+		lStart := image.Point{cvs.Area().Min.X, ar.Min.Y - 1}
+		if _, err := draw.Text(cvs, sl.opts.label, lStart,
 			draw.TextCellOpts(sl.opts.labelCellOpts...),
 			draw.TextOverrunMode(draw.OverrunModeThreeDot),
 		); err != nil {
@@ -125,6 +156,18 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 	return nil
 }
 
+// barColor returns the color of the bar that represents the data point.
+func barColor(v int, opts *options) cell.Color {
+	color := opts.color
+	for _, t := range opts.thresholds {
+		if v < t.Value {
+			break
+		}
+		color = t.Color
+	}
+	return color
+}
+
 // Add adds data points to the SparkLine.
 // Each data point is represented by one bar on the SparkLine. Zero value data
 // points are valid and are represented by an empty space on the SparkLine
@@ -136,7 +179,7 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas) error {
 // The last added data point will be the one displayed all the way on the right
 // of the SparkLine. If there are more data points than we can fit bars to the
 // width of the SparkLine, only the last n data points that fit will be
-// visible.
+// visible, unless the Aggregation option is provided.
 //
 // Provided options override values set when New() was called.
 func (sl *SparkLine) Add(data []int, opts ...Option) error {
@@ -15,6 +15,7 @@
 package sparkline
 
 import (
+	"fmt"
 	"image"
 	"testing"
 
@@ -125,6 +126,64 @@ func TestSparkLine(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "colors bars according to unsorted thresholds",
+			opts: []Option{
+				Color(cell.ColorGreen),
+				Thresholds([]Threshold{
+					{Value: 7, Color: cell.ColorRed},
+					{Value: 4, Color: cell.ColorYellow},
+				}),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+			},
+			canvas: image.Rect(0, 0, 9, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▁▂▃", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorGreen),
+				))
+				testdraw.MustText(c, "▄▅▆", image.Point{4, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorYellow),
+				))
+				testdraw.MustText(c, "▇█", image.Point{7, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "colors all cells of multi-row bars according to thresholds",
+			opts: []Option{
+				Thresholds([]Threshold{
+					{Value: 10, Color: cell.ColorRed},
+				}),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{8, 16})
+			},
+			canvas: image.Rect(0, 0, 2, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "█", image.Point{0, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testdraw.MustText(c, "█", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testdraw.MustText(c, "█", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "sets sparkline color on a call to Add",
 			update: func(sl *SparkLine) error {
@@ -410,6 +469,282 @@ func TestSparkLine(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "fails on negative FixedMin",
+			opts: []Option{
+				FixedMin(-1),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on Thresholds with duplicate values",
+			opts: []Option{
+				Thresholds([]Threshold{
+					{Value: 5, Color: cell.ColorYellow},
+					{Value: 2, Color: cell.ColorBlue},
+					{Value: 5, Color: cell.ColorRed},
+				}),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails when fewer than two Runes are provided",
+			opts: []Option{
+				Runes([]rune("#")),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on full-width Runes",
+			opts: []Option{
+				Runes([]rune(".:世#")),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "draws with custom Runes",
+			opts: []Option{
+				Runes([]rune(".:|#")),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, ".:|#", image.Point{5, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testdraw.MustText(c, ".:|#####", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails when FixedMax isn't larger than FixedMin",
+			opts: []Option{
+				FixedMin(2),
+				FixedMax(2),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "scales to the fixed maximum and clamps larger values",
+			opts: []Option{
+				FixedMax(16),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 2, 4, 8, 16, 32})
+			},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▁▂▄██", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "scales between the fixed minimum and maximum",
+			opts: []Option{
+				FixedMin(10),
+				FixedMax(18),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 10, 11, 14, 18})
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▁▄█", image.Point{2, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fixed minimum with the automatic maximum",
+			opts: []Option{
+				FixedMin(4),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{4, 8, 12})
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails on invalid aggregation",
+			opts: []Option{
+				Aggregation(AggregationMode(-1)),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "aggregates data points that don't fit the width",
+			opts: []Option{
+				Aggregation(AggMax),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 8, 0, 4, 2, 0, 8, 0})
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "█▄▂█", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails on invalid values position",
+			opts: []Option{
+				ValuesPositioning(ValuesPosition(-1)),
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "shows values on the right of the graph",
+			opts: []Option{
+				ShowValues(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{1, 8, 4})
+			},
+			canvas: image.Rect(0, 0, 6, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "█", image.Point{2, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testdraw.MustText(c, "▂██", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testdraw.MustText(c, "8", image.Point{5, 0})
+				testdraw.MustText(c, "4", image.Point{5, 1})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "shows formatted values on the left of the graph",
+			opts: []Option{
+				ShowValues(cell.FgColor(cell.ColorBlue)),
+				ValuesPositioning(ValuesPositionLeft),
+				ValueFormatter(func(v int) string {
+					return fmt.Sprintf("%dk", v)
+				}),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{2, 4})
+			},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{4, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testdraw.MustText(c, "4k", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws resize needed character when the values don't fit",
+			opts: []Option{
+				ShowValues(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{10})
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
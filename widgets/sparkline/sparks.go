@@ -24,7 +24,7 @@ import (
 	"github.com/mum4k/termdash/internal/runewidth"
 )
 
-// sparks are the characters used to draw the SparkLine.
+// sparks are the default characters used to draw the SparkLine.
 var sparks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 
 // visibleMax determines the maximum visible data point given the canvas width.
@@ -48,27 +48,78 @@ func visibleMax(data []int, width int) ([]int, int) {
 	return data, max
 }
 
+// aggregate reduces the data points into the specified number of buckets
+// using the provided aggregation mode. Returns the data unchanged if they fit
+// the width or if the mode is AggNone.
+// Bucket b contains data points in the range [b*n/width, (b+1)*n/width), where
+// n is the number of data points, so the data points are distributed
+// proportionally and the bucket sizes differ by at most one.
+func aggregate(data []int, width int, mode AggregationMode) []int {
+	if mode == AggNone || width <= 0 || len(data) <= width {
+		return data
+	}
+
+	n := len(data)
+	res := make([]int, width)
+	for b := range res {
+		bucket := data[b*n/width : (b+1)*n/width]
+		switch mode {
+		case AggLast:
+			res[b] = bucket[len(bucket)-1]
+
+		case AggMax:
+			for _, v := range bucket {
+				if v > res[b] {
+					res[b] = v
+				}
+			}
+
+		case AggMean:
+			var sum int
+			for _, v := range bucket {
+				sum += v
+			}
+			res[b] = (sum + len(bucket)/2) / len(bucket)
+		}
+	}
+	return res
+}
+
+// clampToRange returns the value limited to the range min <= value <= max.
+// Returns min if max < min.
+func clampToRange(value, min, max int) int {
+	if value > max {
+		value = max
+	}
+	if value < min {
+		value = min
+	}
+	return value
+}
+
 // blocks represents the building blocks that display one value on a SparkLine.
 // I.e. one vertical bar.
 type blocks struct {
 	// full is the number of fully populated blocks.
 	full int
 
-	// partSpark is the spark character from sparks that should be used in the
-	// topmost block. Equals to zero if no partial block should be displayed.
+	// partSpark is the spark character from the runes that should be used in
+	// the topmost block. Equals to zero if no partial block should be displayed.
 	partSpark rune
 }
 
 // toBlocks determines the number of full and partial vertical blocks required
 // to represent the provided value given the specified max visible value and
 // number of vertical cells available to the SparkLine.
-func toBlocks(value, max, vertCells int) blocks {
+// The runes are the spark characters ordered from the smallest to the one that
+// represents a full cell.
+func toBlocks(value, max, vertCells int, runes []rune) blocks {
 	if value <= 0 || max <= 0 || vertCells <= 0 {
 		return blocks{}
 	}
 
 	// How many of the smallest spark elements fit into a cell.
-	cellSparks := len(sparks)
+	cellSparks := len(runes)
 
 	// Scale is how much of the max does one smallest spark element represent,
 	// given the vertical cells that will be used to represent the value.
@@ -83,7 +134,7 @@ func toBlocks(value, max, vertCells int) blocks {
 
 	part := elements % cellSparks
 	if part > 0 {
-		b.partSpark = sparks[part-1]
+		b.partSpark = runes[part-1]
 	}
 	return b
 }
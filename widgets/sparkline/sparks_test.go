@@ -104,7 +104,9 @@ func TestToBlocks(t *testing.T) {
 		value     int
 		max       int
 		vertCells int
-		want      blocks
+		// runes are the spark characters, defaults to sparks if nil.
+		runes []rune
+		want  blocks
 	}{
 		{
 			desc:      "zero value has no blocks",
@@ -239,11 +241,47 @@ func TestToBlocks(t *testing.T) {
 			vertCells: 3,
 			want:      blocks{full: 2, partSpark: sparks[3]},
 		},
+		{
+			desc:      "custom runes, value is 1/4",
+			value:     1,
+			max:       4,
+			vertCells: 1,
+			runes:     []rune(".:|#"),
+			want:      blocks{full: 0, partSpark: '.'},
+		},
+		{
+			desc:      "custom runes, value is 3/4",
+			value:     3,
+			max:       4,
+			vertCells: 1,
+			runes:     []rune(".:|#"),
+			want:      blocks{full: 0, partSpark: '|'},
+		},
+		{
+			desc:      "custom runes, multi line, topmost block is partial",
+			value:     5,
+			max:       8,
+			vertCells: 2,
+			runes:     []rune(".:|#"),
+			want:      blocks{full: 1, partSpark: '.'},
+		},
+		{
+			desc:      "two custom runes, value rounds to a full block",
+			value:     9,
+			max:       10,
+			vertCells: 1,
+			runes:     []rune("-#"),
+			want:      blocks{full: 1},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			got := toBlocks(tc.value, tc.max, tc.vertCells)
+			runes := tc.runes
+			if runes == nil {
+				runes = sparks
+			}
+			got := toBlocks(tc.value, tc.max, tc.vertCells, runes)
 			if diff := pretty.Compare(tc.want, got); diff != "" {
 				t.Errorf("toBlocks => unexpected diff (-want, +got):\n%s", diff)
 				if got.full != tc.want.full {
@@ -251,7 +289,7 @@ func TestToBlocks(t *testing.T) {
 				}
 				if got.partSpark != tc.want.partSpark {
 					t.Errorf("toBlocks => unexpected diff, blocks.partSpark got '%c' (sparks[%d])), want '%c' (sparks[%d])",
-						got.partSpark, findRune(got.partSpark, sparks), tc.want.partSpark, findRune(tc.want.partSpark, sparks))
+						got.partSpark, findRune(got.partSpark, runes), tc.want.partSpark, findRune(tc.want.partSpark, runes))
 				}
 			}
 		})
@@ -268,3 +306,56 @@ func findRune(target rune, runes []rune) int {
 	}
 	return -1
 }
+
+func TestClampToRange(t *testing.T) {
+	tests := []struct {
+		desc  string
+		value int
+		min   int
+		max   int
+		want  int
+	}{
+		{"within range", 5, 0, 10, 5},
+		{"equal to min", 0, 0, 10, 0},
+		{"equal to max", 10, 0, 10, 10},
+		{"below min", 1, 2, 10, 2},
+		{"above max", 11, 0, 10, 10},
+		{"max smaller than min", 5, 6, 4, 6},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := clampToRange(tc.value, tc.min, tc.max)
+			if got != tc.want {
+				t.Errorf("clampToRange(%d, %d, %d) => %d, want %d", tc.value, tc.min, tc.max, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		desc  string
+		data  []int
+		width int
+		mode  AggregationMode
+		want  []int
+	}{
+		{"no aggregation", []int{1, 2, 3, 4}, 2, AggNone, []int{1, 2, 3, 4}},
+		{"data fit the width", []int{1, 2}, 2, AggMax, []int{1, 2}},
+		{"last in each bucket", []int{1, 2, 3, 4}, 2, AggLast, []int{2, 4}},
+		{"max in each bucket", []int{5, 2, 3, 4}, 2, AggMax, []int{5, 4}},
+		{"rounded mean of each bucket", []int{1, 2, 3, 6}, 2, AggMean, []int{2, 5}},
+		{"distributes data proportionally", []int{1, 2, 3, 4, 5}, 2, AggLast, []int{2, 5}},
+		{"buckets differ in size by at most one", []int{1, 2, 3, 4, 5, 6, 7}, 3, AggMax, []int{2, 4, 7}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := aggregate(tc.data, tc.width, tc.mode)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("aggregate => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
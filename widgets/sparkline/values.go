@@ -0,0 +1,136 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparkline
+
+// values.go contains code that places the labels displaying the values.
+
+import (
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+)
+
+// valueLabel is a label that displays one of the values.
+type valueLabel struct {
+	// row is the row of the canvas the label is on.
+	row int
+	// text is the formatted value.
+	text string
+}
+
+// formatValue formats the value using the ValueFormatter if provided.
+func (sl *SparkLine) formatValue(v int) string {
+	if sl.opts.valueFormat != nil {
+		return sl.opts.valueFormat(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// labelsWidth returns the width in cells of the widest label that displays
+// the smallest, the largest or the last of the data points.
+func (sl *SparkLine) labelsWidth(data []int) int {
+	if len(data) == 0 {
+		return 0
+	}
+	lo, hi := minMax(data)
+	var width int
+	for _, v := range []int{lo, hi, data[len(data)-1]} {
+		if w := runewidth.StringWidth(sl.formatValue(v)); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// valueLabels returns the labels displaying the smallest, the largest and the
+// last of the visible data points on a SparkLine drawn in the area scaled to
+// the range from min to max.
+// Each label is on the row where the bar displaying the value ends. If labels
+// fall on the same row, the last value takes precedence over the largest which
+// takes precedence over the smallest value.
+// The returned labels are ordered by their row.
+func (sl *SparkLine) valueLabels(visible []int, min, max int, ar image.Rectangle) []*valueLabel {
+	if len(visible) == 0 || ar.Dy() <= 0 {
+		return nil
+	}
+
+	byRow := map[int]*valueLabel{}
+	lo, hi := minMax(visible)
+	for _, v := range []int{lo, hi, visible[len(visible)-1]} {
+		b := toBlocks(clampToRange(v, min, max)-min, max-min, ar.Dy(), sl.opts.runes)
+		height := b.full
+		if b.partSpark != 0 {
+			height++
+		}
+		if height < 1 {
+			height = 1
+		}
+		row := ar.Max.Y - height
+		byRow[row] = &valueLabel{
+			row:  row,
+			text: sl.formatValue(v),
+		}
+	}
+
+	var res []*valueLabel
+	for _, l := range byRow {
+		res = append(res, l)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].row < res[j].row
+	})
+	return res
+}
+
+// drawValueLabels draws the labels within the provided area that is reserved
+// for them.
+func (sl *SparkLine) drawValueLabels(cvs *canvas.Canvas, labels []*valueLabel, ar image.Rectangle) error {
+	for _, l := range labels {
+		start := image.Point{ar.Min.X, l.row}
+		if sl.opts.valuesPosition == ValuesPositionLeft {
+			// Right aligned next to the SparkLine.
+			if w := runewidth.StringWidth(l.text); w < ar.Dx() {
+				start.X = ar.Max.X - w
+			}
+		}
+		if _, err := draw.Text(cvs, l.text, start,
+			draw.TextCellOpts(sl.opts.valuesCellOpts...),
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minMax returns the smallest and the largest of the data points.
+// The data must not be empty.
+func minMax(data []int) (int, int) {
+	lo, hi := data[0], data[0]
+	for _, v := range data {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
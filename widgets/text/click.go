@@ -0,0 +1,110 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// click.go contains code that detects clicks on runs of text.
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// clickHandler is a handler registered with the OnClick option.
+type clickHandler struct {
+	// matcher determines if the handler fires for a run of text.
+	matcher func(string) bool
+	// handler is called with the clicked run of text.
+	handler func(string)
+}
+
+// runAt returns the run of text that contains the rune at the byte position.
+// A run is a sequence of runes delimited by white space or the ends of the
+// text. Returns an empty string if the rune at the position is white space.
+func runAt(text string, pos int) string {
+	if pos < 0 || pos >= len(text) {
+		return ""
+	}
+	if r, _ := utf8.DecodeRuneInString(text[pos:]); unicode.IsSpace(r) {
+		return ""
+	}
+
+	start := pos
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:start])
+		if unicode.IsSpace(r) {
+			break
+		}
+		start -= size
+	}
+
+	end := pos
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsSpace(r) {
+			break
+		}
+		end += size
+	}
+	return text[start:end]
+}
+
+// mouseClick processes mouse events that click on runs of text.
+// A click is a press and a release of the left mouse button on the same rune.
+// Returns the handler and the clicked run of text if the user just clicked on
+// a run matched by one of the handlers registered with OnClick.
+// Caller must hold t.mu.
+func (t *Text) mouseClick(m *terminalapi.Mouse) (func(string), string, bool) {
+	if len(t.opts.clickHandlers) == 0 || t.cellMap == nil {
+		return nil, "", false
+	}
+
+	pos, ok := t.cellMap.posExact(m.Position)
+	switch m.Button {
+	case mouse.ButtonLeft:
+		if t.clickPressed {
+			if !ok || pos != t.clickPos {
+				t.clickPos = -1 // The mouse moved away, not a click.
+			}
+			return nil, "", false
+		}
+		t.clickPressed = true
+		t.clickPos = -1
+		if ok {
+			t.clickPos = pos
+		}
+
+	case mouse.ButtonRelease:
+		pressed, pressPos := t.clickPressed, t.clickPos
+		t.clickPressed = false
+		t.clickPos = -1
+		if !pressed || !ok || pos != pressPos {
+			return nil, "", false
+		}
+
+		run := runAt(t.buff.String(), pos)
+		if run == "" {
+			return nil, "", false
+		}
+		for _, ch := range t.opts.clickHandlers {
+			if ch.matcher(run) {
+				return ch.handler, run, true
+			}
+		}
+	}
+	return nil, "", false
+}
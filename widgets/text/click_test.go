@@ -0,0 +1,206 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+func TestRunAt(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+		pos  int
+		want string
+	}{
+		{
+			desc: "empty text",
+			text: "",
+			pos:  0,
+			want: "",
+		},
+		{
+			desc: "position out of range",
+			text: "abc",
+			pos:  3,
+			want: "",
+		},
+		{
+			desc: "position on white space",
+			text: "ab cd",
+			pos:  2,
+			want: "",
+		},
+		{
+			desc: "the only run",
+			text: "abc",
+			pos:  1,
+			want: "abc",
+		},
+		{
+			desc: "run in the middle",
+			text: "see https://example.com now",
+			pos:  10,
+			want: "https://example.com",
+		},
+		{
+			desc: "runs are delimited by newlines",
+			text: "ab\ncd\nef",
+			pos:  3,
+			want: "cd",
+		},
+		{
+			desc: "run with full-width runes",
+			text: "a 世界 b",
+			pos:  5,
+			want: "世界",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := runAt(tc.text, tc.pos); got != tc.want {
+				t.Errorf("runAt(%q, %d) => %q, want %q", tc.text, tc.pos, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOnClick(t *testing.T) {
+	isTicket := func(s string) bool { return strings.HasPrefix(s, "BUG-") }
+	isAny := func(string) bool { return true }
+
+	tests := []struct {
+		desc   string
+		text   string
+		canvas image.Rectangle
+		// handlers are the matchers, the calls of their handlers are recorded
+		// with the index of the matcher.
+		handlers []func(string) bool
+		events   []*terminalapi.Mouse
+		want     []string
+	}{
+		{
+			desc:     "reports the clicked run",
+			text:     "fixes BUG-12 and BUG-13",
+			canvas:   image.Rect(0, 0, 30, 1),
+			handlers: []func(string) bool{isTicket},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 0}, Button: mouse.ButtonRelease},
+				{Position: image.Point{22, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{22, 0}, Button: mouse.ButtonRelease},
+			},
+			want: []string{"0:BUG-12", "0:BUG-13"},
+		},
+		{
+			desc:     "ignores runs not accepted by the matcher",
+			text:     "fixes BUG-12",
+			canvas:   image.Rect(0, 0, 30, 1),
+			handlers: []func(string) bool{isTicket},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 0}, Button: mouse.ButtonRelease},
+			},
+		},
+		{
+			desc:     "ignores clicks on white space and empty cells",
+			text:     "fixes BUG-12",
+			canvas:   image.Rect(0, 0, 30, 1),
+			handlers: []func(string) bool{isAny},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+				{Position: image.Point{20, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{20, 0}, Button: mouse.ButtonRelease},
+			},
+		},
+		{
+			desc:     "ignores the release of the button on a different rune",
+			text:     "fixes BUG-12",
+			canvas:   image.Rect(0, 0, 30, 1),
+			handlers: []func(string) bool{isTicket},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{7, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 0}, Button: mouse.ButtonRelease},
+			},
+		},
+		{
+			desc:     "reports the whole run when it is wrapped",
+			text:     "BUG-1234",
+			canvas:   image.Rect(0, 0, 5, 2),
+			handlers: []func(string) bool{isTicket},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+			},
+			want: []string{"0:BUG-1234"},
+		},
+		{
+			desc:     "overlapping matchers resolve by registration order",
+			text:     "fixes BUG-12",
+			canvas:   image.Rect(0, 0, 30, 1),
+			handlers: []func(string) bool{isTicket, isAny},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 0}, Button: mouse.ButtonRelease},
+				{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 0}, Button: mouse.ButtonRelease},
+			},
+			want: []string{"0:BUG-12", "1:fixes"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []string
+			opts := []Option{WrapAtRunes()}
+			for i, m := range tc.handlers {
+				i := i
+				opts = append(opts, OnClick(m, func(s string) {
+					got = append(got, fmt.Sprintf("%d:%s", i, s))
+				}))
+			}
+			widget, err := New(opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := widget.Write(tc.text); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+			if err := widget.Draw(testcanvas.MustNew(tc.canvas)); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := widget.Mouse(ev); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("OnClick handlers => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// line_numbers.go contains code that draws the line number gutter.
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+)
+
+// logicalLineCount returns the number of logical lines in the text, i.e. the
+// lines separated by newline characters before any wrapping.
+func logicalLineCount(text string) int {
+	if text == "" {
+		return 0
+	}
+	count := strings.Count(text, "\n") + 1
+	if strings.HasSuffix(text, "\n") {
+		// A trailing newline doesn't start a new line, see scanLineBreak.
+		count--
+	}
+	return count
+}
+
+// gutterWidth returns the width of the line number gutter in cells needed to
+// number the provided count of logical lines. The gutter contains the right
+// aligned numbers followed by one empty cell that separates them from the
+// text.
+func gutterWidth(lineCount int) int {
+	if lineCount <= 0 {
+		return 0
+	}
+	return len(strconv.Itoa(lineCount)) + 1
+}
+
+// lineNumbers returns the logical line numbers for the provided lines which
+// are starting positions of the lines in the text as returned by findLines.
+// The returned slice has the same length as lines, only lines that start a
+// logical line have a number, continuation lines created by wrapping have a
+// zero. Blank lines collapsed by MaxConsecutiveBlankLines keep their numbers,
+// so the numbering skips over them.
+func lineNumbers(text string, lines []int) []int {
+	res := make([]int, len(lines))
+	var newlines, prev int
+	for i, start := range lines {
+		newlines += strings.Count(text[prev:start], "\n")
+		prev = start
+		if start == 0 || text[start-1] == '\n' {
+			res[i] = newlines + 1
+		}
+	}
+	return res
+}
+
+// drawLineNumbers draws the line number gutter onto the canvas.
+// The fromLine is the first line drawn on the canvas, the left is the column
+// where the gutter starts and the gutter is the width of the gutter in cells.
+func (t *Text) drawLineNumbers(cvs *canvas.Canvas, text string, fromLine, left, gutter int) error {
+	height := cvs.Area().Dy()
+	numbers := lineNumbers(text, t.lines)
+	digits := gutter - 1
+	for row := 0; row < height; row++ {
+		line := fromLine + row
+		if line >= len(t.lines) {
+			break
+		}
+
+		// Don't number the lines replaced by the scroll markers.
+		if row == 0 && height >= minLinesForMarkers && fromLine > 0 {
+			continue
+		}
+		if row == height-1 && height >= minLinesForMarkers && height < len(t.lines)-fromLine {
+			continue
+		}
+
+		numAr := image.Rect(left, row, left+digits, row+1)
+		if err := cvs.SetAreaCellOpts(numAr, t.opts.lineNumberCellOpts...); err != nil {
+			return err
+		}
+		num := numbers[line]
+		if num == 0 {
+			continue // Continuation of a wrapped line.
+		}
+
+		s := strconv.Itoa(num)
+		start := image.Point{left + digits - len(s), row}
+		if _, err := draw.Text(cvs, s, start, draw.TextCellOpts(t.opts.lineNumberCellOpts...)); err != nil {
+			return fmt.Errorf("draw.Text => %v", err)
+		}
+	}
+	return nil
+}
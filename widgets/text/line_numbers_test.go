@@ -0,0 +1,165 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestLogicalLineCount(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+		want int
+	}{
+		{
+			desc: "empty text",
+			text: "",
+			want: 0,
+		},
+		{
+			desc: "single line",
+			text: "hello",
+			want: 1,
+		},
+		{
+			desc: "only a newline",
+			text: "\n",
+			want: 1,
+		},
+		{
+			desc: "multiple lines",
+			text: "a\nb\nc",
+			want: 3,
+		},
+		{
+			desc: "trailing newline doesn't start a line",
+			text: "a\nb\n",
+			want: 2,
+		},
+		{
+			desc: "empty lines are counted",
+			text: "a\n\n\nb",
+			want: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := logicalLineCount(tc.text); got != tc.want {
+				t.Errorf("logicalLineCount => %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGutterWidth(t *testing.T) {
+	tests := []struct {
+		lineCount int
+		want      int
+	}{
+		{0, 0},
+		{1, 2},
+		{9, 2},
+		{10, 3},
+		{99, 3},
+		{100, 4},
+		{999, 4},
+		{1000, 5},
+	}
+
+	for _, tc := range tests {
+		if got := gutterWidth(tc.lineCount); got != tc.want {
+			t.Errorf("gutterWidth(%d) => %d, want %d", tc.lineCount, got, tc.want)
+		}
+	}
+}
+
+func TestLineNumbers(t *testing.T) {
+	tests := []struct {
+		desc     string
+		text     string
+		cvsWidth int
+		opts     *options
+		want     []int
+	}{
+		{
+			desc:     "no text",
+			text:     "",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{},
+		},
+		{
+			desc:     "single line",
+			text:     "abc",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{1},
+		},
+		{
+			desc:     "multiple lines",
+			text:     "a\nb\nc",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{1, 2, 3},
+		},
+		{
+			desc:     "empty lines",
+			text:     "a\n\nc",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{1, 2, 3},
+		},
+		{
+			desc:     "wrapped lines number only the first row",
+			text:     "abcdefg\nh",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{1, 0, 0, 2},
+		},
+		{
+			desc:     "wrapped full-width runes",
+			text:     "你好\n世界",
+			cvsWidth: 2,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{1, 0, 2, 0},
+		},
+		{
+			desc:     "numbers past nine",
+			text:     strings.Repeat("a\n", 10) + "a",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lines := findLines(tc.text, tc.cvsWidth, tc.opts)
+			got := lineNumbers(tc.text, lines)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("lineNumbers => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
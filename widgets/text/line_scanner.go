@@ -35,6 +35,21 @@ func wrapNeeded(r rune, cvsPosX, cvsWidth int, opts *options) bool {
 	return cvsPosX > cvsWidth-rw && opts.wrapAtRunes
 }
 
+// wrapIndent returns the number of cells the continuation rows of wrapped
+// lines are indented by on a canvas of the provided width. The indent leaves
+// space for at least one full-width rune, so that each row fits at least one
+// rune.
+func wrapIndent(cvsWidth int, opts *options) int {
+	indent := opts.wrapIndent
+	if max := cvsWidth - 2; indent > max {
+		indent = max
+	}
+	if indent < 0 {
+		indent = 0
+	}
+	return indent
+}
+
 // findLines finds the starting positions of all lines in the text when the
 // text is drawn on a canvas of the provided width with the specified options.
 func findLines(text string, cvsWidth int, opts *options) []int {
@@ -63,11 +78,22 @@ type lineScanner struct {
 	// canvas.
 	cvsPosX int
 
+	// indent is the number of cells continuation rows of wrapped lines are
+	// indented by.
+	indent int
+
+	// prev is the previously scanned character.
+	prev rune
+
 	// opts are the widget options.
 	opts *options
 
 	// lines are the starting points of the identified lines.
 	lines []int
+
+	// blankLines is the number of consecutive blank lines that were scanned
+	// so far, reset by each line that isn't blank.
+	blankLines int
 }
 
 // newLineScanner returns a new line scanner of the provided text.
@@ -83,6 +109,7 @@ func newLineScanner(text string, cvsWidth int, opts *options) *lineScanner {
 	return &lineScanner{
 		scanner:  &s,
 		cvsWidth: cvsWidth,
+		indent:   wrapIndent(cvsWidth, opts),
 		opts:     opts,
 	}
 }
@@ -98,21 +125,46 @@ func scanStart(ls *lineScanner) scannerState {
 		return nil
 
 	default:
-		ls.lines = append(ls.lines, ls.scanner.Position.Offset)
+		ls.addLine(ls.scanner.Position.Offset, tok == '\n')
 		return scanLine
 	}
 }
 
+// addLine records the starting location of a line unless it is a blank line
+// that exceeds the maximum number of consecutive blank lines.
+func (ls *lineScanner) addLine(offset int, blank bool) {
+	if !blank {
+		ls.blankLines = 0
+		ls.lines = append(ls.lines, offset)
+		return
+	}
+
+	ls.blankLines++
+	if ls.opts.collapseBlankLines && ls.blankLines > ls.opts.maxBlankLines {
+		return // Collapsed.
+	}
+	ls.lines = append(ls.lines, offset)
+}
+
 // scanLine scans a line until it finds its end.
 func scanLine(ls *lineScanner) scannerState {
 	for {
-		switch tok := ls.scanner.Scan(); {
+		tok := ls.scanner.Scan()
+		prev := ls.prev
+		ls.prev = tok
+		switch {
 		case tok == scanner.EOF:
 			return nil
 
 		case tok == scanner.Ident:
+			ls.prev = '\n'
 			return scanLineBreak
 
+		case runewidth.ExtendsCluster(prev, tok):
+			// Belongs to the grapheme cluster of the previous character,
+			// doesn't occupy any cells and never starts a new line.
+			continue
+
 		case wrapNeeded(tok, ls.cvsPosX, ls.cvsWidth, ls.opts):
 			return scanLineWrap
 
@@ -126,9 +178,9 @@ func scanLine(ls *lineScanner) scannerState {
 // scanLineBreak processes a newline character in the input text.
 func scanLineBreak(ls *lineScanner) scannerState {
 	// Newline characters aren't printed, the following character starts the line.
-	if ls.scanner.Peek() != scanner.EOF {
+	if next := ls.scanner.Peek(); next != scanner.EOF {
 		ls.cvsPosX = 0
-		ls.lines = append(ls.lines, ls.scanner.Position.Offset+1)
+		ls.addLine(ls.scanner.Position.Offset+1, next == '\n')
 	}
 	return scanLine
 }
@@ -136,8 +188,8 @@ func scanLineBreak(ls *lineScanner) scannerState {
 // scanLineWrap processes a line wrap due to canvas width.
 func scanLineWrap(ls *lineScanner) scannerState {
 	// The character on which we wrapped will be printed and is the start of
-	// new line.
-	ls.cvsPosX = runewidth.StringWidth(ls.scanner.TokenText())
+	// new line, after the indent.
+	ls.cvsPosX = ls.indent + runewidth.StringWidth(ls.scanner.TokenText())
 	ls.lines = append(ls.lines, ls.scanner.Position.Offset)
 	return scanLine
 }
@@ -273,6 +273,167 @@ func TestFindLines(t *testing.T) {
 			},
 			want: []int{0, 1, 2},
 		},
+		{
+			desc:     "combining accents don't occupy cells",
+			text:     "abe\u0301c",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{0, 5},
+		},
+		{
+			desc:     "combining accent stays with its base rune at the end of a line",
+			text:     "abe\u0301",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{0},
+		},
+		{
+			desc:     "family emoji occupies the cells of one full-width rune",
+			text:     "👨\u200d👩\u200d👧ab",
+			cvsWidth: 4,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{0},
+		},
+		{
+			desc:     "wraps after a family emoji",
+			text:     "👨\u200d👩\u200d👧ab",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{0, 19},
+		},
+		{
+			desc:     "emoji with a skin tone modifier occupies the cells of one full-width rune",
+			text:     "👍\U0001F3FDx",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{0},
+		},
+		{
+			desc:     "blank lines aren't collapsed by default",
+			text:     "a\n\n\n\nb",
+			cvsWidth: 5,
+			opts:     &options{},
+			want:     []int{0, 2, 3, 4, 5},
+		},
+		{
+			desc:     "collapses a run of blank lines at the start",
+			text:     "\n\n\na",
+			cvsWidth: 5,
+			opts: &options{
+				collapseBlankLines: true,
+				maxBlankLines:      1,
+			},
+			want: []int{0, 3},
+		},
+		{
+			desc:     "collapses a run of blank lines in the middle",
+			text:     "a\n\n\n\nb",
+			cvsWidth: 5,
+			opts: &options{
+				collapseBlankLines: true,
+				maxBlankLines:      1,
+			},
+			want: []int{0, 2, 5},
+		},
+		{
+			desc:     "collapses a run of blank lines at the end",
+			text:     "a\n\n\n\n",
+			cvsWidth: 5,
+			opts: &options{
+				collapseBlankLines: true,
+				maxBlankLines:      2,
+			},
+			want: []int{0, 2, 3},
+		},
+		{
+			desc:     "keeps runs that don't exceed the maximum",
+			text:     "a\n\nb\n\n\nc",
+			cvsWidth: 5,
+			opts: &options{
+				collapseBlankLines: true,
+				maxBlankLines:      2,
+			},
+			want: []int{0, 2, 3, 5, 6, 7},
+		},
+		{
+			desc:     "hides all the blank lines with zero maximum",
+			text:     "\na\n\n\nb\n\n",
+			cvsWidth: 5,
+			opts: &options{
+				collapseBlankLines: true,
+			},
+			want: []int{1, 5},
+		},
+		{
+			desc:     "lines with spaces aren't blank",
+			text:     "a\n \n \nb",
+			cvsWidth: 5,
+			opts: &options{
+				collapseBlankLines: true,
+			},
+			want: []int{0, 2, 4, 6},
+		},
+		{
+			desc:     "collapses blank lines between wrapped lines",
+			text:     "abcd\n\n\nef",
+			cvsWidth: 2,
+			opts: &options{
+				wrapAtRunes:        true,
+				collapseBlankLines: true,
+				maxBlankLines:      1,
+			},
+			want: []int{0, 2, 5, 7},
+		},
+		{
+			desc:     "continuation rows reserve the wrap indent",
+			text:     "abcdefgh",
+			cvsWidth: 4,
+			opts: &options{
+				wrapAtRunes: true,
+				wrapIndent:  2,
+			},
+			want: []int{0, 4, 6},
+		},
+		{
+			desc:     "newline characters start rows without the wrap indent",
+			text:     "abcdef\ngh",
+			cvsWidth: 4,
+			opts: &options{
+				wrapAtRunes: true,
+				wrapIndent:  2,
+			},
+			want: []int{0, 4, 7},
+		},
+		{
+			desc:     "wrap indent wider than the canvas is clamped",
+			text:     "abcdef",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+				wrapIndent:  10,
+			},
+			want: []int{0, 3, 5},
+		},
+		{
+			desc:     "clamped wrap indent leaves space for full-width runes",
+			text:     "世世世",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+				wrapIndent:  10,
+			},
+			want: []int{0, 3, 6},
+		},
 	}
 
 	for _, tc := range tests {
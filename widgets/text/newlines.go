@@ -0,0 +1,163 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// newlines.go contains code that interprets carriage return characters.
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mum4k/termdash/internal/attrrange"
+)
+
+// normalizeNewlines replaces all "\r\n" sequences and lone carriage returns in
+// the text with newlines. The afterCR indicates if the previously written
+// text ended with a carriage return, in which case a newline at the start of
+// the text is dropped, since the carriage return was already replaced.
+// Returns the normalized text and true if the text ends with a carriage
+// return.
+func normalizeNewlines(text string, afterCR bool) (string, bool) {
+	if afterCR && strings.HasPrefix(text, "\n") {
+		text = text[1:]
+	}
+	endsCR := strings.HasSuffix(text, "\r")
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	return strings.Replace(text, "\r", "\n", -1), endsCR
+}
+
+// lineStart returns the byte position where the last line of the text
+// starts.
+func lineStart(text string) int {
+	return strings.LastIndexByte(text, '\n') + 1
+}
+
+// writeOverwrite writes the text into the buffer interpreting carriage
+// returns as moving to the start of the current line and overwriting it.
+// The wOptsIdx is the index of the write options for the text.
+// Caller must hold t.mu.
+func (t *Text) writeOverwrite(text string, wOptsIdx int) error {
+	for i, piece := range strings.Split(text, "\r") {
+		if i > 0 {
+			t.overwriteAt = lineStart(t.buff.String())
+			if t.overwriteAt == t.buff.Len() {
+				t.overwriteAt = -1 // Empty line, nothing to overwrite.
+			}
+		}
+
+		if t.overwriteAt >= 0 {
+			// Only the current line can be overwritten, any text after a
+			// newline is appended.
+			head := piece
+			if nl := strings.IndexByte(piece, '\n'); nl >= 0 {
+				head = piece[:nl]
+			}
+			if err := t.overwrite(head, wOptsIdx); err != nil {
+				return err
+			}
+			piece = piece[len(head):]
+			if piece != "" {
+				t.overwriteAt = -1
+			}
+		}
+
+		if piece != "" {
+			if err := t.appendText(piece, wOptsIdx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendText appends the text to the end of the buffer.
+// The wOptsIdx is the index of the write options for the text.
+// Caller must hold t.mu.
+func (t *Text) appendText(text string, wOptsIdx int) error {
+	pos := t.buff.Len()
+	if err := t.wOptsTracker.Add(pos, pos+len(text), wOptsIdx); err != nil {
+		return err
+	}
+	if _, err := t.buff.WriteString(text); err != nil {
+		return err
+	}
+	return nil
+}
+
+// overwrite replaces runes in the buffer starting at t.overwriteAt with the
+// runes of the text, one rune for one rune. Runes that don't have a
+// counterpart in the buffer are appended.
+// The text must not contain any newline characters.
+// The wOptsIdx is the index of the write options for the text.
+// Caller must hold t.mu.
+func (t *Text) overwrite(text string, wOptsIdx int) error {
+	if text == "" {
+		return nil
+	}
+
+	at := t.overwriteAt
+	old := t.buff.String()
+	tail := old[at:]
+	var replaced int // Bytes of the tail replaced by the text.
+	for n := utf8.RuneCountInString(text); n > 0 && replaced < len(tail); n-- {
+		_, size := utf8.DecodeRuneInString(tail[replaced:])
+		replaced += size
+	}
+
+	// Rebuild the write option ranges, since the size of the overwritten
+	// runes can differ which shifts the positions after them.
+	ranges, err := t.wOptsTracker.ForRange(0, len(old))
+	if err != nil {
+		return err
+	}
+	shift := len(text) - replaced
+	tr := attrrange.NewTracker()
+	for _, ar := range ranges {
+		// The portion of the range before the overwritten runes.
+		if high := ar.High; ar.Low < at {
+			if high > at {
+				high = at
+			}
+			if err := tr.Add(ar.Low, high, ar.AttrIdx); err != nil {
+				return err
+			}
+		}
+		// The portion of the range after the overwritten runes.
+		if low := ar.Low; ar.High > at+replaced {
+			if low < at+replaced {
+				low = at + replaced
+			}
+			if err := tr.Add(low+shift, ar.High+shift, ar.AttrIdx); err != nil {
+				return err
+			}
+		}
+	}
+	if err := tr.Add(at, at+len(text), wOptsIdx); err != nil {
+		return err
+	}
+	t.wOptsTracker = tr
+
+	updated := old[:at] + text + tail[replaced:]
+	t.buff.Reset()
+	if _, err := t.buff.WriteString(updated); err != nil {
+		return err
+	}
+
+	t.overwriteAt = at + len(text)
+	if t.overwriteAt == len(updated) {
+		t.overwriteAt = -1
+	}
+	return nil
+}
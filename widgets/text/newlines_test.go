@@ -0,0 +1,238 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := []struct {
+		desc       string
+		text       string
+		afterCR    bool
+		want       string
+		wantEndsCR bool
+	}{
+		{
+			desc: "no carriage returns",
+			text: "a\nb",
+			want: "a\nb",
+		},
+		{
+			desc: "CRLF is a single newline",
+			text: "a\r\nb\r\n",
+			want: "a\nb\n",
+		},
+		{
+			desc: "lone carriage return is a newline",
+			text: "a\rb",
+			want: "a\nb",
+		},
+		{
+			desc: "mixed line endings",
+			text: "a\r\nb\nc\rd\n\re",
+			want: "a\nb\nc\nd\n\ne",
+		},
+		{
+			desc:       "reports trailing carriage return",
+			text:       "a\r",
+			want:       "a\n",
+			wantEndsCR: true,
+		},
+		{
+			desc:    "drops leading newline after a carriage return",
+			text:    "\nb",
+			afterCR: true,
+			want:    "b",
+		},
+		{
+			desc: "keeps leading newline without a preceding carriage return",
+			text: "\nb",
+			want: "\nb",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotEndsCR := normalizeNewlines(tc.text, tc.afterCR)
+			if got != tc.want || gotEndsCR != tc.wantEndsCR {
+				t.Errorf("normalizeNewlines(%q, %v) => %q, %v, want %q, %v", tc.text, tc.afterCR, got, gotEndsCR, tc.want, tc.wantEndsCR)
+			}
+		})
+	}
+}
+
+// write is a call to Text.Write.
+type write struct {
+	text  string
+	wOpts []WriteOption
+}
+
+func TestCarriageReturns(t *testing.T) {
+	red := WriteCellOpts(cell.FgColor(cell.ColorRed))
+	blue := WriteCellOpts(cell.FgColor(cell.ColorBlue))
+
+	tests := []struct {
+		desc   string
+		opts   []Option
+		writes []write
+		// want is the content of the buffer.
+		want string
+		// wantAttrs are the indices of the write options of each byte in
+		// the buffer.
+		wantAttrs []int
+		// wantLines are the starting positions of lines when drawn on a
+		// canvas ten cells wide.
+		wantLines []int
+	}{
+		{
+			desc: "mixed line endings as newlines",
+			writes: []write{
+				{text: "ab\r\ncd\ref\ngh"},
+			},
+			want:      "ab\ncd\nef\ngh",
+			wantAttrs: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			wantLines: []int{0, 3, 6, 9},
+		},
+		{
+			desc: "CRLF split across writes is a single newline",
+			writes: []write{
+				{text: "ab\r"},
+				{text: "\ncd"},
+			},
+			want:      "ab\ncd",
+			wantAttrs: []int{0, 0, 0, 1, 1},
+			wantLines: []int{0, 3},
+		},
+		{
+			desc: "CRLF is a single newline in the overwrite mode",
+			opts: []Option{
+				CarriageReturns(CarriageReturnOverwrite),
+			},
+			writes: []write{
+				{text: "ab\r\ncd"},
+			},
+			want:      "ab\ncd",
+			wantAttrs: []int{0, 0, 0, 0, 0},
+			wantLines: []int{0, 3},
+		},
+		{
+			desc: "overwrites the current line",
+			opts: []Option{
+				CarriageReturns(CarriageReturnOverwrite),
+			},
+			writes: []write{
+				{text: "line\n10%"},
+				{text: "\r20%"},
+				{text: "\r100%"},
+			},
+			want:      "line\n100%",
+			wantAttrs: []int{0, 0, 0, 0, 0, 2, 2, 2, 2},
+			wantLines: []int{0, 5},
+		},
+		{
+			desc: "keeps the end of a longer line",
+			opts: []Option{
+				CarriageReturns(CarriageReturnOverwrite),
+			},
+			writes: []write{
+				{text: "abcd", wOpts: []WriteOption{red}},
+				{text: "\rX", wOpts: []WriteOption{blue}},
+				{text: "Y"},
+			},
+			want:      "XYcd",
+			wantAttrs: []int{1, 2, 0, 0},
+			wantLines: []int{0},
+		},
+		{
+			desc: "newline after overwrite appends after the end of the line",
+			opts: []Option{
+				CarriageReturns(CarriageReturnOverwrite),
+			},
+			writes: []write{
+				{text: "abcd\rX\nnext"},
+			},
+			want:      "Xbcd\nnext",
+			wantAttrs: []int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			wantLines: []int{0, 5},
+		},
+		{
+			desc: "overwrites runes of different sizes",
+			opts: []Option{
+				CarriageReturns(CarriageReturnOverwrite),
+			},
+			writes: []write{
+				{text: "a世b", wOpts: []WriteOption{red}},
+				{text: "\rxy"},
+			},
+			want:      "xyb",
+			wantAttrs: []int{1, 1, 0},
+			wantLines: []int{0},
+		},
+		{
+			desc: "carriage return on an empty line appends",
+			opts: []Option{
+				CarriageReturns(CarriageReturnOverwrite),
+			},
+			writes: []write{
+				{text: "ab\n"},
+				{text: "\rcd"},
+			},
+			want:      "ab\ncd",
+			wantAttrs: []int{0, 0, 0, 1, 1},
+			wantLines: []int{0, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			widget, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for _, w := range tc.writes {
+				if err := widget.Write(w.text, w.wOpts...); err != nil {
+					t.Fatalf("Write(%q) => unexpected error: %v", w.text, err)
+				}
+			}
+
+			got := widget.buff.String()
+			if got != tc.want {
+				t.Errorf("buffer => %q, want %q", got, tc.want)
+			}
+
+			var gotAttrs []int
+			for i := 0; i < len(got); i++ {
+				ar, err := widget.wOptsTracker.ForPosition(i)
+				if err != nil {
+					t.Fatalf("ForPosition(%d) => unexpected error: %v", i, err)
+				}
+				gotAttrs = append(gotAttrs, ar.AttrIdx)
+			}
+			if diff := pretty.Compare(tc.wantAttrs, gotAttrs); diff != "" {
+				t.Errorf("write options => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			gotLines := findLines(got, 10, widget.opts)
+			if diff := pretty.Compare(tc.wantLines, gotLines); diff != "" {
+				t.Errorf("findLines => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
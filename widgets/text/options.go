@@ -17,6 +17,8 @@ package text
 import (
 	"fmt"
 
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
 )
@@ -32,6 +34,9 @@ type Option interface {
 // options stores the provided options.
 type options struct {
 	wrapAtRunes      bool
+	wrapIndent       int
+	maxWidth         int
+	maxWidthAlign    align.Horizontal
 	rollContent      bool
 	disableScrolling bool
 	mouseUpButton    mouse.Button
@@ -40,17 +45,47 @@ type options struct {
 	keyDown          keyboard.Key
 	keyPgUp          keyboard.Key
 	keyPgDown        keyboard.Key
+
+	showLineNumbers    bool
+	lineNumberCellOpts []cell.Option
+	onSelect           func(string)
+	selectionCellOpts  []cell.Option
+	showScrollbar      bool
+	clickHandlers      []clickHandler
+	carriageReturn     CarriageReturnMode
+	zebraStripe        bool
+	zebraEven          []cell.Option
+	zebraOdd           []cell.Option
+	collapseBlankLines bool
+	maxBlankLines      int
+
+	searchIgnoreCase      bool
+	searchCellOpts        []cell.Option
+	searchCurrentCellOpts []cell.Option
 }
 
 // newOptions returns a new options instance.
 func newOptions(opts ...Option) *options {
 	opt := &options{
+		maxWidthAlign:   DefaultMaxWidthAlign,
 		mouseUpButton:   DefaultScrollMouseButtonUp,
 		mouseDownButton: DefaultScrollMouseButtonDown,
 		keyUp:           DefaultScrollKeyUp,
 		keyDown:         DefaultScrollKeyDown,
 		keyPgUp:         DefaultScrollKeyPageUp,
 		keyPgDown:       DefaultScrollKeyPageDown,
+		selectionCellOpts: []cell.Option{
+			cell.FgColor(DefaultSelectionFgColor),
+			cell.BgColor(DefaultSelectionBgColor),
+		},
+		searchCellOpts: []cell.Option{
+			cell.FgColor(DefaultSearchFgColor),
+			cell.BgColor(DefaultSearchBgColor),
+		},
+		searchCurrentCellOpts: []cell.Option{
+			cell.FgColor(DefaultSearchFgColor),
+			cell.BgColor(DefaultSearchCurrentBgColor),
+		},
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -72,6 +107,47 @@ func (o *options) validate() error {
 	if o.mouseUpButton == o.mouseDownButton {
 		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
 	}
+	if o.onSelect != nil && !o.disableScrolling {
+		for _, b := range []mouse.Button{o.mouseUpButton, o.mouseDownButton} {
+			if b == mouse.ButtonLeft || b == mouse.ButtonRelease {
+				return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the %v button is used to select text when OnSelect is provided", o.mouseUpButton, o.mouseDownButton, b)
+			}
+		}
+	}
+	if len(o.clickHandlers) > 0 && !o.disableScrolling {
+		for _, b := range []mouse.Button{o.mouseUpButton, o.mouseDownButton} {
+			if b == mouse.ButtonLeft || b == mouse.ButtonRelease {
+				return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the %v button is used to click on text when OnClick is provided", o.mouseUpButton, o.mouseDownButton, b)
+			}
+		}
+	}
+	if o.wrapIndent < 0 {
+		return fmt.Errorf("invalid WrapIndent(%d), must be zero or a positive number", o.wrapIndent)
+	}
+	if o.maxWidth < 0 {
+		return fmt.Errorf("invalid MaxWidth(%d), must be zero or a positive number", o.maxWidth)
+	}
+	if _, ok := validHAligns[o.maxWidthAlign]; !ok {
+		return fmt.Errorf("invalid MaxWidthAlign(%v)", o.maxWidthAlign)
+	}
+	if o.collapseBlankLines && o.maxBlankLines < 0 {
+		return fmt.Errorf("invalid MaxConsecutiveBlankLines(%d), must be zero or a positive number", o.maxBlankLines)
+	}
+	if _, ok := carriageReturnNames[o.carriageReturn]; !ok {
+		return fmt.Errorf("invalid CarriageReturns(%v)", o.carriageReturn)
+	}
+	for i, ch := range o.clickHandlers {
+		if ch.matcher == nil || ch.handler == nil {
+			return fmt.Errorf("invalid OnClick option #%d, both the matcher and the handler must be provided", i)
+		}
+	}
+	if o.showScrollbar && !o.disableScrolling {
+		for _, b := range []mouse.Button{o.mouseUpButton, o.mouseDownButton} {
+			if b == mouse.ButtonLeft || b == mouse.ButtonRelease {
+				return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the %v button is used to drag the scrollbar when ShowScrollbar is provided", o.mouseUpButton, o.mouseDownButton, b)
+			}
+		}
+	}
 	return nil
 }
 
@@ -92,6 +168,49 @@ func WrapAtRunes() Option {
 	})
 }
 
+// WrapIndent indents the continuation rows of lines wrapped by WrapAtRunes by
+// the provided number of cells, i.e. draws a hanging indent. The indent is
+// limited so that at least two cells remain for the text on each row.
+// Has no effect unless WrapAtRunes is provided. Must be zero or a positive
+// number, defaults to zero.
+func WrapIndent(n int) Option {
+	return option(func(opts *options) {
+		opts.wrapIndent = n
+	})
+}
+
+// MaxWidth limits the width of the text to the provided number of cells, the
+// text is trimmed or wrapped at this width even if the canvas is wider. The
+// text is then placed within the canvas according to the MaxWidthAlign option.
+// The full width of the canvas is used when it is narrower than the limit.
+// The width doesn't include the line number gutter and the scrollbar which
+// are placed next to the text. Must be zero or a positive number, defaults
+// to zero which means no limit.
+func MaxWidth(n int) Option {
+	return option(func(opts *options) {
+		opts.maxWidth = n
+	})
+}
+
+// DefaultMaxWidthAlign is the default value for the MaxWidthAlign option.
+const DefaultMaxWidthAlign = align.HorizontalCenter
+
+// validHAligns are the horizontal alignments supported by MaxWidthAlign.
+var validHAligns = map[align.Horizontal]bool{
+	align.HorizontalLeft:   true,
+	align.HorizontalCenter: true,
+	align.HorizontalRight:  true,
+}
+
+// MaxWidthAlign sets the horizontal alignment of the text within a canvas
+// that is wider than the MaxWidth option. Has no effect unless MaxWidth is
+// provided.
+func MaxWidthAlign(h align.Horizontal) Option {
+	return option(func(opts *options) {
+		opts.maxWidthAlign = h
+	})
+}
+
 // RollContent configures the text widget so that it rolls the text content up
 // if more text than the size of the container is added. If not provided, the
 // content is trimmed instead.
@@ -109,6 +228,103 @@ func DisableScrolling() Option {
 	})
 }
 
+// ShowScrollbar configures the text widget to display a scrollbar in the
+// rightmost column of its canvas, reducing the width available for the text
+// by one column. The size and the position of the scrollbar's thumb reflect
+// the visible fraction of the text and the scrolling position.
+// Unless scrolling is disabled, clicking on the scrollbar or dragging it with
+// the left mouse button scrolls the content to the corresponding position.
+// The scrollbar isn't drawn if the canvas is only one column wide.
+func ShowScrollbar() Option {
+	return option(func(opts *options) {
+		opts.showScrollbar = true
+	})
+}
+
+// ShowLineNumbers configures the text widget to display line numbers in a
+// gutter on the left side of the text. The numbers are right aligned and
+// correspond to the lines of the text separated by newline characters, i.e.
+// when a line is wrapped, only the first row it occupies is numbered.
+// The gutter grows as needed to fit the largest line number, reducing the
+// width available for the text.
+// The provided cell options are applied to the cells of the gutter.
+func ShowLineNumbers(opts ...cell.Option) Option {
+	return option(func(o *options) {
+		o.showLineNumbers = true
+		o.lineNumberCellOpts = opts
+	})
+}
+
+// ZebraStripe configures the text widget to draw the lines of the text with
+// alternating cell options, which makes it easier to follow long lines. The
+// even options are applied to the first logical line and every other line
+// after it, the odd options to the remaining lines. The logical lines are the
+// lines separated by newline characters, when a line is wrapped, all the rows
+// it occupies share the same options.
+// The options are applied to the full width of the rows, options provided to
+// Write via WriteCellOpts take precedence where they differ from the defaults.
+func ZebraStripe(even, odd []cell.Option) Option {
+	return option(func(o *options) {
+		o.zebraStripe = true
+		o.zebraEven = even
+		o.zebraOdd = odd
+	})
+}
+
+// MaxConsecutiveBlankLines collapses runs of consecutive blank lines in the
+// content to at most n blank lines when displaying the text, e.g. to save
+// space when displaying verbose logs. Zero hides all the blank lines. The
+// content itself isn't modified, so text selected with the mouse still
+// contains all the blank lines.
+// The default behavior is to display all the blank lines.
+func MaxConsecutiveBlankLines(n int) Option {
+	return option(func(opts *options) {
+		opts.collapseBlankLines = true
+		opts.maxBlankLines = n
+	})
+}
+
+// CarriageReturnMode determines how the text widget interprets carriage
+// return ('\r') characters that aren't followed by a newline.
+type CarriageReturnMode int
+
+// String implements fmt.Stringer()
+func (crm CarriageReturnMode) String() string {
+	if n, ok := carriageReturnNames[crm]; ok {
+		return n
+	}
+	return "CarriageReturnUnknown"
+}
+
+// carriageReturnNames maps CarriageReturnMode values to human readable names.
+var carriageReturnNames = map[CarriageReturnMode]string{
+	CarriageReturnNewline:   "CarriageReturnNewline",
+	CarriageReturnOverwrite: "CarriageReturnOverwrite",
+}
+
+const (
+	// CarriageReturnNewline interprets a carriage return as a newline.
+	CarriageReturnNewline CarriageReturnMode = iota
+
+	// CarriageReturnOverwrite interprets a carriage return the way terminals
+	// do, i.e. it moves back to the start of the current line and the text
+	// that follows overwrites the line rune by rune. Text that doesn't fit the
+	// existing line is appended to it. Useful for displaying output of tools
+	// that report progress by rewriting the same line.
+	CarriageReturnOverwrite
+)
+
+// CarriageReturns configures how the text widget interprets carriage return
+// ('\r') characters in the written text. Regardless of this option, the
+// "\r\n" sequence is always interpreted as a single newline, even if it is
+// split across two calls to Write.
+// Defaults to CarriageReturnNewline.
+func CarriageReturns(crm CarriageReturnMode) Option {
+	return option(func(opts *options) {
+		opts.carriageReturn = crm
+	})
+}
+
 // The default mouse buttons for content scrolling.
 const (
 	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
@@ -144,3 +360,86 @@ func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
 		opts.keyPgDown = pageDown
 	})
 }
+
+// OnSelect configures the text widget so that the user can select text by
+// dragging the mouse while holding its left button. The provided function is
+// called with the selected text when the user releases the button.
+// The selection is made on the text content, i.e. it doesn't include any
+// line wraps and includes the newline characters. Clicking without dragging
+// clears the selection.
+// The function is called synchronously from the goroutine that delivers mouse
+// events, it must be thread-safe and should not block.
+func OnSelect(fn func(string)) Option {
+	return option(func(opts *options) {
+		opts.onSelect = fn
+	})
+}
+
+// OnClick registers a handler that is called when the user clicks with the
+// left mouse button on a run of text accepted by the matcher. A run is a
+// sequence of runes delimited by white space, e.g. a URL or a ticket ID in a
+// line of a log. The matcher and the handler are called with the run of text
+// as it was written into the widget, regardless of how it was wrapped when
+// drawn.
+// This option can be provided multiple times to register multiple handlers.
+// When multiple matchers accept the same run, only the handler registered
+// first is called.
+// The functions are called synchronously from the goroutine that delivers
+// mouse events, they must be thread-safe and should not block.
+func OnClick(matcher func(string) bool, handler func(string)) Option {
+	return option(func(opts *options) {
+		opts.clickHandlers = append(opts.clickHandlers, clickHandler{
+			matcher: matcher,
+			handler: handler,
+		})
+	})
+}
+
+// The default colors of the selected text.
+const (
+	DefaultSelectionFgColor = cell.ColorBlack
+	DefaultSelectionBgColor = cell.ColorWhite
+)
+
+// SelectionCellOpts sets the cell options applied to the cells of the text
+// selected with the mouse, see OnSelect. Defaults to
+// DefaultSelectionFgColor and DefaultSelectionBgColor.
+func SelectionCellOpts(opts ...cell.Option) Option {
+	return option(func(o *options) {
+		o.selectionCellOpts = opts
+	})
+}
+
+// SearchIgnoreCase makes the Search method match the text regardless of the
+// case of the letters.
+// The default behavior is a case sensitive search.
+func SearchIgnoreCase() Option {
+	return option(func(opts *options) {
+		opts.searchIgnoreCase = true
+	})
+}
+
+// The default colors of the matches of the text searched for with Search.
+const (
+	DefaultSearchFgColor        = cell.ColorBlack
+	DefaultSearchBgColor        = cell.ColorYellow
+	DefaultSearchCurrentBgColor = cell.ColorRed
+)
+
+// SearchCellOpts sets the cell options applied to the cells of the matches of
+// the text searched for with Search, other than the current match.
+// Defaults to DefaultSearchFgColor and DefaultSearchBgColor.
+func SearchCellOpts(opts ...cell.Option) Option {
+	return option(func(o *options) {
+		o.searchCellOpts = opts
+	})
+}
+
+// SearchCurrentCellOpts sets the cell options applied to the cells of the
+// current match of the text searched for with Search.
+// Defaults to DefaultSearchFgColor and DefaultSearchCurrentBgColor.
+func SearchCurrentCellOpts(opts ...cell.Option) Option {
+	return option(func(o *options) {
+		o.searchCurrentCellOpts = opts
+	})
+}
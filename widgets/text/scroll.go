@@ -52,14 +52,36 @@ type scrollTracker struct {
 
 	// state is the state of the scrolling FSM.
 	state rollState
+
+	// follow is true when the FSM is in the rollToEnd state.
+	follow bool
 }
 
 // newScrollTracker returns a new scroll tracker.
 func newScrollTracker(opts *options) *scrollTracker {
-	if opts.rollContent {
-		return &scrollTracker{state: rollToEnd}
+	st := &scrollTracker{}
+	st.setFollow(opts.rollContent)
+	return st
+}
+
+// setFollow either forces the content to roll so that the last line is always
+// visible or disables content rolling until the next call to setFollow.
+// Discards any outstanding scroll requests.
+func (st *scrollTracker) setFollow(follow bool) {
+	st.scroll = 0
+	st.scrollPage = 0
+	st.follow = follow
+	if follow {
+		st.state = rollToEnd
+	} else {
+		st.state = rollingDisabled
 	}
-	return &scrollTracker{state: rollingDisabled}
+}
+
+// following returns true if the content rolls so that the last line is
+// always visible.
+func (st *scrollTracker) following() bool {
+	return st.follow
 }
 
 // upOneLine processes a user request to scroll up by one line.
@@ -82,6 +104,13 @@ func (st *scrollTracker) downOnePage() {
 	st.scrollPage++
 }
 
+// scrollTo processes a user request to scroll so that the specified line
+// becomes the first drawn line. Replaces any outstanding scroll requests.
+func (st *scrollTracker) scrollTo(line int) {
+	st.scroll = line - st.first
+	st.scrollPage = 0
+}
+
 // doScroll processes any outstanding scroll requests and calculates the
 // resulting first line.
 func (st *scrollTracker) doScroll(lines, height int) int {
@@ -124,6 +153,7 @@ func rollToEnd(st *scrollTracker, lines, height int) rollState {
 	if lastLineVisible(st.first, lines, height) {
 		return rollToEnd
 	}
+	st.follow = false
 	return rollingPaused
 }
 
@@ -132,6 +162,7 @@ func rollToEnd(st *scrollTracker, lines, height int) rollState {
 func rollingPaused(st *scrollTracker, lines, height int) rollState {
 	st.first = st.doScroll(lines, height)
 	if lastLineVisible(st.first, lines, height) {
+		st.follow = true
 		return rollToEnd
 	}
 	return rollingPaused
@@ -330,3 +330,92 @@ func TestNormalizeScroll(t *testing.T) {
 		})
 	}
 }
+
+func TestScrollTrackerFollow(t *testing.T) {
+	st := newScrollTracker(&options{})
+	// All of these test cases act on the same instance of the scroll tracker.
+	tests := []struct {
+		desc          string
+		lines         int
+		height        int
+		events        func()
+		want          int
+		wantFollowing bool
+	}{
+		{
+			desc:   "doesn't follow without rolling configured",
+			lines:  4,
+			height: 2,
+			want:   0,
+		},
+		{
+			desc:   "follow rolls the content to the last line",
+			lines:  4,
+			height: 2,
+			events: func() {
+				st.setFollow(true)
+			},
+			want:          2,
+			wantFollowing: true,
+		},
+		{
+			desc:   "user scrolling up pauses following",
+			lines:  4,
+			height: 2,
+			events: func() {
+				st.upOneLine()
+			},
+			want: 1,
+		},
+		{
+			desc:   "scrolling back to the last line resumes following",
+			lines:  5,
+			height: 2,
+			events: func() {
+				st.downOnePage()
+			},
+			want:          3,
+			wantFollowing: true,
+		},
+		{
+			desc:   "disabling follow keeps the position",
+			lines:  6,
+			height: 2,
+			events: func() {
+				st.setFollow(false)
+			},
+			want: 3,
+		},
+		{
+			desc:   "scrolling to the last line doesn't resume following once disabled",
+			lines:  7,
+			height: 2,
+			events: func() {
+				st.downOnePage()
+				st.downOnePage()
+			},
+			want: 5,
+		},
+		{
+			desc:   "new content isn't rolled once disabled",
+			lines:  8,
+			height: 2,
+			want:   5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.events != nil {
+				tc.events()
+			}
+			got := st.firstLine(tc.lines, tc.height)
+			if got != tc.want {
+				t.Errorf("firstLine => got %d, want %d", got, tc.want)
+			}
+			if got := st.following(); got != tc.wantFollowing {
+				t.Errorf("following => got %v, want %v", got, tc.wantFollowing)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// scrollbar.go contains code that draws the scrollbar and maps mouse events
+// on it to scrolling positions.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/internal/canvas"
+)
+
+const (
+	// scrollbarTrackRune is the rune used to draw the track of the scrollbar.
+	scrollbarTrackRune = '│'
+	// scrollbarThumbRune is the rune used to draw the thumb of the scrollbar.
+	scrollbarThumbRune = '█'
+)
+
+// scrollbarThumb returns the first row and the length of the thumb on a
+// scrollbar track of the specified length when drawing lines of text starting
+// at the first line on a canvas with the provided height.
+func scrollbarThumb(first, lines, height, track int) (int, int) {
+	if lines <= height || track <= 0 {
+		return 0, track
+	}
+
+	length := (height*track + lines/2) / lines
+	if length < 1 {
+		length = 1
+	}
+	maxStart := track - length
+	maxFirst := lines - height
+	start := (first*maxStart + maxFirst/2) / maxFirst
+	if start > maxStart {
+		start = maxStart
+	}
+	return start, length
+}
+
+// scrollbarLine returns the first line that should be drawn when the user
+// clicks on the specified row of a scrollbar track of the specified length.
+// The top of the track corresponds to the first line of the text and the
+// bottom to the last page.
+func scrollbarLine(row, lines, height, track int) int {
+	maxFirst := lines - height
+	if maxFirst <= 0 || track <= 1 || row <= 0 {
+		return 0
+	}
+	if row >= track-1 {
+		return maxFirst
+	}
+	return (row*maxFirst + (track-1)/2) / (track - 1)
+}
+
+// drawScrollbar draws the scrollbar in the provided area of the canvas.
+func drawScrollbar(cvs *canvas.Canvas, ar image.Rectangle, first, lines, height int) error {
+	start, length := scrollbarThumb(first, lines, height, ar.Dy())
+	for row := 0; row < ar.Dy(); row++ {
+		r := scrollbarTrackRune
+		if row >= start && row < start+length {
+			r = scrollbarThumbRune
+		}
+		if _, err := cvs.SetCell(image.Point{ar.Min.X, ar.Min.Y + row}, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import "testing"
+
+func TestScrollbarThumb(t *testing.T) {
+	tests := []struct {
+		desc       string
+		first      int
+		lines      int
+		height     int
+		track      int
+		wantStart  int
+		wantLength int
+	}{
+		{
+			desc:       "content fits",
+			lines:      2,
+			height:     4,
+			track:      4,
+			wantStart:  0,
+			wantLength: 4,
+		},
+		{
+			desc:       "at the top",
+			first:      0,
+			lines:      8,
+			height:     4,
+			track:      4,
+			wantStart:  0,
+			wantLength: 2,
+		},
+		{
+			desc:       "at the bottom",
+			first:      4,
+			lines:      8,
+			height:     4,
+			track:      4,
+			wantStart:  2,
+			wantLength: 2,
+		},
+		{
+			desc:       "in the middle",
+			first:      2,
+			lines:      8,
+			height:     4,
+			track:      4,
+			wantStart:  1,
+			wantLength: 2,
+		},
+		{
+			desc:       "thumb is at least one row long",
+			first:      0,
+			lines:      1000,
+			height:     4,
+			track:      4,
+			wantStart:  0,
+			wantLength: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotStart, gotLength := scrollbarThumb(tc.first, tc.lines, tc.height, tc.track)
+			if gotStart != tc.wantStart || gotLength != tc.wantLength {
+				t.Errorf("scrollbarThumb => %d, %d, want %d, %d", gotStart, gotLength, tc.wantStart, tc.wantLength)
+			}
+		})
+	}
+}
+
+func TestScrollbarLine(t *testing.T) {
+	tests := []struct {
+		desc   string
+		row    int
+		lines  int
+		height int
+		track  int
+		want   int
+	}{
+		{
+			desc:   "content fits",
+			row:    3,
+			lines:  2,
+			height: 4,
+			track:  4,
+			want:   0,
+		},
+		{
+			desc:   "top of the track",
+			row:    0,
+			lines:  10,
+			height: 4,
+			track:  4,
+			want:   0,
+		},
+		{
+			desc:   "bottom of the track",
+			row:    3,
+			lines:  10,
+			height: 4,
+			track:  4,
+			want:   6,
+		},
+		{
+			desc:   "middle of the track",
+			row:    1,
+			lines:  10,
+			height: 4,
+			track:  4,
+			want:   2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := scrollbarLine(tc.row, tc.lines, tc.height, tc.track); got != tc.want {
+				t.Errorf("scrollbarLine => %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// search.go contains code that finds and navigates the matches of the text
+// searched for with Text.Search.
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// searchMatch is a range of the text that matches the search query.
+type searchMatch struct {
+	// start is the byte position of the first rune of the match.
+	start int
+	// end is the byte position just after the last rune of the match.
+	end int
+}
+
+// foldEqual returns true if the two runes are equal under simple Unicode case
+// folding.
+func foldEqual(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+		if f == b {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAt determines if the text at the byte position starts with the query.
+// Returns the byte position just after the match.
+func matchAt(text string, pos int, query string, ignoreCase bool) (int, bool) {
+	if !ignoreCase {
+		if strings.HasPrefix(text[pos:], query) {
+			return pos + len(query), true
+		}
+		return 0, false
+	}
+
+	end := pos
+	for _, qr := range query {
+		if end >= len(text) {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if !foldEqual(r, qr) {
+			return 0, false
+		}
+		end += size
+	}
+	return end, true
+}
+
+// findMatches returns all the non-overlapping matches of the query in the
+// text, ordered by their position.
+func findMatches(text, query string, ignoreCase bool) []searchMatch {
+	if query == "" {
+		return nil
+	}
+
+	var res []searchMatch
+	for i := 0; i < len(text); {
+		if end, ok := matchAt(text, i, query, ignoreCase); ok {
+			res = append(res, searchMatch{start: i, end: end})
+			i = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return res
+}
+
+// search tracks the matches of the text searched for with Text.Search.
+//
+// This is not thread safe.
+type search struct {
+	// query is the searched for text, empty if there is no search.
+	query string
+	// matches are the matches of the query in the text.
+	matches []searchMatch
+	// current is the index of the current match in matches or -1 if there
+	// are no matches.
+	current int
+	// center is true if the current match should be scrolled into the middle
+	// of the canvas on the next redraw.
+	center bool
+}
+
+// newSearch returns a new search that doesn't have any matches.
+func newSearch() *search {
+	return &search{current: -1}
+}
+
+// start starts a new search for the query in the text, making the first match
+// the current one.
+func (s *search) start(text, query string, ignoreCase bool) {
+	s.query = query
+	s.matches = findMatches(text, query, ignoreCase)
+	s.current = -1
+	if len(s.matches) > 0 {
+		s.current = 0
+	}
+	s.center = s.current >= 0
+}
+
+// update finds the matches of the query again after the text changed. Keeps
+// the index of the current match if it is still valid.
+func (s *search) update(text string, ignoreCase bool) {
+	if s.query == "" {
+		return
+	}
+	s.matches = findMatches(text, s.query, ignoreCase)
+	switch {
+	case len(s.matches) == 0:
+		s.current = -1
+	case s.current < 0:
+		s.current = 0
+	case s.current >= len(s.matches):
+		s.current = len(s.matches) - 1
+	}
+}
+
+// next makes the following match the current one, wrapping around to the
+// first match.
+func (s *search) next() {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current + 1) % len(s.matches)
+	s.center = true
+}
+
+// prev makes the preceding match the current one, wrapping around to the last
+// match.
+func (s *search) prev() {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current - 1 + len(s.matches)) % len(s.matches)
+	s.center = true
+}
+
+// takeCenter returns the byte position of the current match if it should be
+// scrolled into the middle of the canvas. Only returns true once for each
+// request.
+func (s *search) takeCenter() (int, bool) {
+	if !s.center || s.current < 0 {
+		return 0, false
+	}
+	s.center = false
+	return s.matches[s.current].start, true
+}
+
+// at returns the index of the match that contains the rune at the byte
+// position or -1 if the rune isn't part of any match.
+func (s *search) at(pos int) int {
+	i := sort.Search(len(s.matches), func(i int) bool {
+		return s.matches[i].end > pos
+	})
+	if i < len(s.matches) && s.matches[i].start <= pos {
+		return i
+	}
+	return -1
+}
+
+// Search highlights all the occurrences of the query in the text and makes
+// the first one the current match, which is scrolled into the middle of the
+// canvas on the next redraw. Use SearchNext and SearchPrev to move between
+// the matches and SearchStatus to report them.
+// The matches are updated when text is written into the widget. An empty
+// query clears the search. The search is case sensitive unless the
+// SearchIgnoreCase option is provided.
+func (t *Text) Search(query string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.search.start(t.buff.String(), query, t.opts.searchIgnoreCase)
+	t.notifyChanged()
+}
+
+// SearchNext makes the match that follows the current one the current match
+// and scrolls it into the middle of the canvas on the next redraw. Wraps
+// around to the first match after the last one.
+func (t *Text) SearchNext() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.search.next()
+	t.notifyChanged()
+}
+
+// SearchPrev makes the match that precedes the current one the current match
+// and scrolls it into the middle of the canvas on the next redraw. Wraps
+// around to the last match before the first one.
+func (t *Text) SearchPrev() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.search.prev()
+	t.notifyChanged()
+}
+
+// SearchStatus returns the zero based index of the current match and the
+// total number of matches of the query provided to Search, e.g. to display
+// "match 2 of 5" in a status line. The current index is -1 if there are no
+// matches.
+func (t *Text) SearchStatus() (current, total int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.search.current, len(t.search.matches)
+}
+
+// updateSearch finds the matches of the search again after the text changed.
+// Caller must hold t.mu.
+func (t *Text) updateSearch() {
+	if t.search.query == "" {
+		return
+	}
+	t.search.update(t.buff.String(), t.opts.searchIgnoreCase)
+}
+
+// searchCellOpts returns the cell options that should be applied to the rune
+// at the byte position if it is a part of a match of the search.
+// Caller must hold t.mu.
+func (t *Text) searchCellOpts(pos int) []cell.Option {
+	switch m := t.search.at(pos); {
+	case m < 0:
+		return nil
+	case m == t.search.current:
+		return t.opts.searchCurrentCellOpts
+	default:
+		return t.opts.searchCellOpts
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFindMatches(t *testing.T) {
+	tests := []struct {
+		desc       string
+		text       string
+		query      string
+		ignoreCase bool
+		want       []searchMatch
+	}{
+		{
+			desc:  "no matches for an empty query",
+			text:  "hello",
+			query: "",
+		},
+		{
+			desc:  "no matches when the query isn't found",
+			text:  "hello",
+			query: "world",
+		},
+		{
+			desc:  "finds all the matches",
+			text:  "foo bar foo",
+			query: "foo",
+			want:  []searchMatch{{0, 3}, {8, 11}},
+		},
+		{
+			desc:  "matches don't overlap",
+			text:  "aaaaa",
+			query: "aa",
+			want:  []searchMatch{{0, 2}, {2, 4}},
+		},
+		{
+			desc:  "is case sensitive by default",
+			text:  "Foo foo",
+			query: "foo",
+			want:  []searchMatch{{4, 7}},
+		},
+		{
+			desc:       "ignores case when requested",
+			text:       "Foo fOO",
+			query:      "foo",
+			ignoreCase: true,
+			want:       []searchMatch{{0, 3}, {4, 7}},
+		},
+		{
+			desc:       "ignores case of multi-byte runes",
+			text:       "été ÉTÉ",
+			query:      "été",
+			ignoreCase: true,
+			want:       []searchMatch{{0, 5}, {6, 11}},
+		},
+		{
+			desc:       "match can't extend past the end of the text",
+			text:       "fo",
+			query:      "foo",
+			ignoreCase: true,
+		},
+		{
+			desc:  "matches across lines",
+			text:  "foo\nbar",
+			query: "o\nb",
+			want:  []searchMatch{{2, 5}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := findMatches(tc.text, tc.query, tc.ignoreCase)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("findMatches => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSearchStatus(t *testing.T) {
+	type status struct {
+		Current, Total int
+	}
+
+	tests := []struct {
+		desc   string
+		opts   []Option
+		text   string
+		update func(*Text) error
+		want   status
+	}{
+		{
+			desc: "no matches without a search",
+			text: "foo",
+			update: func(tx *Text) error {
+				return nil
+			},
+			want: status{-1, 0},
+		},
+		{
+			desc: "first match is the current one",
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				return nil
+			},
+			want: status{0, 3},
+		},
+		{
+			desc: "no matches when the query isn't found",
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("FOO")
+				return nil
+			},
+			want: status{-1, 0},
+		},
+		{
+			desc: "finds matches ignoring case with option",
+			opts: []Option{SearchIgnoreCase()},
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("FOO")
+				return nil
+			},
+			want: status{0, 3},
+		},
+		{
+			desc: "next moves to the following match",
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				tx.SearchNext()
+				return nil
+			},
+			want: status{1, 3},
+		},
+		{
+			desc: "next wraps around to the first match",
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				tx.SearchNext()
+				tx.SearchNext()
+				tx.SearchNext()
+				return nil
+			},
+			want: status{0, 3},
+		},
+		{
+			desc: "prev wraps around to the last match",
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				tx.SearchPrev()
+				return nil
+			},
+			want: status{2, 3},
+		},
+		{
+			desc: "navigation does nothing without matches",
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("qux")
+				tx.SearchNext()
+				tx.SearchPrev()
+				return nil
+			},
+			want: status{-1, 0},
+		},
+		{
+			desc: "empty query clears the search",
+			text: "foo bar foo baz foo",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				tx.Search("")
+				return nil
+			},
+			want: status{-1, 0},
+		},
+		{
+			desc: "matches are updated when text is written",
+			text: "foo bar",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				tx.SearchNext()
+				return tx.Write(" foo")
+			},
+			want: status{0, 2},
+		},
+		{
+			desc: "first written match becomes the current one",
+			text: "bar",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				return tx.Write(" foo")
+			},
+			want: status{0, 1},
+		},
+		{
+			desc: "current match is kept in range when text is replaced",
+			text: "foo foo foo",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				tx.SearchPrev()
+				return tx.Write("foo", WriteReplace())
+			},
+			want: status{0, 1},
+		},
+		{
+			desc: "reset clears the search",
+			text: "foo",
+			update: func(tx *Text) error {
+				tx.Search("foo")
+				tx.Reset()
+				return tx.Write("foo")
+			},
+			want: status{-1, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tx, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := tx.Write(tc.text); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+			if err := tc.update(tx); err != nil {
+				t.Fatalf("update => unexpected error: %v", err)
+			}
+
+			var got status
+			got.Current, got.Total = tx.SearchStatus()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("SearchStatus => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// selection.go contains code that tracks text selected with the mouse.
+
+import (
+	"image"
+	"unicode/utf8"
+)
+
+// cellMap maps cells of the canvas the text was last drawn on to byte
+// positions of the runes drawn in them.
+//
+// This is not thread safe.
+type cellMap struct {
+	// origin is the position of the canvas the text was drawn on within the
+	// canvas of the widget.
+	origin image.Point
+
+	// cells are the byte positions of runes indexed as [row][col].
+	// A full-width rune maps both of the cells it occupies.
+	// Cells that don't contain any rune are set to -1.
+	cells [][]int
+
+	// rowStarts are the byte positions where the lines drawn on each row
+	// start. Used for rows that don't contain any runes.
+	rowStarts []int
+}
+
+// newCellMap returns a new cellMap for a canvas with the provided area
+// relative to the canvas of the widget. The rowStarts are the byte positions
+// where the lines drawn on each row start.
+func newCellMap(ar image.Rectangle, rowStarts []int) *cellMap {
+	cells := make([][]int, ar.Dy())
+	for row := range cells {
+		cells[row] = make([]int, ar.Dx())
+		for col := range cells[row] {
+			cells[row][col] = -1
+		}
+	}
+	if len(rowStarts) > len(cells) {
+		rowStarts = rowStarts[:len(cells)]
+	}
+	return &cellMap{
+		origin:    ar.Min,
+		cells:     cells,
+		rowStarts: rowStarts,
+	}
+}
+
+// set records that the rune at the byte position occupies the specified
+// number of cells starting at the point.
+func (cm *cellMap) set(p image.Point, cells, pos int) {
+	if p.Y < 0 || p.Y >= len(cm.cells) {
+		return
+	}
+	row := cm.cells[p.Y]
+	for col := p.X; col < p.X+cells && col < len(row); col++ {
+		if col >= 0 {
+			row[col] = pos
+		}
+	}
+}
+
+// posAt returns the byte position of the rune drawn at the point or nearest
+// to it. The point is relative to the canvas of the widget. Points past the
+// end of a row map to the last rune on the row, points below the last drawn
+// row map to the last drawn row.
+// Returns false if no text was drawn.
+func (cm *cellMap) posAt(p image.Point) (int, bool) {
+	if len(cm.rowStarts) == 0 {
+		return 0, false
+	}
+	p = p.Sub(cm.origin)
+
+	row := p.Y
+	if row < 0 {
+		row = 0
+	}
+	if last := len(cm.rowStarts) - 1; row > last {
+		row = last
+	}
+
+	cells := cm.cells[row]
+	col := p.X
+	if col < 0 {
+		col = 0
+	}
+	if col >= len(cells) {
+		col = len(cells) - 1
+	}
+	for c := col; c >= 0; c-- {
+		if cells[c] >= 0 {
+			return cells[c], true
+		}
+	}
+	for c := col + 1; c < len(cells); c++ {
+		if cells[c] >= 0 {
+			return cells[c], true
+		}
+	}
+	return cm.rowStarts[row], true
+}
+
+// posExact returns the byte position of the rune drawn at the point.
+// The point is relative to the canvas of the widget.
+// Returns false if no rune was drawn at the point.
+func (cm *cellMap) posExact(p image.Point) (int, bool) {
+	p = p.Sub(cm.origin)
+	if p.Y < 0 || p.Y >= len(cm.cells) {
+		return 0, false
+	}
+	row := cm.cells[p.Y]
+	if p.X < 0 || p.X >= len(row) || row[p.X] < 0 {
+		return 0, false
+	}
+	return row[p.X], true
+}
+
+// selection tracks text selected by the user with the mouse.
+//
+// This is not thread safe.
+type selection struct {
+	// active is true if there is a selected range of text.
+	active bool
+	// inProgress is true while the user is still holding the mouse button.
+	inProgress bool
+
+	// anchor is the byte position where the selection started.
+	anchor int
+	// cursor is the byte position where the selection currently ends.
+	cursor int
+}
+
+// start starts a new selection at the byte position.
+func (s *selection) start(pos int) {
+	s.active = false
+	s.inProgress = true
+	s.anchor = pos
+	s.cursor = pos
+}
+
+// extend moves the end of an in-progress selection to the byte position.
+func (s *selection) extend(pos int) {
+	if !s.inProgress {
+		return
+	}
+	s.cursor = pos
+	s.active = s.anchor != s.cursor
+}
+
+// finish completes an in-progress selection.
+// Returns true if the selection is active, i.e. the user dragged the mouse
+// while holding the button.
+func (s *selection) finish() bool {
+	if !s.inProgress {
+		return false
+	}
+	s.inProgress = false
+	return s.active
+}
+
+// clear clears the selection.
+func (s *selection) clear() {
+	*s = selection{}
+}
+
+// contains returns true if the rune at the byte position is selected.
+func (s *selection) contains(pos int) bool {
+	if !s.active {
+		return false
+	}
+	low, high := s.anchor, s.cursor
+	if low > high {
+		low, high = high, low
+	}
+	return pos >= low && pos <= high
+}
+
+// text returns the selected part of the text.
+// Both the rune at the anchor and at the cursor are included in the result.
+func (s *selection) text(text string) string {
+	if !s.active {
+		return ""
+	}
+	low, high := s.anchor, s.cursor
+	if low > high {
+		low, high = high, low
+	}
+	if high >= len(text) {
+		return text[low:]
+	}
+	_, size := utf8.DecodeRuneInString(text[high:])
+	return text[low : high+size]
+}
@@ -0,0 +1,278 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+func TestCellMapPosAt(t *testing.T) {
+	// Represents "ab\n世" drawn on a 4x3 canvas at point (1, 0).
+	newMap := func() *cellMap {
+		cm := newCellMap(image.Rect(1, 0, 5, 3), []int{0, 3})
+		cm.set(image.Point{0, 0}, 1, 0)
+		cm.set(image.Point{1, 0}, 1, 1)
+		cm.set(image.Point{0, 1}, 2, 3)
+		return cm
+	}
+
+	tests := []struct {
+		desc   string
+		cm     *cellMap
+		p      image.Point
+		want   int
+		wantOk bool
+	}{
+		{
+			desc: "nothing was drawn",
+			cm:   newCellMap(image.Rect(0, 0, 2, 2), nil),
+			p:    image.Point{0, 0},
+		},
+		{
+			desc:   "rune under the point",
+			cm:     newMap(),
+			p:      image.Point{2, 0},
+			want:   1,
+			wantOk: true,
+		},
+		{
+			desc:   "second cell of a full-width rune",
+			cm:     newMap(),
+			p:      image.Point{2, 1},
+			want:   3,
+			wantOk: true,
+		},
+		{
+			desc:   "past the end of the row maps to the last rune",
+			cm:     newMap(),
+			p:      image.Point{4, 0},
+			want:   1,
+			wantOk: true,
+		},
+		{
+			desc:   "left of the text maps to the first rune",
+			cm:     newMap(),
+			p:      image.Point{0, 0},
+			want:   0,
+			wantOk: true,
+		},
+		{
+			desc:   "below the last drawn row maps to the last row",
+			cm:     newMap(),
+			p:      image.Point{1, 2},
+			want:   3,
+			wantOk: true,
+		},
+		{
+			desc: "row without runes maps to the start of the row",
+			cm: func() *cellMap {
+				cm := newCellMap(image.Rect(0, 0, 2, 2), []int{0, 1})
+				cm.set(image.Point{0, 0}, 1, 0)
+				return cm
+			}(),
+			p:      image.Point{1, 1},
+			want:   1,
+			wantOk: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotOk := tc.cm.posAt(tc.p)
+			if got != tc.want || gotOk != tc.wantOk {
+				t.Errorf("posAt(%v) => %v, %v, want %v, %v", tc.p, got, gotOk, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestSelection(t *testing.T) {
+	tests := []struct {
+		desc       string
+		text       string
+		update     func(*selection)
+		wantFinish bool
+		wantText   string
+	}{
+		{
+			desc:   "no selection",
+			text:   "abc",
+			update: func(*selection) {},
+		},
+		{
+			desc: "click without dragging",
+			text: "abc",
+			update: func(s *selection) {
+				s.start(1)
+				s.extend(1)
+			},
+		},
+		{
+			desc: "selects forward inclusive of both ends",
+			text: "abcd",
+			update: func(s *selection) {
+				s.start(1)
+				s.extend(2)
+			},
+			wantFinish: true,
+			wantText:   "bc",
+		},
+		{
+			desc: "selects backward",
+			text: "abcd",
+			update: func(s *selection) {
+				s.start(3)
+				s.extend(0)
+			},
+			wantFinish: true,
+			wantText:   "abcd",
+		},
+		{
+			desc: "includes the full-width rune at the end",
+			text: "a世b",
+			update: func(s *selection) {
+				s.start(0)
+				s.extend(1)
+			},
+			wantFinish: true,
+			wantText:   "a世",
+		},
+		{
+			desc: "extend is ignored without start",
+			text: "abc",
+			update: func(s *selection) {
+				s.extend(2)
+			},
+		},
+		{
+			desc: "clear removes the selection",
+			text: "abc",
+			update: func(s *selection) {
+				s.start(0)
+				s.extend(2)
+				s.clear()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var s selection
+			tc.update(&s)
+			if got := s.finish(); got != tc.wantFinish {
+				t.Errorf("finish => %v, want %v", got, tc.wantFinish)
+			}
+			if got := s.text(tc.text); got != tc.wantText {
+				t.Errorf("text => %q, want %q", got, tc.wantText)
+			}
+		})
+	}
+}
+
+func TestOnSelect(t *testing.T) {
+	tests := []struct {
+		desc   string
+		text   string
+		canvas image.Rectangle
+		events []*terminalapi.Mouse
+		want   []string
+	}{
+		{
+			desc:   "no callback without dragging",
+			text:   "hello",
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 0}, Button: mouse.ButtonRelease},
+			},
+		},
+		{
+			desc:   "reports text selected across lines",
+			text:   "hello\nworld",
+			canvas: image.Rect(0, 0, 10, 2),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{3, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonRelease},
+			},
+			want: []string{"lo\nwor"},
+		},
+		{
+			desc:   "reports text selected across wrapped lines",
+			text:   "hello world",
+			canvas: image.Rect(0, 0, 6, 2),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{4, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+			},
+			want: []string{"o wo"},
+		},
+		{
+			desc:   "reports each selection",
+			text:   "hello",
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 0}, Button: mouse.ButtonRelease},
+				{Position: image.Point{3, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonRelease},
+			},
+			want: []string{"he", "lo"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []string
+			widget, err := New(
+				WrapAtRunes(),
+				OnSelect(func(s string) {
+					got = append(got, s)
+				}),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := widget.Write(tc.text); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+			if err := widget.Draw(testcanvas.MustNew(tc.canvas)); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := widget.Mouse(ev); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("OnSelect called with %q, want %q", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("OnSelect call #%d => %q, want %q", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
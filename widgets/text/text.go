@@ -20,12 +20,18 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"sort"
 	"sync"
 	"unicode"
 
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/alignfor"
 	"github.com/mum4k/termdash/internal/attrrange"
 	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/runewidth"
 	"github.com/mum4k/termdash/internal/widgetapi"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
 
@@ -61,6 +67,40 @@ type Text struct {
 	// buffer. I.e. positions of newline characters and of any calculated line wraps.
 	lines []int
 
+	// textOrigin is the position of the area where the text is drawn within
+	// the last canvas provided to the widget.
+	textOrigin image.Point
+	// cellMap maps the cells of the last drawn canvas to positions in buff.
+	// Nil if the text wasn't drawn yet.
+	cellMap *cellMap
+	// sel tracks text selected with the mouse.
+	sel selection
+	// search tracks the matches of the text searched for with Search.
+	search *search
+
+	// scrollbarAr is the area of the last drawn scrollbar within the last
+	// canvas provided to the widget. Empty if the scrollbar wasn't drawn.
+	scrollbarAr image.Rectangle
+	// scrollbarDrag is true while the user drags the scrollbar with the mouse.
+	scrollbarDrag bool
+
+	// clickPressed is true while the user holds the left mouse button.
+	clickPressed bool
+	// clickPos is the byte position of the rune where the left mouse button
+	// was pressed or -1 if the mouse moved away from it since.
+	clickPos int
+
+	// afterCR is true if the last written text ended with a carriage return
+	// that was replaced by a newline.
+	afterCR bool
+	// overwriteAt is the byte position in buff where the next written text
+	// starts overwriting the current line after a carriage return or -1 if
+	// the text should be appended.
+	overwriteAt int
+
+	// notify requests a redraw of the terminal, nil until SetNotify is called.
+	notify func()
+
 	// mu protects the Text widget.
 	mu sync.Mutex
 
@@ -77,6 +117,9 @@ func New(opts ...Option) (*Text, error) {
 	return &Text{
 		wOptsTracker: attrrange.NewTracker(),
 		scroll:       newScrollTracker(opt),
+		search:       newSearch(),
+		clickPos:     -1,
+		overwriteAt:  -1,
 		opts:         opt,
 	}, nil
 }
@@ -86,25 +129,106 @@ func (t *Text) Reset() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.reset()
+	t.scroll = newScrollTracker(t.opts)
+	t.search = newSearch()
+	t.notifyChanged()
+}
+
+// SetNotify implements widgetapi.Notifier.SetNotify.
+// The widget requests a redraw each time its content changes.
+func (t *Text) SetNotify(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.notify = fn
 }
 
-// reset implements Reset, caller must hold t.mu.
+// Content returns all the text written to the widget since it was created or
+// last reset. This is the text before it is wrapped or trimmed when drawn on
+// the canvas, but after its newlines were normalized. Each "\r\n" sequence
+// is returned as a single newline and carriage returns are already applied
+// according to the CarriageReturns option, i.e. they were either replaced with
+// newlines or the text they overwrote is gone.
+func (t *Text) Content() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.buff.String()
+}
+
+// ContentChunk is a part of the content of the widget that shares the same
+// cell options.
+type ContentChunk struct {
+	// Text is the text of this part of the content.
+	Text string
+	// CellOpts are the cell options provided via WriteCellOpts when the text
+	// was written.
+	CellOpts *cell.Options
+}
+
+// ContentWithOptions returns all the text written to the widget since it was
+// created or last reset, split into chunks that share the same cell options.
+// Like Content, the returned text has its newlines normalized.
+// The chunks are returned in the order in which they appear in the content,
+// neighboring chunks might have equal options if they were written
+// separately. Returns nil if the widget has no content.
+func (t *Text) ContentWithOptions() ([]*ContentChunk, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	text := t.buff.String()
+	if text == "" {
+		return nil, nil
+	}
+	ranges, err := t.wOptsTracker.ForRange(0, len(text))
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*ContentChunk
+	for _, r := range ranges {
+		cellOpts := *t.givenWOpts[r.AttrIdx].cellOpts // Copy, so the caller can't modify the widget.
+		res = append(res, &ContentChunk{
+			Text:     text[r.Low:r.High],
+			CellOpts: &cellOpts,
+		})
+	}
+	return res, nil
+}
+
+// notifyChanged requests a redraw of the terminal if SetNotify was called.
+// Caller must hold t.mu.
+func (t *Text) notifyChanged() {
+	if t.notify != nil {
+		t.notify()
+	}
+}
+
+// reset clears the content of the widget, caller must hold t.mu.
+// Doesn't reset the scroll tracker.
 func (t *Text) reset() {
 	t.buff.Reset()
 	t.givenWOpts = nil
 	t.wOptsTracker = attrrange.NewTracker()
-	t.scroll = newScrollTracker(t.opts)
 	t.lastWidth = 0
 	t.contentChanged = true
 	t.lines = nil
+	t.cellMap = nil
+	t.sel.clear()
+	t.clickPressed = false
+	t.clickPos = -1
+	t.afterCR = false
+	t.overwriteAt = -1
 }
 
 // Write writes text for the widget to display. Multiple calls append
-// additional text. The text contain cannot control characters
+// additional text. The text cannot contain control characters
 // (unicode.IsControl) or space character (unicode.IsSpace) other than:
-//   ' ', '\n'
+//
+//	' ', '\n', '\r'
+//
 // Any newline ('\n') characters are interpreted as newlines when displaying
-// the text.
+// the text. The "\r\n" sequence is interpreted as a single newline, other
+// carriage returns ('\r') are interpreted according to the CarriageReturns
+// option.
 func (t *Text) Write(text string, wOpts ...WriteOption) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -112,23 +236,27 @@ func (t *Text) Write(text string, wOpts ...WriteOption) error {
 	if err := validText(text); err != nil {
 		return err
 	}
+	defer t.notifyChanged()
+	defer t.updateSearch()
 
 	opts := newWriteOptions(wOpts...)
 	if opts.replace {
 		t.reset()
+		t.scroll = newScrollTracker(t.opts)
 	}
 
-	pos := t.buff.Len()
 	t.givenWOpts = append(t.givenWOpts, opts)
 	wOptsIdx := len(t.givenWOpts) - 1
-	if err := t.wOptsTracker.Add(pos, pos+len(text), wOptsIdx); err != nil {
-		return err
+	t.contentChanged = true
+	if t.opts.carriageReturn == CarriageReturnOverwrite {
+		return t.writeOverwrite(text, wOptsIdx)
 	}
-	if _, err := t.buff.WriteString(text); err != nil {
-		return err
+
+	text, t.afterCR = normalizeNewlines(text, t.afterCR)
+	if text == "" {
+		return nil // Only the newline of a "\r\n" split across writes.
 	}
-	t.contentChanged = true
-	return nil
+	return t.appendText(text, wOptsIdx)
 }
 
 // minLinesForMarkers are the minimum amount of lines required on the canvas in
@@ -141,7 +269,7 @@ const minLinesForMarkers = 3
 func (t *Text) drawScrollUp(cvs *canvas.Canvas, cur image.Point, fromLine int) (bool, error) {
 	height := cvs.Area().Dy()
 	if cur.Y == 0 && height >= minLinesForMarkers && fromLine > 0 {
-		cells, err := cvs.SetCell(cur, '⇧')
+		cells, err := cvs.SetCell(image.Point{0, cur.Y}, '⇧')
 		if err != nil {
 			return false, err
 		}
@@ -160,7 +288,7 @@ func (t *Text) drawScrollDown(cvs *canvas.Canvas, cur image.Point, fromLine int)
 	height := cvs.Area().Dy()
 	lines := len(t.lines)
 	if cur.Y == height-1 && height >= minLinesForMarkers && height < lines-fromLine {
-		cells, err := cvs.SetCell(cur, '⇩')
+		cells, err := cvs.SetCell(image.Point{0, cur.Y}, '⇩')
 		if err != nil {
 			return false, err
 		}
@@ -172,20 +300,58 @@ func (t *Text) drawScrollDown(cvs *canvas.Canvas, cur image.Point, fromLine int)
 	return false, nil
 }
 
+// isLineStart determines if the byte position is the start of one of the
+// lines in t.lines.
+func (t *Text) isLineStart(pos int) bool {
+	i := sort.SearchInts(t.lines, pos)
+	return i < len(t.lines) && t.lines[i] == pos
+}
+
+// rowStart returns the horizontal position on the canvas where the line with
+// the specified index starts. Continuation rows of wrapped lines start after
+// the indent, see WrapIndent.
+func (t *Text) rowStart(text string, line, indent int) int {
+	if pos := t.lines[line]; pos > 0 && text[pos-1] != '\n' {
+		return indent
+	}
+	return 0
+}
+
 // draw draws the text context on the canvas starting at the specified line.
-func (t *Text) draw(text string, cvs *canvas.Canvas) error {
-	var cur image.Point // Tracks the current drawing position on the canvas.
+func (t *Text) draw(text string, cvs *canvas.Canvas, fromLine int) error {
+	indent := wrapIndent(cvs.Area().Dx(), t.opts)
+	// Tracks the current drawing position on the canvas.
+	cur := image.Point{t.rowStart(text, fromLine, indent), 0}
 	height := cvs.Area().Dy()
-	fromLine := t.scroll.firstLine(len(t.lines), height)
 	optRange, err := t.wOptsTracker.ForPosition(0) // Text options for the current byte.
 	if err != nil {
 		return err
 	}
 	startPos := t.lines[fromLine]
+	cm := newCellMap(cvs.Area().Add(t.textOrigin), t.lines[fromLine:])
+	var logical []int // Logical line indexes, only needed for the bands.
+	if t.opts.zebraStripe {
+		logical = logicalLines(text, t.lines)
+		if err := t.drawBands(cvs, logical, fromLine); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		t.cellMap = cm
+	}()
+	var prev rune // The previous rune in the text.
 	for i, r := range text {
+		extends := runewidth.ExtendsCluster(prev, r)
+		prev = r
 		if i < startPos {
 			continue
 		}
+		if extends {
+			// Runes that extend the grapheme cluster of the previous rune
+			// don't occupy any cells. They aren't drawn since each cell holds
+			// only one rune.
+			continue
+		}
 
 		// Scroll up marker.
 		scrlUp, err := t.drawScrollUp(cvs, cur, fromLine)
@@ -193,14 +359,22 @@ func (t *Text) draw(text string, cvs *canvas.Canvas) error {
 			return err
 		}
 		if scrlUp {
-			cur = image.Point{0, cur.Y + 1} // Move to the next line.
-			startPos = t.lines[fromLine+1]  // Skip one line of text, the marker replaced it.
+			// Move to the next line and skip one line of text, the marker
+			// replaced it.
+			cur = image.Point{t.rowStart(text, fromLine+1, indent), cur.Y + 1}
+			startPos = t.lines[fromLine+1]
 			continue
 		}
 
+		if r == '\n' && t.opts.collapseBlankLines && !t.isLineStart(i+1) {
+			continue // Ends a blank line that was collapsed.
+		}
+
 		// Line wrapping.
-		if r == '\n' || wrapNeeded(r, cur.X, cvs.Area().Dx(), t.opts) {
+		if r == '\n' {
 			cur = image.Point{0, cur.Y + 1} // Move to the next line.
+		} else if wrapNeeded(r, cur.X, cvs.Area().Dx(), t.opts) {
+			cur = image.Point{indent, cur.Y + 1} // Continue the line on the next row.
 		}
 
 		// Scroll down marker.
@@ -233,15 +407,39 @@ func (t *Text) draw(text string, cvs *canvas.Canvas) error {
 			optRange = or
 		}
 		wOpts := t.givenWOpts[optRange.AttrIdx]
-		cells, err := cvs.SetCell(cur, r, wOpts.cellOpts)
+		cellOpts := []cell.Option{wOpts.cellOpts}
+		if logical != nil {
+			if band := t.opts.bandOpts(logical[fromLine+cur.Y]); len(band) > 0 {
+				cellOpts = []cell.Option{withBand(wOpts.cellOpts, band)}
+			}
+		}
+		cellOpts = append(cellOpts, t.searchCellOpts(i)...)
+		if t.sel.contains(i) {
+			cellOpts = append(cellOpts, t.opts.selectionCellOpts...)
+		}
+		cells, err := cvs.SetCell(cur, r, cellOpts...)
 		if err != nil {
 			return err
 		}
+		cm.set(cur, cells, i)
 		cur = image.Point{cur.X + cells, cur.Y} // Move within the same line.
 	}
 	return nil
 }
 
+// contentArea returns the area of the canvas occupied by the text, the line
+// number gutter and the scrollbar. The extra is the width of the gutter and
+// the scrollbar in cells. The area is limited and aligned according to the
+// MaxWidth and MaxWidthAlign options.
+func (t *Text) contentArea(cvsAr image.Rectangle, extra int) (image.Rectangle, error) {
+	width := t.opts.maxWidth + extra
+	if t.opts.maxWidth == 0 || width >= cvsAr.Dx() {
+		return cvsAr, nil
+	}
+	block := image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Min.X+width, cvsAr.Max.Y)
+	return alignfor.Rectangle(cvsAr, block, t.opts.maxWidthAlign, align.VerticalTop)
+}
+
 // Draw draws the text onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (t *Text) Draw(cvs *canvas.Canvas) error {
@@ -249,7 +447,30 @@ func (t *Text) Draw(cvs *canvas.Canvas) error {
 	defer t.mu.Unlock()
 
 	text := t.buff.String()
-	width := cvs.Area().Dx()
+	cvsAr := cvs.Area()
+	var gutter int
+	if t.opts.showLineNumbers {
+		// Only draw the gutter if at least one cell remains for the text.
+		if g := gutterWidth(logicalLineCount(text)); g < cvsAr.Dx() {
+			gutter = g
+		}
+	}
+	var scrollbar int
+	if t.opts.showScrollbar && cvsAr.Dx()-gutter > 1 {
+		scrollbar = 1
+	}
+	contentAr, err := t.contentArea(cvsAr, gutter+scrollbar)
+	if err != nil {
+		return err
+	}
+
+	textAr := image.Rect(contentAr.Min.X+gutter, contentAr.Min.Y, contentAr.Max.X-scrollbar, contentAr.Max.Y)
+	t.scrollbarAr = image.ZR
+	if scrollbar > 0 {
+		t.scrollbarAr = image.Rect(textAr.Max.X, textAr.Min.Y, textAr.Max.X+1, textAr.Max.Y)
+	}
+
+	width := textAr.Dx()
 	if t.contentChanged || t.lastWidth != width {
 		// The previous text preprocessing (line wrapping) is invalidated when
 		// new text is added or the width of the canvas changed.
@@ -261,13 +482,62 @@ func (t *Text) Draw(cvs *canvas.Canvas) error {
 		return nil // Nothing to draw if there's no text.
 	}
 
-	if err := t.draw(text, cvs); err != nil {
+	t.textOrigin = textAr.Min
+	if pos, ok := t.search.takeCenter(); ok {
+		line := sort.SearchInts(t.lines, pos+1) - 1
+		t.scroll.scrollTo(line - textAr.Dy()/2)
+	}
+	fromLine := t.scroll.firstLine(len(t.lines), textAr.Dy())
+	textCvs, err := canvas.New(textAr)
+	if err != nil {
 		return err
 	}
+	if err := t.draw(text, textCvs, fromLine); err != nil {
+		return err
+	}
+	if err := textCvs.CopyTo(cvs); err != nil {
+		return err
+	}
+
+	if gutter > 0 {
+		if err := t.drawLineNumbers(cvs, text, fromLine, contentAr.Min.X, gutter); err != nil {
+			return err
+		}
+	}
+	if !t.scrollbarAr.Empty() {
+		if err := drawScrollbar(cvs, t.scrollbarAr, fromLine, len(t.lines), textAr.Dy()); err != nil {
+			return err
+		}
+	}
 	t.contentChanged = false
 	return nil
 }
 
+// SetFollow either forces the widget to roll the content so that the last line
+// of the text is always visible or disables the rolling. Scrolling the content
+// up with the keyboard or the mouse while following pauses the rolling until
+// the user scrolls back down to the last line.
+// Overrides the RollContent option until the content is replaced, see Reset
+// and WriteReplace, which restore the behavior set by the RollContent option.
+func (t *Text) SetFollow(follow bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scroll.setFollow(follow)
+}
+
+// Following returns true if the widget rolls the content so that the last
+// line of the text is always visible.
+// The returned value reflects the last drawn frame. SetFollow, Reset and
+// WriteReplace change it immediately, but scrolling with the keyboard or the
+// mouse pauses or resumes the rolling only once the widget is drawn, since
+// that depends on the size of the canvas. Until then the returned value
+// doesn't account for such scrolling.
+func (t *Text) Following() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.scroll.following()
+}
+
 // Keyboard implements widgetapi.Widget.Keyboard.
 func (t *Text) Keyboard(k *terminalapi.Keyboard) error {
 	t.mu.Lock()
@@ -289,17 +559,98 @@ func (t *Text) Keyboard(k *terminalapi.Keyboard) error {
 // Mouse implements widgetapi.Widget.Mouse.
 func (t *Text) Mouse(m *terminalapi.Mouse) error {
 	t.mu.Lock()
-	defer t.mu.Unlock()
+	var (
+		selected string
+		ok       bool
+
+		clickFn func(string)
+		clicked string
+		clickOk bool
+	)
+	if !t.mouseScrollbar(m) {
+		selected, ok = t.mouseSelect(m)
+		clickFn, clicked, clickOk = t.mouseClick(m)
+	}
+	if !t.opts.disableScrolling {
+		switch b := m.Button; {
+		case b == t.opts.mouseUpButton:
+			t.scroll.upOneLine()
+		case b == t.opts.mouseDownButton:
+			t.scroll.downOneLine()
+		}
+	}
+	t.mu.Unlock()
 
-	switch b := m.Button; {
-	case b == t.opts.mouseUpButton:
-		t.scroll.upOneLine()
-	case b == t.opts.mouseDownButton:
-		t.scroll.downOneLine()
+	// Called without holding the lock so that the callback can access the
+	// widget.
+	if ok {
+		t.opts.onSelect(selected)
+	}
+	if clickOk {
+		clickFn(clicked)
 	}
 	return nil
 }
 
+// mouseScrollbar processes mouse events that click on or drag the scrollbar.
+// Returns true if the event was consumed by the scrollbar.
+// Caller must hold t.mu.
+func (t *Text) mouseScrollbar(m *terminalapi.Mouse) bool {
+	if t.opts.disableScrolling || t.scrollbarAr.Empty() {
+		return false
+	}
+
+	switch m.Button {
+	case mouse.ButtonLeft:
+		if t.sel.inProgress {
+			return false
+		}
+		if !t.scrollbarDrag && !m.Position.In(t.scrollbarAr) {
+			return false
+		}
+		t.scrollbarDrag = true
+		row := m.Position.Y - t.scrollbarAr.Min.Y
+		t.scroll.scrollTo(scrollbarLine(row, len(t.lines), t.scrollbarAr.Dy(), t.scrollbarAr.Dy()))
+		return true
+
+	case mouse.ButtonRelease:
+		if !t.scrollbarDrag {
+			return false
+		}
+		t.scrollbarDrag = false
+		return true
+	}
+	return false
+}
+
+// mouseSelect processes mouse events that select text.
+// Returns the selected text and true if the user just finished selecting text.
+// Caller must hold t.mu.
+func (t *Text) mouseSelect(m *terminalapi.Mouse) (string, bool) {
+	if t.opts.onSelect == nil || t.cellMap == nil {
+		return "", false
+	}
+
+	switch m.Button {
+	case mouse.ButtonLeft:
+		pos, ok := t.cellMap.posAt(m.Position)
+		if !ok {
+			return "", false
+		}
+		if t.sel.inProgress {
+			t.sel.extend(pos)
+		} else {
+			t.sel.start(pos)
+		}
+
+	case mouse.ButtonRelease:
+		if t.sel.finish() {
+			return t.sel.text(t.buff.String()), true
+		}
+	}
+	return "", false
+}
+
 // Options of the widget
 func (t *Text) Options() widgetapi.Options {
 	var ks widgetapi.KeyScope
@@ -311,6 +662,9 @@ func (t *Text) Options() widgetapi.Options {
 		ks = widgetapi.KeyScopeFocused
 		ms = widgetapi.MouseScopeWidget
 	}
+	if t.opts.onSelect != nil || len(t.opts.clickHandlers) > 0 {
+		ms = widgetapi.MouseScopeWidget
+	}
 
 	return widgetapi.Options{
 		// At least one line with at least one full-width rune.
@@ -327,7 +681,7 @@ func validText(text string) error {
 	}
 
 	for _, c := range text {
-		if c == ' ' || c == '\n' { // Allowed space and control runes.
+		if c == ' ' || c == '\n' || c == '\r' { // Allowed space and control runes.
 			continue
 		}
 		if unicode.IsControl(c) {
@@ -15,10 +15,12 @@
 package text
 
 import (
+	"fmt"
 	"image"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
@@ -212,6 +214,52 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "write options follow the text across wrapped lines",
+			canvas: image.Rect(0, 0, 5, 2),
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				if err := widget.Write("abc"); err != nil {
+					return err
+				}
+				if err := widget.Write("defg", WriteCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				return widget.Write("hij", WriteCellOpts(cell.FgColor(cell.ColorBlue)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abc", image.Point{0, 0})
+				testdraw.MustText(c, "de", image.Point{3, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "fg", image.Point{0, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "hij", image.Point{2, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "reset clears the write options",
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				if err := widget.Write("red", WriteCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				widget.Reset()
+				return widget.Write("default")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "default", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "trims long lines",
 			canvas: image.Rect(0, 0, 10, 4),
@@ -247,6 +295,55 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "combining accents don't occupy cells",
+			canvas: image.Rect(0, 0, 3, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("e\u0301xe\u0301")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "exe", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims after a family emoji",
+			canvas: image.Rect(0, 0, 3, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("👨\u200d👩\u200d👧ab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "👨…", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "wraps after a family emoji",
+			canvas: image.Rect(0, 0, 3, 2),
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("👨\u200d👩\u200d👧ab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "👨a", image.Point{0, 0})
+				testdraw.MustText(c, "b", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "trims content when longer than canvas, no scroll marker on small canvas",
 			canvas: image.Rect(0, 0, 10, 2),
@@ -723,94 +820,1284 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			c, err := canvas.New(tc.canvas)
-			if err != nil {
-				t.Fatalf("canvas.New => unexpected error: %v", err)
-			}
-
-			widget, err := New(tc.opts...)
-			if (err != nil) != tc.wantErr {
-				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
-			}
-			if err != nil {
-				return
-			}
+		{
+			desc:   "draws line numbers with option",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a\nbc\nd")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-			if tc.writes != nil {
-				err := tc.writes(widget)
-				if (err != nil) != tc.wantWriteErr {
-					t.Errorf("Write => unexpected error: %v, wantWriteErr: %v", err, tc.wantWriteErr)
-				}
-				if err != nil {
-					return
-				}
-			}
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "a", image.Point{2, 0})
+				testdraw.MustText(c, "2", image.Point{0, 1})
+				testdraw.MustText(c, "bc", image.Point{2, 1})
+				testdraw.MustText(c, "3", image.Point{0, 2})
+				testdraw.MustText(c, "d", image.Point{2, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws line numbers with cell options",
+			canvas: image.Rect(0, 0, 5, 2),
+			opts: []Option{
+				ShowLineNumbers(cell.FgColor(cell.ColorRed), cell.BgColor(cell.ColorBlue)),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a\nb")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-			if tc.events != nil {
-				tc.events(widget)
-			}
+				opts := []cell.Option{cell.FgColor(cell.ColorRed), cell.BgColor(cell.ColorBlue)}
+				testdraw.MustText(c, "1", image.Point{0, 0}, draw.TextCellOpts(opts...))
+				testdraw.MustText(c, "a", image.Point{2, 0})
+				testdraw.MustText(c, "2", image.Point{0, 1}, draw.TextCellOpts(opts...))
+				testdraw.MustText(c, "b", image.Point{2, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line numbers reduce the width available for text",
+			canvas: image.Rect(0, 0, 5, 1),
+			opts: []Option{
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcdef")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-			if err := widget.Draw(c); err != nil {
-				t.Fatalf("Draw => unexpected error: %v", err)
-			}
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "ab…", image.Point{2, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line numbers only on the first row of wrapped lines",
+			canvas: image.Rect(0, 0, 5, 4),
+			opts: []Option{
+				ShowLineNumbers(),
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcdefg\nh")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-			got, err := faketerm.New(c.Size())
-			if err != nil {
-				t.Fatalf("faketerm.New => unexpected error: %v", err)
-			}
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "abc", image.Point{2, 0})
+				testdraw.MustText(c, "def", image.Point{2, 1})
+				testdraw.MustText(c, "g", image.Point{2, 2})
+				testdraw.MustText(c, "2", image.Point{0, 3})
+				testdraw.MustText(c, "h", image.Point{2, 3})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line number gutter grows past nine lines",
+			canvas: image.Rect(0, 0, 5, 10),
+			opts: []Option{
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("0\n1\n2\n3\n4\n5\n6\n7\n8\n9")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-			if err := c.Apply(got); err != nil {
-				t.Fatalf("Apply => unexpected error: %v", err)
-			}
+				for i := 0; i < 9; i++ {
+					testdraw.MustText(c, fmt.Sprintf("%d", i+1), image.Point{1, i})
+					testdraw.MustText(c, fmt.Sprintf("%d", i), image.Point{3, i})
+				}
+				testdraw.MustText(c, "10", image.Point{0, 9})
+				testdraw.MustText(c, "9", image.Point{3, 9})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line numbers aren't drawn on rows with scroll markers",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a\nb\nc\nd\ne")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: DefaultScrollKeyDown,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
-				t.Errorf("Draw => %v", diff)
-			}
-		})
-	}
-}
+				testdraw.MustText(c, "⇧", image.Point{2, 0})
+				testdraw.MustText(c, "3", image.Point{0, 1})
+				testdraw.MustText(c, "c", image.Point{2, 1})
+				testdraw.MustText(c, "⇩", image.Point{2, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line numbers aren't drawn if the canvas is too narrow",
+			canvas: image.Rect(0, 0, 2, 1),
+			opts: []Option{
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("ab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-func TestOptions(t *testing.T) {
-	tests := []struct {
-		desc string
-		opts []Option
-		want widgetapi.Options
-	}{
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
-			desc: "minimum size for one character",
-			want: widgetapi.Options{
-				MinimumSize:  image.Point{1, 1},
-				WantKeyboard: widgetapi.KeyScopeFocused,
-				WantMouse:    widgetapi.MouseScopeWidget,
+			desc: "fails when OnSelect is combined with the left scroll mouse button",
+			opts: []Option{
+				OnSelect(func(string) {}),
+				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonRight),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
 			},
+			wantErr: true,
 		},
 		{
-			desc: "disabling scrolling removes keyboard and mouse",
+			desc: "fails when OnClick is combined with the left scroll mouse button",
 			opts: []Option{
-				DisableScrolling(),
+				OnClick(func(string) bool { return true }, func(string) {}),
+				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonRight),
 			},
-			want: widgetapi.Options{
-				MinimumSize:  image.Point{1, 1},
-				WantKeyboard: widgetapi.KeyScopeNone,
-				WantMouse:    widgetapi.MouseScopeNone,
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
 			},
+			wantErr: true,
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			text, err := New(tc.opts...)
-			if err != nil {
-				t.Fatalf("New => unexpected error: %v", err)
-			}
-
-			got := text.Options()
-			if diff := pretty.Compare(tc.want, got); diff != "" {
-				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+		{
+			desc: "fails on invalid CarriageReturns",
+			opts: []Option{
+				CarriageReturns(CarriageReturnMode(-1)),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails when OnClick is provided without a matcher",
+			opts: []Option{
+				OnClick(nil, func(string) {}),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "highlights text selected with the mouse",
+			canvas: image.Rect(0, 0, 10, 2),
+			opts: []Option{
+				OnSelect(func(string) {}),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1")
+			},
+			events: func(widget *Text) {
+				widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 2)))
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{3, 0},
+					Button:   mouse.ButtonLeft,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{1, 1},
+					Button:   mouse.ButtonLeft,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{1, 1},
+					Button:   mouse.ButtonRelease,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				selOpts := []cell.Option{
+					cell.FgColor(DefaultSelectionFgColor),
+					cell.BgColor(DefaultSelectionBgColor),
+				}
+				testdraw.MustText(c, "e0", image.Point{3, 0}, draw.TextCellOpts(selOpts...))
+				testdraw.MustText(c, "li", image.Point{0, 1}, draw.TextCellOpts(selOpts...))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlights the matches of the search",
+			canvas: image.Rect(0, 0, 11, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("ab cd ab ab")
+			},
+			events: func(widget *Text) {
+				widget.Search("ab")
+				widget.SearchNext()
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				match := draw.TextCellOpts(cell.FgColor(DefaultSearchFgColor), cell.BgColor(DefaultSearchBgColor))
+				current := draw.TextCellOpts(cell.FgColor(DefaultSearchFgColor), cell.BgColor(DefaultSearchCurrentBgColor))
+				testdraw.MustText(c, "ab", image.Point{0, 0}, match)
+				testdraw.MustText(c, " cd ", image.Point{2, 0})
+				testdraw.MustText(c, "ab", image.Point{6, 0}, current)
+				testdraw.MustText(c, " ", image.Point{8, 0})
+				testdraw.MustText(c, "ab", image.Point{9, 0}, match)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlights the matches of the search with custom cell options",
+			canvas: image.Rect(0, 0, 5, 1),
+			opts: []Option{
+				SearchIgnoreCase(),
+				SearchCellOpts(cell.FgColor(cell.ColorBlue)),
+				SearchCurrentCellOpts(cell.FgColor(cell.ColorGreen)),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("Ab aB")
+			},
+			events: func(widget *Text) {
+				widget.Search("ab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Ab", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorGreen)))
+				testdraw.MustText(c, " ", image.Point{2, 0})
+				testdraw.MustText(c, "aB", image.Point{3, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls the current match into the middle of the canvas",
+			canvas: image.Rect(0, 0, 3, 5),
+			writes: func(widget *Text) error {
+				return widget.Write("0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11")
+			},
+			events: func(widget *Text) {
+				widget.Search("6")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "5", image.Point{0, 1})
+				testdraw.MustText(c, "6", image.Point{0, 2}, draw.TextCellOpts(
+					cell.FgColor(DefaultSearchFgColor),
+					cell.BgColor(DefaultSearchCurrentBgColor),
+				))
+				testdraw.MustText(c, "7", image.Point{0, 3})
+				testdraw.MustText(c, "⇩", image.Point{0, 4})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls to the previous match wrapping around",
+			canvas: image.Rect(0, 0, 3, 5),
+			writes: func(widget *Text) error {
+				return widget.Write("x\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\nx")
+			},
+			events: func(widget *Text) {
+				widget.Search("x")
+				widget.SearchPrev()
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "8", image.Point{0, 1})
+				testdraw.MustText(c, "9", image.Point{0, 2})
+				testdraw.MustText(c, "10", image.Point{0, 3})
+				testdraw.MustText(c, "x", image.Point{0, 4}, draw.TextCellOpts(
+					cell.FgColor(DefaultSearchFgColor),
+					cell.BgColor(DefaultSearchCurrentBgColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "selection uses custom cell options and accounts for line numbers",
+			canvas: image.Rect(0, 0, 10, 1),
+			opts: []Option{
+				OnSelect(func(string) {}),
+				SelectionCellOpts(cell.BgColor(cell.ColorRed)),
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcd")
+			},
+			events: func(widget *Text) {
+				widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 1)))
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{2, 0},
+					Button:   mouse.ButtonLeft,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{3, 0},
+					Button:   mouse.ButtonLeft,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{2, 0}, draw.TextCellOpts(cell.BgColor(cell.ColorRed)))
+				testdraw.MustText(c, "cd", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "click without dragging doesn't select text",
+			canvas: image.Rect(0, 0, 10, 1),
+			opts: []Option{
+				OnSelect(func(string) {}),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcd")
+			},
+			events: func(widget *Text) {
+				widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 1)))
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{1, 0},
+					Button:   mouse.ButtonLeft,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{1, 0},
+					Button:   mouse.ButtonRelease,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abcd", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "SetFollow rolls content without the RollContent option",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				widget.SetFollow(true)
+				return widget.Write("line0\nline1\nline2")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line1", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "SetFollow(false) overrides the RollContent option",
+			canvas: image.Rect(0, 0, 10, 2),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				widget.SetFollow(false)
+				return widget.Write("line0\nline1\nline2")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "replacing the text discards SetFollow(true)",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				widget.SetFollow(true)
+				if err := widget.Write("line0\nline1\nline2"); err != nil {
+					return err
+				}
+				return widget.Write("new0\nnew1\nnew2\nnew3", WriteReplace())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "new0", image.Point{0, 0})
+				testdraw.MustText(c, "new1", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "replacing the text discards SetFollow(false) and rolls per RollContent",
+			canvas: image.Rect(0, 0, 10, 2),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				widget.SetFollow(false)
+				if err := widget.Write("line0\nline1\nline2"); err != nil {
+					return err
+				}
+				return widget.Write("new0\nnew1\nnew2\nnew3", WriteReplace())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "new2", image.Point{0, 0})
+				testdraw.MustText(c, "new3", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails when scroll mouse buttons conflict with the scrollbar",
+			opts: []Option{
+				ShowScrollbar(),
+				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonRight),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws scrollbar with thumb over the whole track when the content fits",
+			canvas: image.Rect(0, 0, 4, 2),
+			opts: []Option{
+				ShowScrollbar(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("ab\ncd")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testdraw.MustText(c, "cd", image.Point{0, 1})
+				testdraw.MustText(c, "█", image.Point{3, 0})
+				testdraw.MustText(c, "█", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrollbar reduces the width available for text",
+			canvas: image.Rect(0, 0, 4, 1),
+			opts: []Option{
+				ShowScrollbar(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcd")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "ab…", image.Point{0, 0})
+				testdraw.MustText(c, "█", image.Point{3, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrollbar thumb reflects the visible fraction of the content",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				ShowScrollbar(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("1\n2\n3\n4\n5\n6\n7\n8")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "2", image.Point{0, 1})
+				testdraw.MustText(c, "3", image.Point{0, 2})
+				testdraw.MustText(c, "⇩", image.Point{0, 3})
+				testdraw.MustText(c, "█", image.Point{2, 0})
+				testdraw.MustText(c, "█", image.Point{2, 1})
+				testdraw.MustText(c, "│", image.Point{2, 2})
+				testdraw.MustText(c, "│", image.Point{2, 3})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking on the scrollbar scrolls to the position",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				ShowScrollbar(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("1\n2\n3\n4\n5\n6\n7\n8")
+			},
+			events: func(widget *Text) {
+				// Draw once so that the widget knows where the scrollbar is.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 3, 4))); err != nil {
+					panic(err)
+				}
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{2, 3},
+					Button:   mouse.ButtonLeft,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{2, 3},
+					Button:   mouse.ButtonRelease,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "6", image.Point{0, 1})
+				testdraw.MustText(c, "7", image.Point{0, 2})
+				testdraw.MustText(c, "8", image.Point{0, 3})
+				testdraw.MustText(c, "│", image.Point{2, 0})
+				testdraw.MustText(c, "│", image.Point{2, 1})
+				testdraw.MustText(c, "█", image.Point{2, 2})
+				testdraw.MustText(c, "█", image.Point{2, 3})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "dragging the scrollbar continues outside of the track",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				ShowScrollbar(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("1\n2\n3\n4\n5\n6\n7\n8")
+			},
+			events: func(widget *Text) {
+				// Draw once so that the widget knows where the scrollbar is.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 3, 4))); err != nil {
+					panic(err)
+				}
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{2, 3},
+					Button:   mouse.ButtonLeft,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{0, 0},
+					Button:   mouse.ButtonLeft,
+				})
+				widget.Mouse(&terminalapi.Mouse{
+					Position: image.Point{0, 0},
+					Button:   mouse.ButtonRelease,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "2", image.Point{0, 1})
+				testdraw.MustText(c, "3", image.Point{0, 2})
+				testdraw.MustText(c, "⇩", image.Point{0, 3})
+				testdraw.MustText(c, "█", image.Point{2, 0})
+				testdraw.MustText(c, "█", image.Point{2, 1})
+				testdraw.MustText(c, "│", image.Point{2, 2})
+				testdraw.MustText(c, "│", image.Point{2, 3})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws alternating bands with zebra striping",
+			canvas: image.Rect(0, 0, 3, 3),
+			opts: []Option{
+				ZebraStripe(
+					[]cell.Option{cell.BgColor(cell.ColorBlue)},
+					[]cell.Option{cell.BgColor(cell.ColorRed)},
+				),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a\nb")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCellOpts(c, image.Rect(0, 0, 3, 1), cell.BgColor(cell.ColorBlue))
+				testcanvas.MustSetAreaCellOpts(c, image.Rect(0, 1, 3, 2), cell.BgColor(cell.ColorRed))
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(cell.BgColor(cell.ColorBlue)))
+				testdraw.MustText(c, "b", image.Point{0, 1}, draw.TextCellOpts(cell.BgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "wrapped rows share the band of their logical line",
+			canvas: image.Rect(0, 0, 2, 3),
+			opts: []Option{
+				WrapAtRunes(),
+				ZebraStripe(
+					[]cell.Option{cell.BgColor(cell.ColorBlue)},
+					[]cell.Option{cell.BgColor(cell.ColorRed)},
+				),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abc\nd")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCellOpts(c, image.Rect(0, 0, 2, 2), cell.BgColor(cell.ColorBlue))
+				testcanvas.MustSetAreaCellOpts(c, image.Rect(0, 2, 2, 3), cell.BgColor(cell.ColorRed))
+				testdraw.MustText(c, "ab", image.Point{0, 0}, draw.TextCellOpts(cell.BgColor(cell.ColorBlue)))
+				testdraw.MustText(c, "c", image.Point{0, 1}, draw.TextCellOpts(cell.BgColor(cell.ColorBlue)))
+				testdraw.MustText(c, "d", image.Point{0, 2}, draw.TextCellOpts(cell.BgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "bands stay with their lines after scrolling",
+			canvas: image.Rect(0, 0, 2, 2),
+			opts: []Option{
+				ZebraStripe(
+					[]cell.Option{cell.BgColor(cell.ColorBlue)},
+					[]cell.Option{cell.BgColor(cell.ColorRed)},
+				),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a\nb\nc")
+			},
+			events: func(widget *Text) {
+				widget.Mouse(&terminalapi.Mouse{
+					Button: mouse.ButtonWheelDown,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCellOpts(c, image.Rect(0, 0, 2, 1), cell.BgColor(cell.ColorRed))
+				testcanvas.MustSetAreaCellOpts(c, image.Rect(0, 1, 2, 2), cell.BgColor(cell.ColorBlue))
+				testdraw.MustText(c, "b", image.Point{0, 0}, draw.TextCellOpts(cell.BgColor(cell.ColorRed)))
+				testdraw.MustText(c, "c", image.Point{0, 1}, draw.TextCellOpts(cell.BgColor(cell.ColorBlue)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "write cell options take precedence over the band",
+			canvas: image.Rect(0, 0, 2, 1),
+			opts: []Option{
+				ZebraStripe(
+					[]cell.Option{cell.FgColor(cell.ColorYellow), cell.BgColor(cell.ColorBlue)},
+					nil,
+				),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a", WriteCellOpts(cell.BgColor(cell.ColorGreen)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCellOpts(c, image.Rect(0, 0, 2, 1), cell.FgColor(cell.ColorYellow), cell.BgColor(cell.ColorBlue))
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorYellow), cell.BgColor(cell.ColorGreen)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails on negative wrap indent",
+			opts: []Option{
+				WrapIndent(-1),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "indents continuation rows of wrapped lines",
+			canvas: image.Rect(0, 0, 4, 4),
+			opts: []Option{
+				WrapAtRunes(),
+				WrapIndent(2),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcdefgh\nij")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abcd", image.Point{0, 0})
+				testdraw.MustText(c, "ef", image.Point{2, 1})
+				testdraw.MustText(c, "gh", image.Point{2, 2})
+				testdraw.MustText(c, "ij", image.Point{0, 3})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clamps wrap indent wider than the canvas",
+			canvas: image.Rect(0, 0, 3, 3),
+			opts: []Option{
+				WrapAtRunes(),
+				WrapIndent(10),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcdef")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abc", image.Point{0, 0})
+				testdraw.MustText(c, "de", image.Point{1, 1})
+				testdraw.MustText(c, "f", image.Point{1, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolled content starting on a continuation row is indented",
+			canvas: image.Rect(0, 0, 4, 2),
+			opts: []Option{
+				WrapAtRunes(),
+				WrapIndent(1),
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcdefghij")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "efg", image.Point{1, 0})
+				testdraw.MustText(c, "hij", image.Point{1, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails on negative maximum width",
+			opts: []Option{
+				MaxWidth(-1),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on unsupported maximum width alignment",
+			opts: []Option{
+				MaxWidthAlign(align.Horizontal(-1)),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "wraps at the maximum width and centers the text by default",
+			canvas: image.Rect(0, 0, 8, 3),
+			opts: []Option{
+				WrapAtRunes(),
+				MaxWidth(4),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcdefgh\nij")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abcd", image.Point{2, 0})
+				testdraw.MustText(c, "efgh", image.Point{2, 1})
+				testdraw.MustText(c, "ij", image.Point{2, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "aligns text limited by maximum width to the right",
+			canvas: image.Rect(0, 0, 6, 1),
+			opts: []Option{
+				MaxWidth(3),
+				MaxWidthAlign(align.HorizontalRight),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abc")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abc", image.Point{3, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims text at the maximum width aligned to the left",
+			canvas: image.Rect(0, 0, 6, 1),
+			opts: []Option{
+				MaxWidth(3),
+				MaxWidthAlign(align.HorizontalLeft),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcde")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "ab…", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "uses the full width when the canvas is narrower than the maximum width",
+			canvas: image.Rect(0, 0, 4, 2),
+			opts: []Option{
+				WrapAtRunes(),
+				MaxWidth(10),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcdef")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abcd", image.Point{0, 0})
+				testdraw.MustText(c, "ef", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line numbers are placed next to text limited by maximum width",
+			canvas: image.Rect(0, 0, 8, 1),
+			opts: []Option{
+				MaxWidth(2),
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("ab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "1", image.Point{2, 0})
+				testdraw.MustText(c, "ab", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails on negative maximum of consecutive blank lines",
+			opts: []Option{
+				MaxConsecutiveBlankLines(-1),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "collapses consecutive blank lines",
+			canvas: image.Rect(0, 0, 5, 5),
+			opts: []Option{
+				MaxConsecutiveBlankLines(1),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("\n\n\na\n\n\n\nb\nc")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "a", image.Point{0, 1})
+				testdraw.MustText(c, "b", image.Point{0, 3})
+				testdraw.MustText(c, "c", image.Point{0, 4})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line numbers skip over collapsed blank lines",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				MaxConsecutiveBlankLines(0),
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a\n\n\nb\nc")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "a", image.Point{2, 0})
+				testdraw.MustText(c, "4", image.Point{0, 1})
+				testdraw.MustText(c, "b", image.Point{2, 1})
+				testdraw.MustText(c, "5", image.Point{0, 2})
+				testdraw.MustText(c, "c", image.Point{2, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+
+			widget, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.writes != nil {
+				err := tc.writes(widget)
+				if (err != nil) != tc.wantWriteErr {
+					t.Errorf("Write => unexpected error: %v, wantWriteErr: %v", err, tc.wantWriteErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			if tc.events != nil {
+				tc.events(widget)
+			}
+
+			if err := widget.Draw(c); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "minimum size for one character",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "disabling scrolling removes keyboard and mouse",
+			opts: []Option{
+				DisableScrolling(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "OnSelect requests mouse events even with scrolling disabled",
+			opts: []Option{
+				DisableScrolling(),
+				OnSelect(func(string) {}),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			text, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			got := text.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	widget, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	// Writes before SetNotify is called don't notify.
+	if err := widget.Write("before"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+
+	var got int
+	widget.SetNotify(func() { got++ })
+	if err := widget.Write("hello"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if err := widget.Write("world", WriteReplace()); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	widget.Reset()
+
+	if want := 3; got != want {
+		t.Errorf("the notify function was called %d times, want %d", got, want)
+	}
+}
+
+func TestFollowing(t *testing.T) {
+	widget, err := New(RollContent())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := widget.Write("line0\nline1\nline2\nline3"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	cvs := testcanvas.MustNew(image.Rect(0, 0, 10, 2))
+	if err := widget.Draw(cvs); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got, want := widget.Following(), true; got != want {
+		t.Fatalf("Following => %v, want %v", got, want)
+	}
+
+	// Scrolling up is only evaluated when drawing.
+	if err := widget.Keyboard(&terminalapi.Keyboard{Key: DefaultScrollKeyUp}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if got, want := widget.Following(), true; got != want {
+		t.Errorf("Following before Draw => %v, want %v", got, want)
+	}
+	if err := widget.Draw(cvs); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got, want := widget.Following(), false; got != want {
+		t.Errorf("Following after Draw => %v, want %v", got, want)
+	}
+
+	// SetFollow and WriteReplace take effect immediately.
+	widget.SetFollow(true)
+	if got, want := widget.Following(), true; got != want {
+		t.Errorf("Following after SetFollow => %v, want %v", got, want)
+	}
+	widget.SetFollow(false)
+	if err := widget.Write("new", WriteReplace()); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if got, want := widget.Following(), true; got != want {
+		t.Errorf("Following after WriteReplace => %v, want %v", got, want)
+	}
+}
+
+func TestContent(t *testing.T) {
+	tests := []struct {
+		desc            string
+		opts            []Option
+		writes          func(*Text) error
+		want            string
+		wantWithOptions []*ContentChunk
+	}{
+		{
+			desc: "no content",
+		},
+		{
+			desc: "returns the content as written",
+			writes: func(widget *Text) error {
+				if err := widget.Write("hello\n"); err != nil {
+					return err
+				}
+				return widget.Write("world", WriteCellOpts(cell.FgColor(cell.ColorRed)))
+			},
+			want: "hello\nworld",
+			wantWithOptions: []*ContentChunk{
+				{Text: "hello\n", CellOpts: cell.NewOptions()},
+				{Text: "world", CellOpts: cell.NewOptions(cell.FgColor(cell.ColorRed))},
+			},
+		},
+		{
+			desc: "content isn't affected by wrapping",
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a very long line")
+			},
+			want: "a very long line",
+			wantWithOptions: []*ContentChunk{
+				{Text: "a very long line", CellOpts: cell.NewOptions()},
+			},
+		},
+		{
+			desc: "returns the content with normalized newlines",
+			writes: func(widget *Text) error {
+				if err := widget.Write("hello\r\nworld\r"); err != nil {
+					return err
+				}
+				return widget.Write("\nagain\rend")
+			},
+			want: "hello\nworld\nagain\nend",
+			wantWithOptions: []*ContentChunk{
+				{Text: "hello\nworld\n", CellOpts: cell.NewOptions()},
+				{Text: "again\nend", CellOpts: cell.NewOptions()},
+			},
+		},
+		{
+			desc: "returns the content with carriage returns applied",
+			opts: []Option{
+				CarriageReturns(CarriageReturnOverwrite),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("10%\r50%\r\ndone")
+			},
+			want: "50%\ndone",
+			wantWithOptions: []*ContentChunk{
+				{Text: "50%", CellOpts: cell.NewOptions()},
+				{Text: "\ndone", CellOpts: cell.NewOptions()},
+			},
+		},
+		{
+			desc: "returns only the content written after a replace",
+			writes: func(widget *Text) error {
+				if err := widget.Write("hello"); err != nil {
+					return err
+				}
+				return widget.Write("world", WriteReplace(), WriteCellOpts(cell.BgColor(cell.ColorBlue)))
+			},
+			want: "world",
+			wantWithOptions: []*ContentChunk{
+				{Text: "world", CellOpts: cell.NewOptions(cell.BgColor(cell.ColorBlue))},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			widget, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.writes != nil {
+				if err := tc.writes(widget); err != nil {
+					t.Fatalf("writes => unexpected error: %v", err)
+				}
+			}
+
+			// Drawing doesn't change the content.
+			cvs := testcanvas.MustNew(image.Rect(0, 0, 3, 3))
+			if err := widget.Draw(cvs); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if got := widget.Content(); got != tc.want {
+				t.Errorf("Content => %q, want %q", got, tc.want)
+			}
+			got, err := widget.ContentWithOptions()
+			if err != nil {
+				t.Fatalf("ContentWithOptions => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.wantWithOptions, got); diff != "" {
+				t.Errorf("ContentWithOptions => unexpected diff (-want, +got):\n%s", diff)
 			}
 		})
 	}
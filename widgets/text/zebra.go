@@ -0,0 +1,94 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// zebra.go contains code that draws the alternating backgrounds of lines.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+)
+
+// logicalLines returns the zero based indexes of the logical lines for the
+// provided lines which are starting positions of the lines in the text as
+// returned by findLines. The returned slice has the same length as lines,
+// continuation lines created by wrapping share the index of the logical line
+// they belong to.
+func logicalLines(text string, lines []int) []int {
+	res := make([]int, len(lines))
+	idx := -1
+	for i, start := range lines {
+		if start == 0 || text[start-1] == '\n' {
+			idx++
+		}
+		res[i] = idx
+	}
+	return res
+}
+
+// bandOpts returns the cell options of the band for the logical line with the
+// provided zero based index. Returns nil if zebra striping isn't enabled.
+func (o *options) bandOpts(logical int) []cell.Option {
+	if !o.zebraStripe {
+		return nil
+	}
+	if logical%2 == 0 {
+		return o.zebraEven
+	}
+	return o.zebraOdd
+}
+
+// withBand returns cell options that apply the provided write options on top
+// of the options of the band. Only the write options that differ from the
+// defaults override the band, so that text written without a background
+// color takes the background color of the band.
+func withBand(wOpts *cell.Options, band []cell.Option) *cell.Options {
+	res := cell.NewOptions(band...)
+	if wOpts.FgColor != cell.ColorDefault {
+		res.FgColor = wOpts.FgColor
+	}
+	if wOpts.BgColor != cell.ColorDefault {
+		res.BgColor = wOpts.BgColor
+	}
+	if wOpts.Blink {
+		res.Blink = true
+	}
+	return res
+}
+
+// drawBands applies the cell options of the bands to the full width of all
+// the rows on the canvas that contain text. The logical contains the logical
+// line indexes as returned by logicalLines and fromLine is the first line
+// drawn on the canvas.
+func (t *Text) drawBands(cvs *canvas.Canvas, logical []int, fromLine int) error {
+	ar := cvs.Area()
+	for row := 0; row < ar.Dy(); row++ {
+		line := fromLine + row
+		if line >= len(logical) {
+			break
+		}
+		band := t.opts.bandOpts(logical[line])
+		if len(band) == 0 {
+			continue
+		}
+		rowAr := image.Rect(ar.Min.X, row, ar.Max.X, row+1)
+		if err := cvs.SetAreaCellOpts(rowAr, band...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestLogicalLines(t *testing.T) {
+	tests := []struct {
+		desc     string
+		text     string
+		cvsWidth int
+		opts     *options
+		want     []int
+	}{
+		{
+			desc:     "no text",
+			text:     "",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{},
+		},
+		{
+			desc:     "multiple lines",
+			text:     "a\nb\nc",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{0, 1, 2},
+		},
+		{
+			desc:     "empty lines",
+			text:     "a\n\nc",
+			cvsWidth: 3,
+			opts:     &options{},
+			want:     []int{0, 1, 2},
+		},
+		{
+			desc:     "wrapped rows share the index of their line",
+			text:     "abcdefg\nh",
+			cvsWidth: 3,
+			opts: &options{
+				wrapAtRunes: true,
+			},
+			want: []int{0, 0, 0, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lines := findLines(tc.text, tc.cvsWidth, tc.opts)
+			got := logicalLines(tc.text, lines)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("logicalLines => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}